@@ -11,6 +11,7 @@ import (
 
 	"github.com/rubix-simulator/backend/internal/config"
 	"github.com/rubix-simulator/backend/internal/handlers"
+	"github.com/rubix-simulator/backend/internal/logging"
 	"github.com/rubix-simulator/backend/internal/middleware"
 	"github.com/rubix-simulator/backend/internal/services"
 
@@ -20,27 +21,25 @@ import (
 
 func main() {
 	cfg := config.Load()
+	logging.SetLevel(cfg.LogLevel)
 
 	nodeManager := services.NewNodeManager(cfg)
 	transactionExecutor := services.NewTransactionExecutor(cfg)
-	reportGenerator := services.NewReportGenerator(cfg)
-	simulationService := services.NewSimulationService(nodeManager, transactionExecutor, reportGenerator)
+	reportGenerator, err := services.NewReportGenerator(cfg)
+	if err != nil {
+		log.Fatalf("Cannot write reports: %v (set REPORTS_PATH to a writable directory)", err)
+	}
+	simulationService := services.NewSimulationService(cfg, nodeManager, transactionExecutor, reportGenerator)
 
 	handler := handlers.NewHandler(simulationService, reportGenerator)
 
 	// Auto-start token monitoring if nodes already exist
 	nodeManager.AutoStartTokenMonitoring()
 
-	// Optional: Start cleanup routine for very old finished simulations
-	// (commented out by default - users might want to keep finished reports)
-	// go func() {
-	// 	ticker := time.NewTicker(1 * time.Hour) // Clean up every hour
-	// 	defer ticker.Stop()
-	// 	
-	// 	for range ticker.C {
-	// 		simulationService.CleanupFinishedSimulations()
-	// 	}
-	// }()
+	// Clean up old finished simulations and their reports; kept forever unless configured
+	if cfg.ReportRetentionHours > 0 {
+		simulationService.StartReportCleanup(time.Duration(cfg.ReportRetentionHours) * time.Hour)
+	}
 
 	router := setupRouter(handler)
 
@@ -48,6 +47,7 @@ func main() {
 		AllowedOrigins:   []string{"http://localhost:5173", "http://localhost:3000"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
 		AllowedHeaders:   []string{"*"},
+		ExposedHeaders:   []string{"X-Total-Count"}, // Report count set by GET /reports/list
 		AllowCredentials: true,
 	})
 
@@ -58,6 +58,8 @@ func main() {
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
+	// Open progress streams would otherwise hold up srv.Shutdown until the deadline
+	srv.RegisterOnShutdown(simulationService.CloseStreams)
 
 	go func() {
 		log.Printf("Starting server on port %s", cfg.Port)
@@ -75,16 +77,37 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	// NOTE: Nodes are intentionally NOT stopped when server shuts down
-	// This allows nodes to continue running independently of the backend server
-	// if err := nodeManager.StopAllNodes(); err != nil {
-	// 	log.Printf("Error stopping nodes: %v", err)
-	// }
-
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Printf("Server forced to shutdown: %v", err)
 	}
 
+	// Checkpoint in-progress simulations so a restart doesn't lose or corrupt them
+	if err := simulationService.Shutdown(ctx); err != nil {
+		log.Printf("Simulations did not stop cleanly before shutdown deadline: %v", err)
+	}
+
+	// NOTE: By default nodes are intentionally NOT stopped when the server shuts down,
+	// so they keep running independently of the backend. CI setups can opt in to teardown.
+	if cfg.StopNodesOnShutdown {
+		nodeCount := len(nodeManager.GetNodes())
+		log.Printf("Stopping %d node(s) before exit...", nodeCount)
+
+		stopped := make(chan error, 1)
+		go func() {
+			stopped <- nodeManager.StopAllNodes()
+		}()
+		select {
+		case err := <-stopped:
+			if err != nil {
+				log.Printf("Error stopping nodes: %v", err)
+			} else {
+				log.Printf("Stopped %d node(s)", nodeCount)
+			}
+		case <-ctx.Done():
+			log.Printf("Nodes did not stop before shutdown deadline: %v", ctx.Err())
+		}
+	}
+
 	log.Println("Server exited")
 }
 
@@ -92,22 +115,45 @@ func setupRouter(h *handlers.Handler) *mux.Router {
 	r := mux.NewRouter()
 
 	r.HandleFunc("/health", h.HealthCheck).Methods("GET")
+	r.HandleFunc("/health/detailed", h.DetailedHealthCheck).Methods("GET")
+	r.HandleFunc("/version", h.GetVersion).Methods("GET")
+	r.HandleFunc("/metrics", h.GetMetrics).Methods("GET")
+	r.HandleFunc("/system/disk-usage", h.GetDiskUsage).Methods("GET")
 
 	// Node management endpoints
 	r.HandleFunc("/nodes/start", h.StartNodes).Methods("POST")
+	r.HandleFunc("/nodes/add", h.AddNodes).Methods("POST")
+	r.HandleFunc("/nodes/remove", h.RemoveNodes).Methods("POST")
 	r.HandleFunc("/nodes/stop", h.StopNodes).Methods("POST")
 	r.HandleFunc("/nodes/restart", h.RestartNodes).Methods("POST")
 	r.HandleFunc("/nodes/reset", h.ResetNodes).Methods("POST")
+	r.HandleFunc("/nodes/register-dids", h.RegisterDIDs).Methods("POST")
 	r.HandleFunc("/nodes/check-tokens", h.CheckTokenBalances).Methods("POST")
 	r.HandleFunc("/nodes/token-status", h.GetTokenMonitoringStatus).Methods("GET")
+	r.HandleFunc("/nodes/startup-status", h.GetStartupStatus).Methods("GET")
+	r.HandleFunc("/nodes/{id}/uptime", h.GetNodeUptime).Methods("GET")
+	r.HandleFunc("/nodes/{id}/metrics", h.GetNodeMetrics).Methods("GET")
+	r.HandleFunc("/nodes/{id}/logs", h.GetNodeLogs).Methods("GET")
+	r.HandleFunc("/nodes/{id}/balance", h.GetNodeBalance).Methods("GET")
+	r.HandleFunc("/nodes/{id}/label", h.SetNodeLabel).Methods("POST")
 
 	// Simulation endpoints
 	r.HandleFunc("/simulate", h.StartSimulation).Methods("POST")
 	r.HandleFunc("/report/{id}", h.GetSimulationStatus).Methods("GET")
 	r.HandleFunc("/simulations/active", h.GetActiveSimulations).Methods("GET")
+	r.HandleFunc("/simulations/compare", h.CompareSimulations).Methods("GET")
+	r.HandleFunc("/simulations/{id}/cancel", h.CancelSimulation).Methods("POST")
+	r.HandleFunc("/simulations/{id}/pause", h.PauseSimulation).Methods("POST")
+	r.HandleFunc("/simulations/{id}/resume", h.ResumeSimulation).Methods("POST")
+	r.HandleFunc("/simulations/{id}/stream", h.StreamSimulation).Methods("GET")
 
 	// Report endpoints
 	r.HandleFunc("/reports/{id}/download", h.DownloadReport).Methods("GET")
+	r.HandleFunc("/reports/{id}/download.json", h.DownloadReportJSON).Methods("GET")
+	r.HandleFunc("/reports/{id}/download.csv", h.DownloadReportCSV).Methods("GET")
+	r.HandleFunc("/reports/{id}/filtered", h.DownloadFilteredReport).Methods("POST")
+	r.HandleFunc("/reports/{id}/regenerate", h.RegenerateReport).Methods("POST")
+	r.HandleFunc("/reports/{id}/view", h.ViewReport).Methods("GET")
 	r.HandleFunc("/reports/list", h.ListReports).Methods("GET")
 
 	return r
@@ -55,7 +55,7 @@ func testManager() {
 
 	// Start nodes (7 quorum + 2 transaction)
 	log.Println("Starting nodes...")
-	err := manager.StartNodes(2, true)
+	err := manager.StartNodes(2, 0, 0, true)
 	if err != nil {
 		log.Fatalf("Failed to start nodes: %v", err)
 	}
@@ -1,9 +1,38 @@
 package config
 
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// InstancePortStride is the port spacing between simulator instances; it must exceed
+// the largest possible fleet (quorum + transaction nodes)
+const InstancePortStride = 100
+
+// DefaultInitialTokens is the number of test tokens generated for each new node when
+// InitialTokens is unset
+const DefaultInitialTokens = 100
+
+// ExternalNode describes a transaction node started and managed outside the simulator
+type ExternalNode struct {
+	ID   string `json:"id"`
+	Port int    `json:"port"`
+	DID  string `json:"did"`
+}
+
 // RubixConfig contains configuration for Rubix node management
 type RubixConfig struct {
 	// DataDir is the root directory for all Rubix-related data
 	DataDir string `json:"dataDir"`
+
+	// InstanceOffset isolates parallel simulator instances on one machine by shifting
+	// the data directory and all node ports (0 = default single instance)
+	InstanceOffset int `json:"instanceOffset"`
+
+	// ExternalNodes, when set, makes the simulator run against an existing fleet
+	// instead of starting and managing its own nodes
+	ExternalNodes []ExternalNode `json:"externalNodes"`
 	
 	// Network configuration
 	BaseServerPort int `json:"baseServerPort"`
@@ -13,16 +42,30 @@ type RubixConfig struct {
 	QuorumNodeCount     int `json:"quorumNodeCount"`
 	MinTransactionNodes int `json:"minTransactionNodes"`
 	MaxTransactionNodes int `json:"maxTransactionNodes"`
+	AutoScaleNodes      bool `json:"autoScaleNodes"` // Start extra transaction nodes when fewer exist than requested
+	NodeNaming          string `json:"nodeNaming"`   // "index" (node0..nodeN, default) or "role" (quorum0.., txn0..)
 	
 	// Timeouts and delays
 	NodeStartupDelay   int `json:"nodeStartupDelay"`   // Seconds to wait for node startup
 	NodeStartupTimeout int `json:"nodeStartupTimeout"` // Maximum seconds to wait for node
+	ProcessBootPollInterval int `json:"processBootPollInterval"` // Milliseconds between reachability checks after launching a node
+	CheckGrpcReadiness bool `json:"checkGrpcReadiness"` // Also require the gRPC port to accept connections before a node counts as ready
+	SignatureTimeout   int `json:"signatureTimeout"`   // Maximum seconds to wait for a signature/consensus response
+	DefaultAPITimeout  int `json:"defaultApiTimeout"`  // Maximum seconds for any other node API call
+	MaxTransactionRetries int `json:"maxTransactionRetries"` // Extra attempts for a transfer that errors (0 = no retries)
+	BatchTransferConcurrency int `json:"batchTransferConcurrency"` // Transfers in flight per sender node in batch mode
+	
+	// Logging
+	LogSampleEvery int `json:"logSampleEvery"` // Log full detail for every Nth transaction only (0 or 1 logs all)
 	
 	// Rubix platform settings
 	RubixRepoURL    string `json:"rubixRepoUrl"`
 	RubixBranch     string `json:"rubixBranch"`
 	IPFSVersion     string `json:"ipfsVersion"`
 	TestSwarmKeyURL string `json:"testSwarmKeyUrl"`
+	IPFSSHA256         string `json:"ipfsSha256"`         // Expected SHA-256 of the kubo archive; empty uses the release's published SHA-512
+	TestSwarmKeySHA256 string `json:"testSwarmKeySha256"` // Expected SHA-256 of the swarm key; empty skips verification
+	SkipPlatformUpdate bool `json:"skipPlatformUpdate"` // Use an existing checkout as-is instead of running git pull
 	
 	// Default passwords (for testing only)
 	DefaultPrivKeyPassword   string `json:"defaultPrivKeyPassword"`
@@ -33,6 +76,11 @@ type RubixConfig struct {
 	TokenMonitoringInterval   int     `json:"tokenMonitoringInterval"`   // Minutes between balance checks
 	MinTokenBalance          float64 `json:"minTokenBalance"`           // Minimum balance threshold (RBT)
 	TokenRefillAmount        int     `json:"tokenRefillAmount"`         // Amount to generate when below threshold
+	InitialTokens            int     `json:"initialTokens"`             // Test tokens generated for each new node
+	TokenGenRetries          int     `json:"tokenGenRetries"`           // Attempts per node when generating test tokens
+	TokenGenVerifyTimeout    int     `json:"tokenGenVerifyTimeout"`     // Seconds to wait for generated tokens to show in the balance
+	TokenGenTolerance        float64 `json:"tokenGenTolerance"`         // RBT a generation may fall short of the requested amount and still succeed
+	MaxTotalGeneratedTokens  int     `json:"maxTotalGeneratedTokens"`   // Fleet-wide cap on generated test tokens (0 = unlimited)
 	// Note: Token monitoring automatically pauses during active simulations to avoid interfering with transaction results
 }
 
@@ -47,6 +95,11 @@ func DefaultRubixConfig() *RubixConfig {
 		MaxTransactionNodes: 20,
 		NodeStartupDelay:    40,
 		NodeStartupTimeout:  120,  // Increased to 2 minutes for slower systems
+		ProcessBootPollInterval: 1000,
+		SignatureTimeout:    900,  // 15 minutes, consensus can be slow on a busy testnet
+		DefaultAPITimeout:   300,
+		BatchTransferConcurrency: 4,
+		LogSampleEvery:      10,
 		RubixRepoURL:        "https://github.com/rubixchain/rubixgoplatform.git",
 		RubixBranch:         "main",
 		IPFSVersion:         "v0.21.0",
@@ -59,5 +112,40 @@ func DefaultRubixConfig() *RubixConfig {
 		TokenMonitoringInterval: 10,     // 10 minutes
 		MinTokenBalance:        1000.0,  // 1000 RBT threshold
 		TokenRefillAmount:      100,     // Generate 100 tokens when below threshold
+		InitialTokens:          DefaultInitialTokens,
+		TokenGenRetries:        3,
+		TokenGenVerifyTimeout:  50,      // 50 seconds
+	}
+}
+
+// ForInstance returns a copy of the configuration with the data directory and base
+// ports shifted according to InstanceOffset, so several backends can coexist
+func (c *RubixConfig) ForInstance() *RubixConfig {
+	instanceCfg := *c
+	if c.InstanceOffset <= 0 {
+		return &instanceCfg
+	}
+
+	instanceCfg.DataDir = fmt.Sprintf("%s-%d", c.DataDir, c.InstanceOffset)
+	instanceCfg.BaseServerPort = c.BaseServerPort + c.InstanceOffset*InstancePortStride
+	instanceCfg.BaseGrpcPort = c.BaseGrpcPort + c.InstanceOffset*InstancePortStride
+	return &instanceCfg
+}
+
+// LoadRubixConfig returns the default configuration overlaid with any values set in
+// the JSON file at path. An empty path returns the defaults unchanged.
+func LoadRubixConfig(path string) (*RubixConfig, error) {
+	cfg := DefaultRubixConfig()
+	if path == "" {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return DefaultRubixConfig(), err
 	}
+	return cfg, nil
 }
\ No newline at end of file
@@ -1,26 +1,66 @@
 package config
 
 import (
+	"log"
+	"log/slog"
 	"os"
+	"strconv"
+
+	rubixconfig "github.com/rubix-simulator/backend/config"
+	"github.com/rubix-simulator/backend/internal/logging"
 )
 
 type Config struct {
 	Port            string
 	RubixScriptPath string
 	ReportsPath     string
+	ReportsIndex    string // File name of the reports index kept in the reports directory ("none" disables it)
 	MaxNodes        int
-	MaxTransactions int
+	MaxTransactions int // Most transactions a single simulation may request
 	ExplorerBaseURL string
+	SettleSeconds   int // Seconds to wait after a run before re-verifying balances (0 disables)
+	StaleMinutes    int // Minutes without a heartbeat before a running simulation is marked stalled
+	SnapshotSeconds int // Minimum seconds between on-disk progress snapshots during a run (0 disables)
+	StopNodesOnShutdown bool // Stop all nodes when the server exits instead of leaving them running
+	ThroughputWindowSeconds int // Width of the windows the report's throughput series is bucketed into
+	TokenRangeBuckets int // Number of amount ranges the report's token analysis and chart split transfers into
+	ReportRetentionHours int // Hours finished simulations and their report files are kept (0 keeps them forever)
+	LogLevel        slog.Level // Minimum level written by the logging package (debug, info, warn or error)
+	Rubix           *rubixconfig.RubixConfig
 }
 
 func Load() *Config {
+	rubixConfigPath := getEnv("RUBIX_CONFIG", "")
+	rubixCfg, err := rubixconfig.LoadRubixConfig(rubixConfigPath)
+	if err != nil {
+		log.Printf("Warning: failed to load Rubix config from %s, using defaults: %v", rubixConfigPath, err)
+	}
+	if offset := getEnvInt("RUBIX_INSTANCE_OFFSET", 0); offset > 0 {
+		rubixCfg.InstanceOffset = offset
+	}
+
+	logLevel, err := logging.ParseLevel(getEnv("LOG_LEVEL", "info"))
+	if err != nil {
+		log.Printf("Warning: %v, using info", err)
+	}
+
 	return &Config{
 		Port:            getEnv("PORT", "8080"),
 		RubixScriptPath: getEnv("RUBIX_SCRIPT_PATH", "./scripts/rubix_node_manager.py"),
 		ReportsPath:     getEnv("REPORTS_PATH", "./reports"),
+		ReportsIndex:    getEnv("REPORTS_INDEX_FILE", "index.json"),
 		MaxNodes:        20,
-		MaxTransactions: 500,
+		MaxTransactions: getEnvInt("MAX_TRANSACTIONS", 500),
 		ExplorerBaseURL: getEnv("EXPLORER_BASE_URL", "https://testnet.rubixexplorer.com/#/transaction"),
+		SettleSeconds:   getEnvInt("SETTLE_SECONDS", 0),
+		StaleMinutes:    getEnvInt("SIMULATION_STALE_MINUTES", 60),
+		SnapshotSeconds: getEnvInt("SIMULATION_SNAPSHOT_SECONDS", 30),
+		StopNodesOnShutdown: getEnvBool("STOP_NODES_ON_SHUTDOWN", false),
+		ThroughputWindowSeconds: getEnvInt("THROUGHPUT_WINDOW_SECONDS", 5),
+		TokenRangeBuckets: getEnvInt("TOKEN_RANGE_BUCKETS", 10),
+		ReportRetentionHours: getEnvInt("REPORT_RETENTION_HOURS", 0),
+		LogLevel:        logLevel,
+		Rubix:           rubixCfg,
 	}
 }
 
@@ -29,4 +69,22 @@ func getEnv(key, defaultValue string) string {
 		return value
 	}
 	return defaultValue
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
 }
\ No newline at end of file
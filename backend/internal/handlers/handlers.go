@@ -1,22 +1,32 @@
 package handlers
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 	"fmt"
 
 	"github.com/gorilla/mux"
+	"github.com/rubix-simulator/backend/internal/logging"
+	"github.com/rubix-simulator/backend/internal/metrics"
 	"github.com/rubix-simulator/backend/internal/models"
+	"github.com/rubix-simulator/backend/internal/rubix"
 	"github.com/rubix-simulator/backend/internal/services"
+	"github.com/rubix-simulator/backend/internal/version"
 )
 
 type Handler struct {
 	simulationService *services.SimulationService
 	reportGenerator   *services.ReportGenerator
 	nodeManager       *services.NodeManager
+	startedAt         time.Time
 }
 
 func NewHandler(ss *services.SimulationService, rg *services.ReportGenerator) *Handler {
@@ -24,6 +34,7 @@ func NewHandler(ss *services.SimulationService, rg *services.ReportGenerator) *H
 		simulationService: ss,
 		reportGenerator:   rg,
 		nodeManager:       ss.GetNodeManager(),
+		startedAt:         time.Now(),
 	}
 }
 
@@ -31,17 +42,60 @@ func (h *Handler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	response := models.HealthResponse{
 		Status:    "healthy",
 		Timestamp: time.Now(),
-		Version:   "1.0.0",
+		Version:   version.Version,
 	}
 	
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
+// DetailedHealthCheck pings the nodes and reports whether a simulation could run. It
+// returns 503 when fewer than 2 transaction nodes are running, so orchestrators can gate
+// traffic on it; /health stays a plain liveness check.
+func (h *Handler) DetailedHealthCheck(w http.ResponseWriter, r *http.Request) {
+	total, running, runningTransaction := h.nodeManager.CountNodeStatuses()
+
+	response := models.DetailedHealthResponse{
+		Status:                  "healthy",
+		Timestamp:               time.Now(),
+		Version:                 version.Version,
+		UptimeSeconds:           time.Since(h.startedAt).Seconds(),
+		TotalNodes:              total,
+		RunningNodes:            running,
+		FailedNodes:             total - running,
+		RunningTransactionNodes: runningTransaction,
+		SimulationActive:        h.simulationService.IsSimulationRunning(),
+	}
+
+	status := http.StatusOK
+	if runningTransaction < 2 {
+		response.Status = "degraded"
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetVersion reports the simulator build version, commit, build time and Go version
+func (h *Handler) GetVersion(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(version.Get())
+}
+
+// GetMetrics exports the simulator metrics in the Prometheus text format
+func (h *Handler) GetMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	metrics.WriteText(w)
+}
+
 func (h *Handler) StartNodes(w http.ResponseWriter, r *http.Request) {
 	var req struct {
-		Count int  `json:"count"`
-		Fresh bool `json:"fresh"`
+		Count         int  `json:"count"`
+		QuorumCount   int  `json:"quorumCount"`   // Quorum size of a fresh fleet (odd, at least 3); 0 keeps the current size
+		InitialTokens int  `json:"initialTokens"` // Test tokens for each new node; 0 uses the configured amount
+		Fresh         bool `json:"fresh"`
 	}
 	
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -53,9 +107,19 @@ func (h *Handler) StartNodes(w http.ResponseWriter, r *http.Request) {
 	if req.Count == 0 {
 		req.Count = 2
 	}
+	if req.QuorumCount != 0 {
+		if err := rubix.ValidateQuorumCount(req.QuorumCount); err != nil {
+			h.sendError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if req.InitialTokens < 0 {
+		h.sendError(w, "initialTokens must not be negative", http.StatusBadRequest)
+		return
+	}
 	
 	// Start nodes using the node manager
-	nodes, err := h.nodeManager.StartNodesWithOptions(req.Count, req.Fresh)
+	nodes, err := h.nodeManager.StartNodesWithOptions(req.Count, req.QuorumCount, req.InitialTokens, req.Fresh)
 	if err != nil {
 		h.sendError(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -70,6 +134,93 @@ func (h *Handler) StartNodes(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// AddNodes starts more transaction nodes next to the running fleet
+func (h *Handler) AddNodes(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Count int `json:"count"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Default to a single node if not specified
+	if req.Count == 0 {
+		req.Count = 1
+	}
+	if req.Count < 1 || req.Count > 20 {
+		h.sendError(w, "count must be between 1 and 20", http.StatusBadRequest)
+		return
+	}
+	// A running simulation has picked its nodes from the fleet as it is
+	if h.simulationService.IsSimulationRunning() {
+		h.sendError(w, "cannot add nodes while a simulation is running", http.StatusConflict)
+		return
+	}
+
+	added, err := h.nodeManager.AddNodes(req.Count)
+	if err != nil {
+		if errors.Is(err, rubix.ErrTooManyTransactionNodes) {
+			h.sendError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		h.sendError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	nodes := h.nodeManager.GetNodes()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": fmt.Sprintf("Added %d transaction node(s)", len(added)),
+		"added":   added,
+		"nodes":   nodes,
+		"total":   len(nodes),
+	})
+}
+
+// RemoveNodes shuts down specific transaction nodes and removes them from the fleet
+func (h *Handler) RemoveNodes(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		NodeIDs []string `json:"nodeIds"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if len(req.NodeIDs) == 0 {
+		h.sendError(w, "nodeIds must list at least one node", http.StatusBadRequest)
+		return
+	}
+	// A running simulation may be sending through any of them
+	if h.simulationService.IsSimulationRunning() {
+		h.sendError(w, "cannot remove nodes while a simulation is running", http.StatusConflict)
+		return
+	}
+
+	if err := h.nodeManager.RemoveNodes(req.NodeIDs); err != nil {
+		switch {
+		case errors.Is(err, rubix.ErrNodeNotFound):
+			h.sendError(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, rubix.ErrQuorumNodeRemoval), errors.Is(err, rubix.ErrTooFewTransactionNodes):
+			h.sendError(w, err.Error(), http.StatusBadRequest)
+		default:
+			h.sendError(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	nodes := h.nodeManager.GetNodes()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": fmt.Sprintf("Removed %d node(s)", len(req.NodeIDs)),
+		"removed": req.NodeIDs,
+		"nodes":   nodes,
+		"total":   len(nodes),
+	})
+}
+
 func (h *Handler) StopNodes(w http.ResponseWriter, r *http.Request) {
 	err := h.nodeManager.StopAllNodes()
 	if err != nil {
@@ -110,6 +261,170 @@ func (h *Handler) ResetNodes(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// RegisterDIDs re-registers node DIDs with the network, e.g. after peer discovery
+// problems. By default every running node is registered again; with
+// {"onlyUnconfirmed": true} nodes whose registration is already confirmed are skipped.
+func (h *Handler) RegisterDIDs(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		OnlyUnconfirmed bool `json:"onlyUnconfirmed"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	registered, err := h.nodeManager.RegisterDIDs(!req.OnlyUnconfirmed)
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":    true,
+		"message":    "DIDs registered",
+		"registered": registered,
+	})
+}
+
+func (h *Handler) GetNodeUptime(w http.ResponseWriter, r *http.Request) {
+	nodeID := mux.Vars(r)["id"]
+
+	uptime, err := h.nodeManager.GetNodeUptime(nodeID)
+	if err != nil {
+		switch {
+		case errors.Is(err, rubix.ErrNodeNotFound):
+			h.sendError(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, rubix.ErrUptimeUnknown):
+			h.sendError(w, err.Error(), http.StatusNotImplemented)
+		default:
+			h.sendError(w, err.Error(), http.StatusBadGateway)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"nodeId":        nodeID,
+		"uptime":        uptime.String(),
+		"uptimeSeconds": int64(uptime.Seconds()),
+		"timestamp":     time.Now(),
+	})
+}
+
+// GetNodeMetrics returns live metrics for one node, such as its peer count and account balance
+func (h *Handler) GetNodeMetrics(w http.ResponseWriter, r *http.Request) {
+	nodeID := mux.Vars(r)["id"]
+
+	metrics, err := h.nodeManager.GetNodeMetrics(nodeID)
+	if err != nil {
+		if errors.Is(err, rubix.ErrNodeNotFound) {
+			h.sendError(w, err.Error(), http.StatusNotFound)
+		} else {
+			h.sendError(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(metrics)
+}
+
+// GetNodeBalance returns a node's DID with its available RBT balance and the pledged,
+// locked and pinned amounts
+func (h *Handler) GetNodeBalance(w http.ResponseWriter, r *http.Request) {
+	nodeID := mux.Vars(r)["id"]
+
+	info, err := h.nodeManager.GetNodeAccountInfo(nodeID)
+	if err != nil {
+		if errors.Is(err, rubix.ErrNodeNotFound) {
+			h.sendError(w, err.Error(), http.StatusNotFound)
+		} else {
+			h.sendError(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"nodeId":  nodeID,
+		"did":     info.DID,
+		"balance": info.RBTAmount,
+		"pledged": info.PledgedRBT,
+		"locked":  info.LockedRBT,
+		"pinned":  info.PinnedRBT,
+	})
+}
+
+// maxNodeLabelLength bounds node labels so they fit report tables
+const maxNodeLabelLength = 32
+
+// SetNodeLabel names a node for reports; an empty label clears it
+func (h *Handler) SetNodeLabel(w http.ResponseWriter, r *http.Request) {
+	nodeID := mux.Vars(r)["id"]
+
+	var req struct {
+		Label string `json:"label"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	label := strings.TrimSpace(req.Label)
+	if len(label) > maxNodeLabelLength {
+		h.sendError(w, fmt.Sprintf("label must be at most %d characters", maxNodeLabelLength), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.nodeManager.SetNodeLabel(nodeID, label); err != nil {
+		if errors.Is(err, rubix.ErrNodeNotFound) {
+			h.sendError(w, err.Error(), http.StatusNotFound)
+		} else {
+			h.sendError(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"nodeId":  nodeID,
+		"label":   label,
+	})
+}
+
+// GetNodeLogs returns the tail of a node's captured output; ?lines=N picks how many
+// lines (default 100, at most 5000)
+func (h *Handler) GetNodeLogs(w http.ResponseWriter, r *http.Request) {
+	nodeID := mux.Vars(r)["id"]
+
+	lines := 100
+	if value := r.URL.Query().Get("lines"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > 5000 {
+			h.sendError(w, "lines must be between 1 and 5000", http.StatusBadRequest)
+			return
+		}
+		lines = parsed
+	}
+
+	logLines, err := h.nodeManager.GetNodeLogs(nodeID, lines)
+	if err != nil {
+		if errors.Is(err, rubix.ErrNodeNotFound) || errors.Is(err, rubix.ErrNodeLogNotFound) {
+			h.sendError(w, err.Error(), http.StatusNotFound)
+		} else {
+			h.sendError(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"nodeId": nodeID,
+		"lines":  logLines,
+	})
+}
+
 func (h *Handler) StartSimulation(w http.ResponseWriter, r *http.Request) {
 	var req models.SimulationRequest
 	
@@ -118,7 +433,7 @@ func (h *Handler) StartSimulation(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	
-	simulationID, err := h.simulationService.StartSimulation(req.Nodes, req.Transactions)
+	simulationID, err := h.simulationService.StartSimulation(req)
 	if err != nil {
 		h.sendError(w, err.Error(), http.StatusBadRequest)
 		return
@@ -128,6 +443,9 @@ func (h *Handler) StartSimulation(w http.ResponseWriter, r *http.Request) {
 		SimulationID: simulationID,
 		Message:      "Simulation started successfully",
 	}
+	if report, err := h.simulationService.GetSimulationReport(simulationID); err == nil {
+		response.Warnings = report.Warnings
+	}
 	
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -175,35 +493,271 @@ func (h *Handler) DownloadReport(w http.ResponseWriter, r *http.Request) {
 	io.Copy(w, file)
 }
 
+// DownloadReportJSON exports the in-memory simulation report, including every transaction
+// and the per-node breakdown, as indented JSON. Running simulations can be exported mid-flight.
+// ?status=success|failed and ?node=<id> keep only the matching transactions and recompute
+// the report's counts and metrics from them.
+func (h *Handler) DownloadReportJSON(w http.ResponseWriter, r *http.Request) {
+	reportID := mux.Vars(r)["id"]
+
+	filter := models.ReportFilter{
+		Status: r.URL.Query().Get("status"),
+		Node:   r.URL.Query().Get("node"),
+	}
+	if filter.Status != "" && filter.Status != "success" && filter.Status != "failed" {
+		h.sendError(w, "status must be \"success\" or \"failed\"", http.StatusBadRequest)
+		return
+	}
+
+	var report *models.SimulationReport
+	var err error
+	if filter.Status != "" || filter.Node != "" {
+		report, err = h.simulationService.GetFilteredReport(reportID, filter)
+	} else {
+		report, err = h.simulationService.GetReport(reportID)
+	}
+	if err != nil {
+		h.sendError(w, "Report not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", "attachment; filename=simulation-"+reportID+".json")
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(report)
+}
+
+// DownloadReportCSV exports the simulation's transaction log as CSV for spreadsheet analysis
+func (h *Handler) DownloadReportCSV(w http.ResponseWriter, r *http.Request) {
+	reportID := mux.Vars(r)["id"]
+
+	report, err := h.simulationService.GetReport(reportID)
+	if err != nil {
+		h.sendError(w, "Report not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=transactions-%s.csv", report.SimulationID))
+	// The rows are already streaming, so a failure can only be logged
+	if err := h.reportGenerator.WriteCSV(w, report); err != nil {
+		logging.Errorf("Failed to stream CSV of simulation %s: %v", reportID, err)
+	}
+}
+
+func (h *Handler) DownloadFilteredReport(w http.ResponseWriter, r *http.Request) {
+	reportID := mux.Vars(r)["id"]
+
+	var filter models.ReportFilter
+	if err := json.NewDecoder(r.Body).Decode(&filter); err != nil && err != io.EOF {
+		h.sendError(w, "Invalid filter body", http.StatusBadRequest)
+		return
+	}
+	if filter.Status != "" && filter.Status != "success" && filter.Status != "failed" {
+		h.sendError(w, "status must be \"success\" or \"failed\"", http.StatusBadRequest)
+		return
+	}
+	if filter.MaxAmount > 0 && filter.MaxAmount < filter.MinAmount {
+		h.sendError(w, "maxAmount must be greater than or equal to minAmount", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.simulationService.GetFilteredReport(reportID, filter)
+	if err != nil {
+		h.sendError(w, "Simulation not found", http.StatusNotFound)
+		return
+	}
+
+	// Render in memory so a failure can still be reported as an error response
+	var pdf bytes.Buffer
+	if err := h.reportGenerator.RenderFilteredPDF(&pdf, report); err != nil {
+		h.sendError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=simulation-%s-filtered.pdf", report.SimulationID))
+	w.Header().Set("Content-Length", strconv.Itoa(pdf.Len()))
+	pdf.WriteTo(w)
+}
+
+// RegenerateReport renders the PDF of a finished simulation again from its stored report,
+// replacing any existing PDF, and returns the file name
+func (h *Handler) RegenerateReport(w http.ResponseWriter, r *http.Request) {
+	reportID := mux.Vars(r)["id"]
+
+	report, err := h.simulationService.GetReport(reportID)
+	if err != nil {
+		h.sendError(w, "Simulation not found", http.StatusNotFound)
+		return
+	}
+	if !report.IsFinished {
+		h.sendError(w, "Simulation is still running", http.StatusConflict)
+		return
+	}
+
+	filename, err := h.reportGenerator.GeneratePDF(report)
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{
+		"simulationId": report.SimulationID,
+		"filename":     filename,
+	})
+}
+
+// ViewReport renders a finished simulation's report as a self-contained HTML page for
+// viewing in the browser
+func (h *Handler) ViewReport(w http.ResponseWriter, r *http.Request) {
+	reportID := mux.Vars(r)["id"]
+
+	report, err := h.simulationService.GetReport(reportID)
+	if err != nil {
+		h.sendError(w, "Simulation not found", http.StatusNotFound)
+		return
+	}
+	if !report.IsFinished {
+		h.sendError(w, "Simulation is still running", http.StatusConflict)
+		return
+	}
+
+	// Rendered in memory first, so a failure can still be reported as an error
+	var page bytes.Buffer
+	if err := h.reportGenerator.RenderHTML(&page, report); err != nil {
+		h.sendError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	page.WriteTo(w)
+}
+
+// ListReports returns the generated reports, newest first by default. ?sort=created|size
+// and ?order=desc|asc choose the ordering, ?limit= and ?offset= select a page, and the
+// X-Total-Count header carries the number of reports before paging.
 func (h *Handler) ListReports(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	sortBy := query.Get("sort")
+	if sortBy == "" {
+		sortBy = "created"
+	}
+	if sortBy != "created" && sortBy != "size" {
+		h.sendError(w, "sort must be created or size", http.StatusBadRequest)
+		return
+	}
+	order := query.Get("order")
+	if order == "" {
+		order = "desc"
+	}
+	if order != "desc" && order != "asc" {
+		h.sendError(w, "order must be desc or asc", http.StatusBadRequest)
+		return
+	}
+
+	offset := 0
+	if value := query.Get("offset"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			h.sendError(w, "offset must be a non-negative integer", http.StatusBadRequest)
+			return
+		}
+		offset = parsed
+	}
+	limit := -1 // All reports
+	if value := query.Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			h.sendError(w, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
 	reports, err := h.reportGenerator.ListReports()
 	if err != nil {
 		h.sendError(w, "Failed to list reports", http.StatusInternalServerError)
 		return
 	}
+
+	sort.SliceStable(reports, func(i, j int) bool {
+		a, b := reports[i], reports[j]
+		if order == "asc" {
+			a, b = b, a
+		}
+		if sortBy == "size" {
+			return a.Size > b.Size
+		}
+		return a.CreatedAt.After(b.CreatedAt)
+	})
+
+	total := len(reports)
+	if offset > total {
+		offset = total
+	}
+	page := reports[offset:]
+	if limit >= 0 && limit < len(page) {
+		page = page[:limit]
+	}
+	if page == nil {
+		page = []models.ReportInfo{}
+	}
 	
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(reports)
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	json.NewEncoder(w).Encode(page)
 }
 
+// CheckTokenBalances returns every node's balance right away and refills low nodes in
+// the background; poll it again to see the refilled balances
 func (h *Handler) CheckTokenBalances(w http.ResponseWriter, r *http.Request) {
-	h.nodeManager.CheckTokenBalances()
+	nodes, refillStarted, refillSkipped := h.nodeManager.CheckTokenBalances()
+
+	balances := make(map[string]float64, len(nodes))
+	lowNodes := 0
+	for _, node := range nodes {
+		balances[node.NodeID] = node.Balance
+		if node.BelowThreshold {
+			lowNodes++
+		}
+	}
 	
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
+	message := fmt.Sprintf("Token balance check complete for %d node(s), %d below threshold", len(nodes), lowNodes)
+	if lowNodes > 0 && !refillStarted {
+		message += "; no refill started: " + refillSkipped
+	}
+	response := map[string]interface{}{
 		"success": true,
-		"message": "Token balance check initiated. Check server logs for details.",
+		"message": message,
+		"balances": balances,
+		"nodes": nodes,
+		"refillStarted": refillStarted,
+		"minTokenBalance": h.nodeManager.MinTokenBalance(),
 		"timestamp": time.Now(),
-	})
+	}
+	if !refillStarted {
+		response["refillSkipped"] = refillSkipped
+	}
+	
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
 }
 
 func (h *Handler) GetTokenMonitoringStatus(w http.ResponseWriter, r *http.Request) {
 	isSimActive := h.nodeManager.IsSimulationActive()
+	generatedTokens, maxGeneratedTokens := h.nodeManager.GeneratedTokenStats()
 	
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"simulation_active": isSimActive,
 		"token_monitoring_paused": isSimActive,
+		"tokens_generated": generatedTokens,
+		"max_total_generated_tokens": maxGeneratedTokens,
 		"message": func() string {
 			if isSimActive {
 				return "Token monitoring is paused - simulation is running"
@@ -214,12 +768,201 @@ func (h *Handler) GetTokenMonitoringStatus(w http.ResponseWriter, r *http.Reques
 	})
 }
 
+// GetStartupStatus reports the phase and per-node completion of the current or last fleet
+// start, so clients can show progress while POST /nodes/start runs
+func (h *Handler) GetStartupStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.nodeManager.GetStartupProgress())
+}
+
+// GetDiskUsage reports how much space the node data and generated reports take up,
+// and how much is left on the data directory's filesystem
+func (h *Handler) GetDiskUsage(w http.ResponseWriter, r *http.Request) {
+	dataUsage, err := h.nodeManager.DiskUsage()
+	if err != nil {
+		h.sendError(w, fmt.Sprintf("Failed to measure data directory: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	reportsDir, reportsBytes, err := h.reportGenerator.ReportsDiskUsage()
+	if err != nil {
+		h.sendError(w, fmt.Sprintf("Failed to measure reports directory: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"dataDir":      dataUsage.DataDir,
+		"dataBytes":    dataUsage.DataBytes,
+		"nodeBytes":    dataUsage.NodeBytes,
+		"reportsDir":   reportsDir,
+		"reportsBytes": reportsBytes,
+		"freeBytes":    dataUsage.FreeBytes,
+		"timestamp":    time.Now(),
+	})
+}
+
+// StreamSimulation pushes a simulation's progress as Server-Sent Events: a JSON frame
+// immediately and after every update, until the simulation finishes or the client leaves
+func (h *Handler) StreamSimulation(w http.ResponseWriter, r *http.Request) {
+	simulationID := mux.Vars(r)["id"]
+
+	frames, unsubscribe, err := h.simulationService.SubscribeProgress(simulationID)
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	defer unsubscribe()
+
+	// The server's write timeout would cut a long-running stream short
+	rc := http.NewResponseController(w)
+	rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	for {
+		select {
+		case frame, ok := <-frames:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(frame)
+			if err != nil {
+				return
+			}
+			fmt.Fprintf(w, "event: progress\ndata: %s\n\n", data)
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// CancelSimulation stops a running simulation after its current round
+func (h *Handler) CancelSimulation(w http.ResponseWriter, r *http.Request) {
+	simulationID := mux.Vars(r)["id"]
+
+	if err := h.simulationService.CancelSimulation(simulationID); err != nil {
+		switch {
+		case errors.Is(err, services.ErrSimulationNotFound):
+			h.sendError(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, services.ErrSimulationFinished):
+			h.sendError(w, err.Error(), http.StatusConflict)
+		default:
+			h.sendError(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":      true,
+		"message":      "Simulation cancellation requested; it stops after the current round",
+		"simulationId": simulationID,
+	})
+}
+
+// CompareSimulations returns the change in key metrics between the finished simulations
+// ?a= and ?b=
+func (h *Handler) CompareSimulations(w http.ResponseWriter, r *http.Request) {
+	a, b := r.URL.Query().Get("a"), r.URL.Query().Get("b")
+	if a == "" || b == "" {
+		h.sendError(w, "Both a and b simulation IDs are required", http.StatusBadRequest)
+		return
+	}
+
+	comparison, err := h.simulationService.CompareSimulations(a, b)
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(comparison)
+}
+
+// PauseSimulation holds a running simulation before its next round
+func (h *Handler) PauseSimulation(w http.ResponseWriter, r *http.Request) {
+	simulationID := mux.Vars(r)["id"]
+
+	if err := h.simulationService.PauseSimulation(simulationID); err != nil {
+		h.sendPauseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":      true,
+		"message":      "Simulation paused; it holds after the current round until resumed",
+		"simulationId": simulationID,
+	})
+}
+
+// ResumeSimulation continues a paused simulation
+func (h *Handler) ResumeSimulation(w http.ResponseWriter, r *http.Request) {
+	simulationID := mux.Vars(r)["id"]
+
+	if err := h.simulationService.ResumeSimulation(simulationID); err != nil {
+		h.sendPauseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":      true,
+		"message":      "Simulation resumed",
+		"simulationId": simulationID,
+	})
+}
+
+// sendPauseError maps pause/resume failures to HTTP status codes
+func (h *Handler) sendPauseError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrSimulationNotFound):
+		h.sendError(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, services.ErrSimulationFinished),
+		errors.Is(err, services.ErrSimulationPaused),
+		errors.Is(err, services.ErrSimulationNotPaused),
+		errors.Is(err, services.ErrSimulationNotPausable):
+		h.sendError(w, err.Error(), http.StatusConflict)
+	default:
+		h.sendError(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// GetActiveSimulations returns every unfinished simulation, plus a compact progress entry
+// per simulation so a UI can poll this one endpoint instead of each report
 func (h *Handler) GetActiveSimulations(w http.ResponseWriter, r *http.Request) {
 	activeSimulations := h.simulationService.GetActiveSimulations()
+
+	progress := make([]map[string]interface{}, 0, len(activeSimulations))
+	for _, report := range activeSimulations {
+		percent := 0.0
+		if report.TotalTransactions > 0 {
+			percent = float64(report.TransactionsCompleted) / float64(report.TotalTransactions) * 100
+		}
+		progress = append(progress, map[string]interface{}{
+			"simulationId":          report.SimulationID,
+			"transactionsCompleted": report.TransactionsCompleted,
+			"totalTransactions":     report.TotalTransactions,
+			"successCount":          report.SuccessCount,
+			"failureCount":          report.FailureCount,
+			"percentComplete":       percent,
+			"lastHeartbeat":         report.LastHeartbeat,
+			"isPaused":              report.IsPaused,
+			"pausedAt":              report.PausedAt,
+		})
+	}
 	
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"active_simulations": activeSimulations,
+		"progress":           progress,
 		"count":              len(activeSimulations),
 		"timestamp":          time.Now(),
 	})
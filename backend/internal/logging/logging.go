@@ -0,0 +1,58 @@
+// Package logging adds levels on top of the standard logger, so verbose output can be
+// quieted with LOG_LEVEL without changing the log format. Levels use log/slog's names:
+// debug, info, warn and error.
+package logging
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+	"strings"
+)
+
+var level = new(slog.LevelVar) // Defaults to info
+
+// ParseLevel converts a level name such as "debug" or "WARN" to a slog.Level
+func ParseLevel(name string) (slog.Level, error) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(strings.TrimSpace(name))); err != nil {
+		return slog.LevelInfo, fmt.Errorf("unknown log level %q (use debug, info, warn or error)", name)
+	}
+	return l, nil
+}
+
+// SetLevel sets the minimum level that is written
+func SetLevel(l slog.Level) {
+	level.Set(l)
+}
+
+// Enabled reports whether messages at l are written
+func Enabled(l slog.Level) bool {
+	return l >= level.Level()
+}
+
+// Debugf logs detail that is only useful when diagnosing a problem
+func Debugf(format string, args ...interface{}) {
+	logf(slog.LevelDebug, format, args...)
+}
+
+// Infof logs normal progress such as phase summaries
+func Infof(format string, args ...interface{}) {
+	logf(slog.LevelInfo, format, args...)
+}
+
+// Warnf logs a problem the simulator recovered from
+func Warnf(format string, args ...interface{}) {
+	logf(slog.LevelWarn, format, args...)
+}
+
+// Errorf logs a failed operation
+func Errorf(format string, args ...interface{}) {
+	logf(slog.LevelError, format, args...)
+}
+
+func logf(l slog.Level, format string, args ...interface{}) {
+	if Enabled(l) {
+		log.Output(3, fmt.Sprintf(format, args...))
+	}
+}
@@ -0,0 +1,69 @@
+// Package metrics keeps the simulator-wide counters and gauges exported at GET /metrics
+// in the Prometheus text exposition format. It has no dependencies so that any package
+// can record into it.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sync/atomic"
+)
+
+var (
+	transactionsTotal   atomic.Uint64
+	transactionsSuccess atomic.Uint64
+	transactionsFailed  atomic.Uint64
+
+	activeNodes        atomic.Int64
+	runningSimulations atomic.Int64
+	lastRunLatency     atomic.Uint64 // math.Float64bits of the average latency in seconds
+)
+
+// RecordTransaction counts an executed transaction and its outcome
+func RecordTransaction(success bool) {
+	transactionsTotal.Add(1)
+	if success {
+		transactionsSuccess.Add(1)
+	} else {
+		transactionsFailed.Add(1)
+	}
+}
+
+// SetActiveNodes sets the number of running nodes
+func SetActiveNodes(count int) {
+	activeNodes.Store(int64(count))
+}
+
+// SetRunningSimulations sets the number of simulations in progress
+func SetRunningSimulations(count int) {
+	runningSimulations.Store(int64(count))
+}
+
+// SetLastRunAverageLatency sets the average transaction latency of the last finished run,
+// in milliseconds as the reports use
+func SetLastRunAverageLatency(ms float64) {
+	lastRunLatency.Store(math.Float64bits(ms / 1000))
+}
+
+// WriteText writes all metrics in the Prometheus text format
+func WriteText(w io.Writer) error {
+	metrics := []struct {
+		name, kind, help string
+		value            float64
+	}{
+		{"rubix_simulator_transactions_total", "counter", "Transactions executed against the nodes.", float64(transactionsTotal.Load())},
+		{"rubix_simulator_transactions_success_total", "counter", "Transactions that succeeded.", float64(transactionsSuccess.Load())},
+		{"rubix_simulator_transactions_failed_total", "counter", "Transactions that failed.", float64(transactionsFailed.Load())},
+		{"rubix_simulator_active_nodes", "gauge", "Nodes currently running.", float64(activeNodes.Load())},
+		{"rubix_simulator_running_simulations", "gauge", "Simulations currently in progress.", float64(runningSimulations.Load())},
+		{"rubix_simulator_last_run_average_latency_seconds", "gauge", "Average transaction latency of the last finished simulation.", math.Float64frombits(lastRunLatency.Load())},
+	}
+
+	for _, m := range metrics {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %g\n", m.name, m.help, m.name, m.kind, m.name, m.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,9 +1,10 @@
 package middleware
 
 import (
-	"log"
 	"net/http"
 	"time"
+
+	"github.com/rubix-simulator/backend/internal/logging"
 )
 
 type responseWriter struct {
@@ -16,6 +17,12 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap exposes the underlying writer so http.ResponseController can flush and
+// adjust deadlines through the wrapper (needed for event streams)
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 func LoggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
@@ -27,7 +34,7 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 		
 		next.ServeHTTP(wrapped, r)
 		
-		log.Printf(
+		logging.Infof(
 			"[%s] %s %s %d %v",
 			r.Method,
 			r.RemoteAddr,
@@ -13,6 +13,15 @@ type Node struct {
 	IsQuorum bool      `json:"isQuorum"`
 	Status   string    `json:"status"`
 	Started  time.Time `json:"started"`
+	Label    string    `json:"label,omitempty"` // Readable name shown in reports instead of the ID
+}
+
+// DisplayName returns the node's label, or its ID when it has none
+func (n *Node) DisplayName() string {
+	if n.Label != "" {
+		return n.Label
+	}
+	return n.ID
 }
 
 type Transaction struct {
@@ -20,18 +29,39 @@ type Transaction struct {
 	Sender      string        `json:"sender"`
 	Receiver    string        `json:"receiver"`
 	TokenAmount float64       `json:"tokenAmount"`  // Changed to float64 for RBT transfers
+	RequestedAmount float64   `json:"requestedAmount"` // Amount planned before any balance adjustment
 	Comment     string        `json:"comment"`
 	Status      string        `json:"status"`
 	TimeTaken   time.Duration `json:"timeTaken"`
 	Error       string        `json:"error,omitempty"`
 	NodeID      string        `json:"nodeId"`
 	Timestamp   time.Time     `json:"timestamp"`
+	Attempts    int           `json:"attempts,omitempty"` // Transfer attempts made, including retries
+	SubmitTime  time.Duration `json:"submitTime,omitempty"`  // Last attempt: until the node returned the signature request
+	ConfirmTime time.Duration `json:"confirmTime,omitempty"` // Last attempt: signature response and consensus
+	ExplorerURL string        `json:"explorerUrl,omitempty"` // On-chain record of a successful transaction
 }
 
 type SimulationConfig struct {
 	ID           string    `json:"id"`
 	Nodes        int       `json:"nodes"`
 	Transactions int       `json:"transactions"`
+	GeneratePDF  bool      `json:"generatePdf"`
+	MinTokenAmount float64 `json:"minTokenAmount"`
+	MaxTokenAmount float64 `json:"maxTokenAmount"`
+	SenderNodeID   string  `json:"senderNodeId,omitempty"`
+	ReceiverNodeID string  `json:"receiverNodeId,omitempty"`
+	SenderNodeIDs   []string `json:"senderNodeIds,omitempty"`
+	ReceiverNodeIDs []string `json:"receiverNodeIds,omitempty"`
+	DryRun         bool    `json:"dryRun,omitempty"`
+	TransferMode   string  `json:"transferMode,omitempty"`
+	DistributionMode string `json:"distributionMode,omitempty"`
+	WarmupTransactions int  `json:"warmupTransactions,omitempty"`
+	InterRoundDelayMs  int  `json:"interRoundDelayMs"`
+	AutoRefill         bool `json:"autoRefill,omitempty"`
+	ThroughputWindowSeconds int `json:"throughputWindowSeconds,omitempty"`
+	CallbackURL        string `json:"-"` // Kept out of reports, as webhook URLs often embed a token
+	CommentTemplate    string `json:"commentTemplate,omitempty"`
 	StartedAt    time.Time `json:"startedAt"`
 	EndedAt      *time.Time `json:"endedAt,omitempty"`
 }
@@ -45,41 +75,223 @@ type SimulationReport struct {
 	TotalTransactions    int            `json:"totalTransactions"`
 	SuccessCount         int            `json:"successCount"`
 	FailureCount         int            `json:"failureCount"`
-	AverageTransactionTime       float64        `json:"averageTransactionTime"`
-	MinTransactionTime           time.Duration  `json:"minTransactionTime"`
-	MaxTransactionTime           time.Duration  `json:"maxTransactionTime"`
+	AverageTransactionTime float64       `json:"averageTransactionTime"` // Milliseconds
+	MinTransactionTime   time.Duration  `json:"minTransactionTime"`             // Nanoseconds when encoded
+	MaxTransactionTime   time.Duration  `json:"maxTransactionTime"`             // Nanoseconds when encoded
+	AverageSubmitTime    float64        `json:"averageSubmitTime"`              // Milliseconds, successful transactions
+	AverageConfirmTime   float64        `json:"averageConfirmTime"`             // Milliseconds, successful transactions
+	P50TransactionTime   time.Duration  `json:"p50TransactionTime"`             // Percentiles over successful transactions
+	P95TransactionTime   time.Duration  `json:"p95TransactionTime"`
+	P99TransactionTime   time.Duration  `json:"p99TransactionTime"`
 	TotalTokensTransferred float64       `json:"totalTokensTransferred"`
+	TotalTokensRequested float64        `json:"totalTokensRequested"`
+	AdjustedTransactions int            `json:"adjustedTransactions"`
+	RetriedTransactions  int            `json:"retriedTransactions"`
 	TotalTime            time.Duration  `json:"totalTime"`
 	IsFinished           bool           `json:"isFinished"`
+	IsPaused             bool           `json:"isPaused"`
+	PausedAt             *time.Time     `json:"pausedAt,omitempty"` // When the current pause began
+	LastHeartbeat        time.Time      `json:"lastHeartbeat"`
 	Error                string         `json:"error,omitempty"`
+	Warnings             []string       `json:"warnings,omitempty"`
 	NodeBreakdown        []NodeStats    `json:"nodeBreakdown"`
+	FailureBreakdown     []FailureCategoryCount `json:"failureBreakdown,omitempty"`
+	Refills              []RefillEvent  `json:"refills,omitempty"` // Mid-run token top-ups, excluded from transaction times
+	Fairness             *FairnessMetrics `json:"fairness,omitempty"`
+	ThroughputSeries     []ThroughputBucket `json:"throughputSeries,omitempty"`
+	Settlement           *SettlementReconciliation `json:"settlement,omitempty"`
+	AccountBalances      []NodeAccountBalance `json:"accountBalances,omitempty"`
+	Filter               *ReportFilter  `json:"filter,omitempty"`
 	CreatedAt            time.Time      `json:"createdAt"`
 }
 
+// ReportFilter scopes a report to a subset of its transactions; zero values match everything
+type ReportFilter struct {
+	Status    string     `json:"status,omitempty"` // "success" or "failed"
+	MinAmount float64    `json:"minAmount,omitempty"`
+	MaxAmount float64    `json:"maxAmount,omitempty"`
+	NodePairs []NodePair `json:"nodePairs,omitempty"`
+	Node      string     `json:"node,omitempty"` // Node ID that sent or received the transaction
+}
+
+// NodePair identifies a sender/receiver combination by node ID
+type NodePair struct {
+	Sender   string `json:"sender"`
+	Receiver string `json:"receiver"`
+}
+
+// SettlementReconciliation compares what the transfer API reported with the
+// on-chain state observed after the post-simulation settle period
+type SettlementReconciliation struct {
+	SettleDuration  time.Duration      `json:"settleDuration"`
+	ReportedSuccess int                `json:"reportedSuccess"`
+	Confirmed       int                `json:"confirmed"`
+	Unverifiable    int                `json:"unverifiable"`
+	Unconfirmed     []string           `json:"unconfirmed"`
+	NodeBalances    []NodeBalanceCheck `json:"nodeBalances"`
+}
+
+// NodeBalanceCheck holds the expected and observed balance change of a node over a simulation
+type NodeBalanceCheck struct {
+	NodeID         string  `json:"nodeId"`
+	InitialBalance float64 `json:"initialBalance"`
+	FinalBalance   float64 `json:"finalBalance"`
+	ExpectedDelta  float64 `json:"expectedDelta"`
+	ActualDelta    float64 `json:"actualDelta"`
+	Matches        bool    `json:"matches"`
+}
+
+// NodeTokenBalance is a node's RBT balance as seen by a token balance check
+type NodeTokenBalance struct {
+	NodeID         string  `json:"nodeId"`
+	DID            string  `json:"did"`
+	Balance        float64 `json:"balance"`
+	IsQuorum       bool    `json:"isQuorum"`
+	BelowThreshold bool    `json:"belowThreshold"` // Below MinTokenBalance, so due for a refill
+}
+
+// NodeAccountBalance is a transaction node's account breakdown at the end of a simulation.
+// Tokens pledged to quorum or locked in flight are not spendable even though no transfer consumed them.
+type NodeAccountBalance struct {
+	NodeID    string  `json:"nodeId"`
+	DID       string  `json:"did"`
+	Available float64 `json:"available"`
+	Pledged   float64 `json:"pledged"`
+	Locked    float64 `json:"locked"`
+	Pinned    float64 `json:"pinned"`
+}
+
+// FairnessMetrics quantifies how evenly random pairing spread load across the transaction nodes
+type FairnessMetrics struct {
+	Initiated LoadDistribution `json:"initiated"`
+	Received  LoadDistribution `json:"received"`
+}
+
+// ThroughputBucket counts the transactions started within one fixed window of a run
+type ThroughputBucket struct {
+	Start         time.Time `json:"start"`
+	OffsetSeconds float64   `json:"offsetSeconds"` // Window start relative to the first transaction
+	Completed     int       `json:"completed"`
+	Successful    int       `json:"successful"`
+	TPS           float64   `json:"tps"` // Completed transactions per second of window
+}
+
+// LoadDistribution summarises a per-node transaction count across all transaction nodes,
+// including nodes that took no part in the run
+type LoadDistribution struct {
+	Min         int     `json:"min"`
+	Max         int     `json:"max"`
+	MinMaxRatio float64 `json:"minMaxRatio"` // min/max; 1 means perfectly even
+	Gini        float64 `json:"gini"`        // 0 means perfectly even, approaching 1 means concentrated on one node
+}
+
+// RefillEvent records one mid-simulation top-up of a sender's test tokens
+type RefillEvent struct {
+	NodeID    string        `json:"nodeId"`
+	Tokens    int           `json:"tokens"`
+	Balance   float64       `json:"balance"` // Sender balance that triggered the refill
+	Timestamp time.Time     `json:"timestamp"`
+	Duration  time.Duration `json:"duration"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// FailureCategoryCount is the number of failed transactions whose error falls into one category
+type FailureCategoryCount struct {
+	Category string `json:"category"`
+	Count    int    `json:"count"`
+}
+
+// NodeStats summarises the transactions a node initiated and received during a run
 type NodeStats struct {
-	NodeID               string        `json:"nodeId"`
-	TransactionsHandled  int          `json:"transactionsHandled"`
-	SuccessfulTransactions int        `json:"successfulTransactions"`
-	FailedTransactions   int          `json:"failedTransactions"`
-	AverageTransactionTime       time.Duration `json:"averageTransactionTime"`
-	TotalTokensTransferred float64    `json:"totalTokensTransferred"`
+	NodeID                 string        `json:"nodeId"`
+	TransactionsHandled    int           `json:"transactionsHandled"`
+	TransactionsReceived   int           `json:"transactionsReceived"`
+	SuccessfulTransactions int           `json:"successfulTransactions"`
+	FailedTransactions     int           `json:"failedTransactions"`
+	AverageTransactionTime time.Duration `json:"averageTransactionTime"` // Mean time of the transactions the node initiated
+	TotalTokensTransferred float64       `json:"totalTokensTransferred"`
 }
 
 type SimulationRequest struct {
-	Nodes        int `json:"nodes"`
-	Transactions int `json:"transactions"`
+	Nodes         int    `json:"nodes"`
+	Transactions  int    `json:"transactions"`
+	ExternalNodes []Node `json:"externalNodes,omitempty"` // Run against an externally-managed fleet (id, port, did)
+	GeneratePDF   *bool  `json:"generatePdf,omitempty"`   // Render the PDF report when the run finishes (default true)
+	MinTokenAmount float64 `json:"minTokenAmount,omitempty"` // Smallest RBT amount per transaction (default 1)
+	MaxTokenAmount float64 `json:"maxTokenAmount,omitempty"` // Largest RBT amount per transaction (default 10)
+	SenderNodeID   string  `json:"senderNodeId,omitempty"`   // With receiverNodeId, send every transaction over this one pair
+	ReceiverNodeID string  `json:"receiverNodeId,omitempty"`
+	SenderNodeIDs   []string `json:"senderNodeIds,omitempty"`   // With receiverNodeIds, only these nodes send...
+	ReceiverNodeIDs []string `json:"receiverNodeIds,omitempty"` // ...and only these receive; the sets must be disjoint
+	DryRun            bool    `json:"dryRun,omitempty"`            // Synthesize transactions without real nodes
+	DryRunSuccessRate float64 `json:"dryRunSuccessRate,omitempty"` // Fraction of dry-run transactions that succeed (default 0.95)
+	TransferMode      string  `json:"transferMode,omitempty"`      // "paired" (default) or "batch"
+	DistributionMode  string  `json:"distributionMode,omitempty"`  // "random" (default) or "roundrobin"
+	WarmupTransactions int    `json:"warmupTransactions,omitempty"` // Unmeasured transfers run before the measured ones
+	Adjust            bool    `json:"adjust,omitempty"`            // Resize the running fleet when nodes differs from its transaction node count
+	AutoRefill        bool    `json:"autoRefill,omitempty"`        // Generate test tokens for a sender that can't cover its next transfer
+	InterRoundDelayMs *int    `json:"interRoundDelayMs,omitempty"` // Pause between paired rounds (default 500, 0 disables)
+	CallbackURL       string  `json:"callbackUrl,omitempty"`       // POST the final report here when the run finishes
+	CommentTemplate   string  `json:"commentTemplate,omitempty"`   // Transaction comment with {index}, {sender}, {receiver} and {amount} placeholders
+}
+
+// ShouldGeneratePDF reports whether a PDF should be rendered for the run, defaulting to true
+func (r SimulationRequest) ShouldGeneratePDF() bool {
+	return r.GeneratePDF == nil || *r.GeneratePDF
+}
+
+// ProgressFrame is one progress update pushed over a simulation's event stream
+type ProgressFrame struct {
+	SimulationID           string  `json:"simulationId"`
+	TransactionsCompleted  int     `json:"transactionsCompleted"`
+	TotalTransactions      int     `json:"totalTransactions"`
+	SuccessCount           int     `json:"successCount"`
+	FailureCount           int     `json:"failureCount"`
+	AverageTransactionTime float64 `json:"averageTransactionTime"`
+	IsPaused               bool    `json:"isPaused"`
+	IsFinished             bool    `json:"isFinished"`
+	Error                  string  `json:"error,omitempty"`
 }
 
 type SimulationResponse struct {
-	SimulationID string `json:"simulationId"`
-	Message      string `json:"message"`
+	SimulationID string   `json:"simulationId"`
+	Message      string   `json:"message"`
+	Warnings     []string `json:"warnings,omitempty"`
 }
 
 type ReportInfo struct {
-	ID        string    `json:"id"`
-	Filename  string    `json:"filename"`
-	CreatedAt time.Time `json:"createdAt"`
-	Size      int64     `json:"size"`
+	ID           string    `json:"id"`
+	Filename     string    `json:"filename"`
+	CreatedAt    time.Time `json:"createdAt"`
+	Size         int64     `json:"size"`
+	SimulationID string    `json:"simulationId,omitempty"`
+	Name         string    `json:"name,omitempty"`
+	Tags         []string  `json:"tags,omitempty"`
+	Path         string    `json:"path,omitempty"`
+	Nodes        int       `json:"nodes,omitempty"`
+	Transactions int       `json:"transactions,omitempty"`
+	SuccessCount int       `json:"successCount,omitempty"`
+	FailureCount int       `json:"failureCount,omitempty"`
+	SuccessRate  float64   `json:"successRate,omitempty"` // Percentage of completed transactions that succeeded
+}
+
+// MetricDelta compares one metric between two simulations
+type MetricDelta struct {
+	A             float64  `json:"a"`
+	B             float64  `json:"b"`
+	Delta         float64  `json:"delta"`                   // B - A
+	PercentChange *float64 `json:"percentChange,omitempty"` // Delta as a percentage of A; omitted when A is 0
+}
+
+// SimulationComparison holds the difference in key metrics between two finished simulations
+type SimulationComparison struct {
+	A                 string                 `json:"a"` // Simulation IDs
+	B                 string                 `json:"b"`
+	SuccessRate       MetricDelta            `json:"successRate"`       // Percentage of completed transactions that succeeded
+	AverageLatency    MetricDelta            `json:"averageLatency"`    // Milliseconds
+	P95Latency        MetricDelta            `json:"p95Latency"`        // Milliseconds, successful transactions
+	TPS               MetricDelta            `json:"tps"`               // Completed transactions per second of run time
+	FailureCategories map[string]MetricDelta `json:"failureCategories"` // Failed transactions per category
 }
 
 type ErrorResponse struct {
@@ -94,6 +306,20 @@ type HealthResponse struct {
 	Version   string    `json:"version"`
 }
 
+// DetailedHealthResponse reports whether the simulator can run transactions, not just
+// whether the server is up
+type DetailedHealthResponse struct {
+	Status                  string    `json:"status"` // "healthy", or "degraded" with fewer than 2 running transaction nodes
+	Timestamp               time.Time `json:"timestamp"`
+	Version                 string    `json:"version"`
+	UptimeSeconds           float64   `json:"uptimeSeconds"`
+	TotalNodes              int       `json:"totalNodes"`
+	RunningNodes            int       `json:"runningNodes"`
+	FailedNodes             int       `json:"failedNodes"`
+	RunningTransactionNodes int       `json:"runningTransactionNodes"`
+	SimulationActive        bool      `json:"simulationActive"`
+}
+
 type RubixTransferRequest struct {
 	Receiver    string  `json:"receiver"`
 	Sender      string  `json:"sender"`
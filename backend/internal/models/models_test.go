@@ -0,0 +1,40 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestNodeStatsJSON(t *testing.T) {
+	stats := NodeStats{
+		NodeID:                 "node3",
+		TransactionsHandled:    4,
+		SuccessfulTransactions: 3,
+		FailedTransactions:     1,
+		AverageTransactionTime: 1500 * time.Millisecond,
+	}
+
+	data, err := json.Marshal(stats)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Unmarshal into map: %v", err)
+	}
+	if _, ok := fields["averageTransactionTime"]; !ok {
+		t.Errorf("averageTransactionTime missing from %s", data)
+	}
+	if _, ok := fields["averageLatency"]; ok {
+		t.Errorf("stale averageLatency key in %s", data)
+	}
+
+	var decoded NodeStats
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if decoded != stats {
+		t.Errorf("round trip = %+v, want %+v", decoded, stats)
+	}
+}
@@ -2,20 +2,72 @@ package rubix
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"mime/multipart"
+	"net"
 	"net/http"
-	"strings"
+	"net/url"
+	"regexp"
+	"strconv"
+	"sync"
 	"time"
+
+	"github.com/rubix-simulator/backend/config"
+	"github.com/rubix-simulator/backend/internal/logging"
+	"github.com/rubix-simulator/backend/internal/models"
 )
 
+// defaultAPITimeout bounds node API calls other than signature responses
+const defaultAPITimeout = 5 * time.Minute
+
+// defaultSignatureTimeout bounds how long a signature response may wait for consensus
+const defaultSignatureTimeout = 15 * time.Minute
+
+// defaultBatchConcurrency caps how many transfers InitiateBatchTransfer has in flight
+const defaultBatchConcurrency = 4
+
+// defaultTokenVerifyTimeout bounds how long GenerateTestTokens polls for the new balance
+const defaultTokenVerifyTimeout = 50 * time.Second
+
+// tokenVerifyInterval is the delay between balance checks while verifying token generation
+const tokenVerifyInterval = 5 * time.Second
+
+// nodeTransport is shared by every client so connections to a node are pooled across
+// clients. The default transport keeps only 2 idle connections per host, so concurrent
+// transfers against one node kept closing and re-dialing connections.
+var nodeTransport = newNodeTransport()
+
+// maxIdleConnsPerNode is how many idle keep-alive connections are kept open to each node
+const maxIdleConnsPerNode = 32
+
+func newNodeTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = 256
+	transport.MaxIdleConnsPerHost = maxIdleConnsPerNode
+	return transport
+}
+
+// ErrTokenGenNotVerified is returned when a token generation request was accepted but the
+// node's balance did not increase within the verification timeout
+var ErrTokenGenNotVerified = errors.New("token generation not confirmed")
+
+// ErrTokenGenShort is returned when generated tokens showed up in the node's balance but
+// fewer than were requested arrived within the verification timeout
+var ErrTokenGenShort = errors.New("fewer tokens generated than requested")
+
 // Client represents a Rubix node HTTP client
 type Client struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL            string
+	httpClient         *http.Client
+	signatureTimeout   time.Duration
+	tokenVerifyTimeout time.Duration
+	tokenTolerance     float64 // RBT a token generation may fall short and still succeed
+	batchConcurrency   int
+	verbose            bool
 }
 
 // NewClient creates a new Rubix node client
@@ -23,8 +75,84 @@ func NewClient(port int) *Client {
 	return &Client{
 		baseURL: fmt.Sprintf("http://localhost:%d", port),
 		httpClient: &http.Client{
-			Timeout: 5 * time.Minute,
+			Timeout:   defaultAPITimeout,
+			Transport: nodeTransport,
 		},
+		signatureTimeout:   defaultSignatureTimeout,
+		tokenVerifyTimeout: defaultTokenVerifyTimeout,
+		batchConcurrency:   defaultBatchConcurrency,
+		verbose:            true,
+	}
+}
+
+// NewClientWithConfig creates a new Rubix node client using timeouts from the given configuration
+func NewClientWithConfig(port int, cfg *config.RubixConfig) *Client {
+	c := NewClient(port)
+	if cfg != nil && cfg.DefaultAPITimeout > 0 {
+		c.httpClient.Timeout = time.Duration(cfg.DefaultAPITimeout) * time.Second
+	}
+	if cfg != nil && cfg.SignatureTimeout > 0 {
+		c.signatureTimeout = time.Duration(cfg.SignatureTimeout) * time.Second
+	}
+	if cfg != nil && cfg.TokenGenVerifyTimeout > 0 {
+		c.tokenVerifyTimeout = time.Duration(cfg.TokenGenVerifyTimeout) * time.Second
+	}
+	if cfg != nil && cfg.TokenGenTolerance > 0 {
+		c.tokenTolerance = cfg.TokenGenTolerance
+	}
+	if cfg != nil && cfg.BatchTransferConcurrency > 0 {
+		c.batchConcurrency = cfg.BatchTransferConcurrency
+	}
+	return c
+}
+
+// SetVerbose controls whether transfer requests log their payloads, responses and
+// progress. Errors are logged either way.
+func (c *Client) SetVerbose(verbose bool) {
+	c.verbose = verbose
+}
+
+// debugf logs request detail at info level when the client is verbose, and at debug
+// level otherwise
+func (c *Client) debugf(format string, args ...interface{}) {
+	if c.verbose {
+		logging.Infof(format, args...)
+	} else {
+		logging.Debugf(format, args...)
+	}
+}
+
+// get sends a GET request that is abandoned once ctx is done
+func (c *Client) get(ctx context.Context, url string) (*http.Response, error) {
+	return send(ctx, c.httpClient, http.MethodGet, url, "", nil)
+}
+
+// post sends a POST request that is abandoned once ctx is done
+func (c *Client) post(ctx context.Context, url, contentType string, body io.Reader) (*http.Response, error) {
+	return send(ctx, c.httpClient, http.MethodPost, url, contentType, body)
+}
+
+// send sends a request through client that is abandoned once ctx is done
+func send(ctx context.Context, client *http.Client, method, url, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return client.Do(req)
+}
+
+// sleepContext waits for d, returning ctx's error early if ctx is done first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
 	}
 }
 
@@ -53,19 +181,24 @@ type QuorumData struct {
 
 // Start initializes the node core
 func (c *Client) Start() error {
-	resp, err := c.httpClient.Get(c.baseURL + "/api/start")
+	return c.StartContext(context.Background())
+}
+
+// StartContext is Start with a context that cancels its requests
+func (c *Client) StartContext(ctx context.Context) error {
+	resp, err := c.get(ctx, c.baseURL+"/api/start")
 	if err != nil {
-		return fmt.Errorf("failed to start node: %w", err)
+		return fmt.Errorf("failed to start node: %w", unreachable(err))
 	}
 	defer resp.Body.Close()
 
 	var result BasicResponse
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+		return fmt.Errorf("failed to decode response: %w", invalidResponse(err))
 	}
 
 	if !result.Status {
-		return fmt.Errorf("start failed: %s", result.Message)
+		return rejected(fmt.Errorf("start failed: %s", result.Message))
 	}
 
 	return nil
@@ -73,19 +206,24 @@ func (c *Client) Start() error {
 
 // Shutdown stops the node
 func (c *Client) Shutdown() error {
-	resp, err := c.httpClient.Post(c.baseURL+"/api/shutdown", "application/json", nil)
+	return c.ShutdownContext(context.Background())
+}
+
+// ShutdownContext is Shutdown with a context that cancels its requests
+func (c *Client) ShutdownContext(ctx context.Context) error {
+	resp, err := c.post(ctx, c.baseURL+"/api/shutdown", "application/json", nil)
 	if err != nil {
-		return fmt.Errorf("failed to shutdown node: %w", err)
+		return fmt.Errorf("failed to shutdown node: %w", unreachable(err))
 	}
 	defer resp.Body.Close()
 
 	var result BasicResponse
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+		return fmt.Errorf("failed to decode response: %w", invalidResponse(err))
 	}
 
 	if !result.Status {
-		return fmt.Errorf("shutdown failed: %s", result.Message)
+		return rejected(fmt.Errorf("shutdown failed: %s", result.Message))
 	}
 
 	return nil
@@ -93,15 +231,20 @@ func (c *Client) Shutdown() error {
 
 // NodeStatus checks if the node is running
 func (c *Client) NodeStatus() (bool, error) {
-	resp, err := c.httpClient.Get(c.baseURL + "/api/node-status")
+	return c.NodeStatusContext(context.Background())
+}
+
+// NodeStatusContext is NodeStatus with a context that cancels its requests
+func (c *Client) NodeStatusContext(ctx context.Context) (bool, error) {
+	resp, err := c.get(ctx, c.baseURL+"/api/node-status")
 	if err != nil {
-		return false, err
+		return false, unreachable(err)
 	}
 	defer resp.Body.Close()
 
 	var result BasicResponse
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return false, err
+		return false, invalidResponse(err)
 	}
 
 	return result.Status, nil
@@ -109,13 +252,18 @@ func (c *Client) NodeStatus() (bool, error) {
 
 // CreateDID creates a new DID of type 4
 func (c *Client) CreateDID(privKeyPassword string) (string, string, error) {
+	return c.CreateDIDContext(context.Background(), privKeyPassword)
+}
+
+// CreateDIDContext is CreateDID with a context that cancels its requests
+func (c *Client) CreateDIDContext(ctx context.Context, privKeyPassword string) (string, string, error) {
 	// Create multipart form
 	var buf bytes.Buffer
 	writer := multipart.NewWriter(&buf)
 
 	// Add DID config - matching field names from reference function
 	didConfig := map[string]interface{}{
-		"Type":          4,
+		"Type":          liteDIDType,
 		"priv_pwd":      privKeyPassword,
 		"mnemonic_file": "",
 		"childPath":     0,
@@ -135,7 +283,7 @@ func (c *Client) CreateDID(privKeyPassword string) (string, string, error) {
 	}
 
 	// Make request
-	req, err := http.NewRequest("POST", c.baseURL+"/api/createdid", &buf)
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/createdid", &buf)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to create request: %w", err)
 	}
@@ -143,17 +291,17 @@ func (c *Client) CreateDID(privKeyPassword string) (string, string, error) {
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to send request: %w", err)
+		return "", "", fmt.Errorf("failed to send request: %w", unreachable(err))
 	}
 	defer resp.Body.Close()
 
 	var result DIDResponse
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", "", fmt.Errorf("failed to decode response: %w", err)
+		return "", "", fmt.Errorf("failed to decode response: %w", invalidResponse(err))
 	}
 
 	if !result.Status {
-		return "", "", fmt.Errorf("create DID failed: %s", result.Message)
+		return "", "", rejected(fmt.Errorf("create DID failed: %s", result.Message))
 	}
 
 	return result.Result.DID, result.Result.PeerID, nil
@@ -161,7 +309,12 @@ func (c *Client) CreateDID(privKeyPassword string) (string, string, error) {
 
 // RegisterDID registers a DID with signature handling
 func (c *Client) RegisterDID(did string, password string) error {
-	log.Printf("[RegisterDID] Starting DID registration for: %s", did)
+	return c.RegisterDIDContext(context.Background(), did, password)
+}
+
+// RegisterDIDContext is RegisterDID with a context that cancels its requests
+func (c *Client) RegisterDIDContext(ctx context.Context, did string, password string) error {
+	logging.Infof("[RegisterDID] Starting DID registration for: %s", did)
 
 	payload := map[string]string{
 		"did": did,
@@ -172,48 +325,50 @@ func (c *Client) RegisterDID(did string, password string) error {
 		return fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	resp, err := c.httpClient.Post(c.baseURL+"/api/register-did", "application/json", bytes.NewBuffer(data))
+	resp, err := c.post(ctx, c.baseURL+"/api/register-did", "application/json", bytes.NewBuffer(data))
 	if err != nil {
-		return fmt.Errorf("failed to register DID: %w", err)
+		return fmt.Errorf("failed to register DID: %w", unreachable(err))
 	}
 	defer resp.Body.Close()
 
 	// Parse the response to check if signature is needed
 	var sigResp SignatureResponse
 	body, _ := io.ReadAll(resp.Body)
-	log.Printf("[RegisterDID] Response status: %d, body: %s", resp.StatusCode, string(body))
+	logging.Debugf("[RegisterDID] Response status: %d, body: %s", resp.StatusCode, string(body))
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("register DID failed (status %d): %s", resp.StatusCode, string(body))
+		return rejected(fmt.Errorf("register DID failed (status %d): %s", resp.StatusCode, string(body)))
 	}
 
 	// Parse the response to check if password is needed
 	if err := json.Unmarshal(body, &sigResp); err != nil {
-		log.Printf("[RegisterDID] ERROR: Failed to parse response: %v", err)
-		return fmt.Errorf("failed to parse response: %w", err)
+		logging.Errorf("[RegisterDID] ERROR: Failed to parse response: %v", err)
+		return fmt.Errorf("failed to parse response: %w", invalidResponse(err))
 	}
 
 	// If password is needed, send signature response
 	if sigResp.Status && sigResp.Message == "Password needed" {
-		log.Printf("[RegisterDID] Password required, sending signature response...")
+		logging.Infof("[RegisterDID] Password required, sending signature response...")
 
-		result, err := c.SendSignatureResponse(sigResp.Result.ID, sigResp.Result.Mode, password)
+		result, err := c.SendSignatureResponseContext(ctx, sigResp.Result.ID, sigResp.Result.Mode, password)
 		if err != nil {
-			log.Printf("[RegisterDID] ERROR: Failed to send signature response: %v", err)
+			logging.Errorf("[RegisterDID] ERROR: Failed to send signature response: %v", err)
 			// For RegisterDID, we don't need the transaction ID, just success/failure
 			return fmt.Errorf("failed to send signature response: %w", err)
 		}
 
 		if result != nil && result.Success {
-			log.Printf("[RegisterDID] Signature response sent successfully, registration complete")
+			logging.Infof("[RegisterDID] Signature response sent successfully, registration complete")
 		} else {
-			log.Printf("[RegisterDID] Signature response sent, waiting for registration to complete...")
+			logging.Infof("[RegisterDID] Signature response sent, waiting for registration to complete...")
 		}
 	}
 
 	// Wait a bit for the async operation to complete
-	time.Sleep(5 * time.Second)
-	log.Printf("[RegisterDID] DID registration completed for: %s", did)
+	if err := sleepContext(ctx, 5*time.Second); err != nil {
+		return err
+	}
+	logging.Infof("[RegisterDID] DID registration completed for: %s", did)
 
 	return nil
 }
@@ -235,14 +390,34 @@ type TransferResult struct {
 	Success       bool
 	TransactionID string
 	Message       string
+	StartedAt     time.Time
+	SubmitTime    time.Duration // Until the node returned the signature request
 	TimeTaken     time.Duration
 }
 
+// trnxidPattern matches the transaction ID in a transfer's success message, e.g.
+// "Transfer finished successfully in 5m51.7789643s with trnxid 08765414...59b13e3"
+var trnxidPattern = regexp.MustCompile(`(?i)trnxid\s+([a-f0-9]{64})\b`)
+
+// parseTransactionID returns the 64-hex transaction ID in a success message, or "" when
+// there is none. Punctuation, line breaks or text around the ID don't affect it.
+func parseTransactionID(message string) string {
+	if match := trnxidPattern.FindStringSubmatch(message); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
 // SendSignatureResponse sends a signature response with password
 func (c *Client) SendSignatureResponse(id string, mode int, password string) (*TransferResult, error) {
-	log.Printf("[SendSignatureResponse] Starting signature response for request ID: %s", id)
-	log.Printf("[SendSignatureResponse]   Mode: %d (0=Basic, 1=Standard, 2=Wallet, 3=Child, 4=Lite)", mode)
-	log.Printf("[SendSignatureResponse]   Target: %s", c.baseURL)
+	return c.SendSignatureResponseContext(context.Background(), id, mode, password)
+}
+
+// SendSignatureResponseContext is SendSignatureResponse with a context that cancels its requests
+func (c *Client) SendSignatureResponseContext(ctx context.Context, id string, mode int, password string) (*TransferResult, error) {
+	c.debugf("[SendSignatureResponse] Starting signature response for request ID: %s", id)
+	c.debugf("[SendSignatureResponse]   Mode: %d (0=Basic, 1=Standard, 2=Wallet, 3=Child, 4=Lite)", mode)
+	c.debugf("[SendSignatureResponse]   Target: %s", c.baseURL)
 
 	payload := map[string]interface{}{
 		"id":       id,
@@ -255,40 +430,41 @@ func (c *Client) SendSignatureResponse(id string, mode int, password string) (*T
 		return nil, fmt.Errorf("failed to marshal signature response: %w", err)
 	}
 
-	log.Printf("[SendSignatureResponse] Payload: %s", string(data))
+	c.debugf("[SendSignatureResponse] Payload: %s", string(data))
 
-	// Use a 15-minute timeout for signature operations as they may involve consensus
+	// Use a long timeout for signature operations as they may involve consensus
 	signatureClient := &http.Client{
-		Timeout: 15 * time.Minute, // 15 minutes timeout for signature operations
+		Timeout:   c.signatureTimeout,
+		Transport: nodeTransport,
 	}
 
-	log.Printf("[SendSignatureResponse] Sending POST request to %s/api/signature-response (timeout: 15 minutes)...", c.baseURL)
+	c.debugf("[SendSignatureResponse] Sending POST request to %s/api/signature-response (timeout: %v)...", c.baseURL, c.signatureTimeout)
 	startTime := time.Now()
 
-	resp, err := signatureClient.Post(c.baseURL+"/api/signature-response", "application/json", bytes.NewBuffer(data))
+	resp, err := send(ctx, signatureClient, http.MethodPost, c.baseURL+"/api/signature-response", "application/json", bytes.NewBuffer(data))
 	elapsed := time.Since(startTime)
 
 	if err != nil {
-		log.Printf("[SendSignatureResponse] ERROR: Request failed after %v: %v", elapsed, err)
-		return nil, fmt.Errorf("failed to send signature response: %w", err)
+		logging.Errorf("[SendSignatureResponse] ERROR: Request failed after %v: %v", elapsed, err)
+		return nil, fmt.Errorf("failed to send signature response: %w", signatureRequestFailed(err))
 	}
 	defer resp.Body.Close()
 
 	body, _ := io.ReadAll(resp.Body)
-	log.Printf("[SendSignatureResponse] Response received after %v", elapsed)
-	log.Printf("[SendSignatureResponse]   Status: %d", resp.StatusCode)
-	log.Printf("[SendSignatureResponse]   Body: %s", string(body))
+	c.debugf("[SendSignatureResponse] Response received after %v", elapsed)
+	c.debugf("[SendSignatureResponse]   Status: %d", resp.StatusCode)
+	c.debugf("[SendSignatureResponse]   Body: %s", string(body))
 
 	if resp.StatusCode != http.StatusOK {
-		log.Printf("[SendSignatureResponse] ERROR: Non-200 status code")
-		return nil, fmt.Errorf("signature response failed (status %d): %s", resp.StatusCode, string(body))
+		logging.Errorf("[SendSignatureResponse] ERROR: Non-200 status code")
+		return nil, rejectedTransfer(fmt.Errorf("signature response failed (status %d): %s", resp.StatusCode, string(body)), string(body))
 	}
 
 	// Parse response to check transaction status
 	var result BasicResponse
 	if err := json.Unmarshal(body, &result); err != nil {
-		log.Printf("[SendSignatureResponse] ERROR: Failed to parse response: %v", err)
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		logging.Errorf("[SendSignatureResponse] ERROR: Failed to parse response: %v", err)
+		return nil, fmt.Errorf("failed to parse response: %w", invalidResponse(err))
 	}
 
 	// Create transfer result
@@ -299,31 +475,41 @@ func (c *Client) SendSignatureResponse(id string, mode int, password string) (*T
 	}
 
 	if !result.Status {
-		log.Printf("[SendSignatureResponse] ERROR: Transfer failed: %s", result.Message)
-		return transferResult, fmt.Errorf("transfer failed: %s", result.Message)
-	}
-
-	// Parse success message to extract transaction ID
-	// Message format: "Transfer finished successfully in 5m51.7789643s with trnxid 08765414814e03e9ffb71f3cedda61c7246f40cf1a48b2d5f6cdfdfc359b13e3"
-	if strings.Contains(result.Message, "Transfer finished successfully") {
-		if idx := strings.Index(result.Message, "trnxid "); idx != -1 {
-			txID := result.Message[idx+7:] // Skip "trnxid "
-			// Remove any trailing text or whitespace
-			if spaceIdx := strings.Index(txID, " "); spaceIdx != -1 {
-				txID = txID[:spaceIdx]
-			}
-			transferResult.TransactionID = strings.TrimSpace(txID)
-			log.Printf("[SendSignatureResponse] SUCCESS: Transaction completed with ID: %s", transferResult.TransactionID)
-		}
+		logging.Errorf("[SendSignatureResponse] ERROR: Transfer failed: %s", result.Message)
+		return transferResult, transferFailure(result.Message)
 	}
 
-	log.Printf("[SendSignatureResponse] SUCCESS: %s", result.Message)
+	// Parse success message to extract transaction ID; without one the caller falls back
+	// to the request ID
+	if txID := parseTransactionID(result.Message); txID != "" {
+		transferResult.TransactionID = txID
+		c.debugf("[SendSignatureResponse] SUCCESS: Transaction completed with ID: %s", transferResult.TransactionID)
+	}
+
+	c.debugf("[SendSignatureResponse] SUCCESS: %s", result.Message)
 	return transferResult, nil
 }
 
-// GenerateTestTokens generates test RBT tokens with signature handling
+// GenerateTestTokens generates test RBT tokens with signature handling. Generation completes
+// asynchronously, so it returns nil only once the node's balance has grown by the requested
+// amount (less the configured tolerance). If the balance grows by less within the
+// verification timeout it returns ErrTokenGenShort, and ErrTokenGenNotVerified if it does
+// not grow at all.
 func (c *Client) GenerateTestTokens(did string, numberOfTokens int, password string) error {
-	log.Printf("[GenerateTestTokens] Starting token generation for DID: %s, numberOfTokens: %d", did, numberOfTokens)
+	return c.GenerateTestTokensContext(context.Background(), did, numberOfTokens, password)
+}
+
+// GenerateTestTokensContext is GenerateTestTokens with a context that cancels its requests
+func (c *Client) GenerateTestTokensContext(ctx context.Context, did string, numberOfTokens int, password string) error {
+	logging.Infof("[GenerateTestTokens] Starting token generation for DID: %s, numberOfTokens: %d", did, numberOfTokens)
+
+	// Record the starting balance so refills of already-funded DIDs can be verified too.
+	// A new DID may have no account info yet, which counts as a zero balance; any other
+	// failure leaves nothing to verify against.
+	initialBalance, err := c.GetAccountBalanceContext(ctx, did)
+	if err != nil && !errors.Is(err, ErrNoAccountInfo) {
+		return fmt.Errorf("failed to read balance before generating tokens: %w", err)
+	}
 
 	payload := map[string]interface{}{
 		"number_of_tokens": numberOfTokens,
@@ -332,114 +518,140 @@ func (c *Client) GenerateTestTokens(did string, numberOfTokens int, password str
 
 	data, err := json.Marshal(payload)
 	if err != nil {
-		log.Printf("[GenerateTestTokens] ERROR: Failed to marshal request: %v", err)
+		logging.Errorf("[GenerateTestTokens] ERROR: Failed to marshal request: %v", err)
 		return fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	log.Printf("[GenerateTestTokens] Sending request to %s with payload: %s", c.baseURL+"/api/generate-test-token", string(data))
+	logging.Debugf("[GenerateTestTokens] Sending request to %s with payload: %s", c.baseURL+"/api/generate-test-token", string(data))
 
-	resp, err := c.httpClient.Post(c.baseURL+"/api/generate-test-token", "application/json", bytes.NewBuffer(data))
+	resp, err := c.post(ctx, c.baseURL+"/api/generate-test-token", "application/json", bytes.NewBuffer(data))
 	if err != nil {
-		log.Printf("[GenerateTestTokens] ERROR: Failed to make HTTP request: %v", err)
-		return fmt.Errorf("failed to generate tokens: %w", err)
+		logging.Errorf("[GenerateTestTokens] ERROR: Failed to make HTTP request: %v", err)
+		return fmt.Errorf("failed to generate tokens: %w", unreachable(err))
 	}
 	defer resp.Body.Close()
 
 	// Parse the response to check if signature is needed
 	var sigResp SignatureResponse
 	body, _ := io.ReadAll(resp.Body)
-	log.Printf("[GenerateTestTokens] Response status: %d, body: %s", resp.StatusCode, string(body))
+	logging.Debugf("[GenerateTestTokens] Response status: %d, body: %s", resp.StatusCode, string(body))
 
 	if resp.StatusCode != http.StatusOK {
-		log.Printf("[GenerateTestTokens] ERROR: Non-200 status code received")
-		return fmt.Errorf("generate tokens failed (status %d): %s", resp.StatusCode, string(body))
+		logging.Errorf("[GenerateTestTokens] ERROR: Non-200 status code received")
+		return rejected(fmt.Errorf("generate tokens failed (status %d): %s", resp.StatusCode, string(body)))
 	}
 
 	// Parse the response to check if password is needed
 	if err := json.Unmarshal(body, &sigResp); err != nil {
-		log.Printf("[GenerateTestTokens] ERROR: Failed to parse response: %v", err)
-		return fmt.Errorf("failed to parse response: %w", err)
+		logging.Errorf("[GenerateTestTokens] ERROR: Failed to parse response: %v", err)
+		return fmt.Errorf("failed to parse response: %w", invalidResponse(err))
 	}
 
 	// If password is needed, send signature response
 	if sigResp.Status && sigResp.Message == "Password needed" {
-		log.Printf("[GenerateTestTokens] Password required, sending signature response...")
+		logging.Infof("[GenerateTestTokens] Password required, sending signature response...")
 
-		result, err := c.SendSignatureResponse(sigResp.Result.ID, sigResp.Result.Mode, password)
+		result, err := c.SendSignatureResponseContext(ctx, sigResp.Result.ID, sigResp.Result.Mode, password)
 		if err != nil {
-			log.Printf("[GenerateTestTokens] ERROR: Failed to send signature response: %v", err)
+			logging.Errorf("[GenerateTestTokens] ERROR: Failed to send signature response: %v", err)
 			// For token generation, we don't need the transaction ID
 			return fmt.Errorf("failed to send signature response: %w", err)
 		}
 
 		if result != nil && result.Success {
-			log.Printf("[GenerateTestTokens] Token generation completed successfully")
+			logging.Infof("[GenerateTestTokens] Token generation completed successfully")
 		} else {
-			log.Printf("[GenerateTestTokens] Signature response sent, waiting for token generation...")
+			logging.Infof("[GenerateTestTokens] Signature response sent, waiting for token generation...")
 		}
 	}
 
 	// Wait and check balance periodically
-	log.Printf("[GenerateTestTokens] Waiting for async token generation...")
-
-	for i := 0; i < 10; i++ { // Check for up to 50 seconds (10 * 5 seconds)
-		time.Sleep(5 * time.Second)
+	logging.Infof("[GenerateTestTokens] Waiting up to %v for async token generation...", c.tokenVerifyTimeout)
+
+	expectedBalance := initialBalance + float64(numberOfTokens) - c.tokenTolerance
+	lastBalance := initialBalance
+	deadline := time.Now().Add(c.tokenVerifyTimeout)
+	for check := 1; ; check++ {
+		if err := sleepContext(ctx, tokenVerifyInterval); err != nil {
+			return err
+		}
 
-		balance, err := c.GetAccountBalance(did)
+		balance, err := c.GetAccountBalanceContext(ctx, did)
 		if err != nil {
-			log.Printf("[GenerateTestTokens] Check %d: Failed to get balance: %v", i+1, err)
+			logging.Debugf("[GenerateTestTokens] Check %d: Failed to get balance: %v", check, err)
 		} else {
-			log.Printf("[GenerateTestTokens] Check %d: Current balance: %.2f RBT", i+1, balance)
-			if balance > 0 {
-				log.Printf("[GenerateTestTokens] SUCCESS: Tokens generated! Final balance: %.2f RBT", balance)
+			logging.Debugf("[GenerateTestTokens] Check %d: Current balance: %.2f RBT", check, balance)
+			if balance >= expectedBalance {
+				logging.Infof("[GenerateTestTokens] SUCCESS: Tokens generated! Final balance: %.2f RBT", balance)
 				return nil
 			}
+			lastBalance = balance
+		}
+
+		if time.Now().After(deadline) {
+			break
 		}
 	}
 
-	log.Printf("[GenerateTestTokens] WARNING: Token generation may have failed - balance still 0 after 50 seconds")
-	return nil
+	if lastBalance > initialBalance {
+		logging.Errorf("[GenerateTestTokens] ERROR: only %.2f of %d RBT arrived within %v (balance %.2f RBT)",
+			lastBalance-initialBalance, numberOfTokens, c.tokenVerifyTimeout, lastBalance)
+		return fmt.Errorf("%w: received %.2f of %d RBT after %v", ErrTokenGenShort,
+			lastBalance-initialBalance, numberOfTokens, c.tokenVerifyTimeout)
+	}
+	logging.Errorf("[GenerateTestTokens] ERROR: balance did not increase from %.2f RBT within %v", initialBalance, c.tokenVerifyTimeout)
+	return fmt.Errorf("%w: balance still %.2f RBT after %v", ErrTokenGenNotVerified, initialBalance, c.tokenVerifyTimeout)
 }
 
 // AddQuorum adds quorum list to the node
 func (c *Client) AddQuorum(quorumList []QuorumData) error {
-	log.Printf("[AddQuorum] Adding %d quorum members to node at %s", len(quorumList), c.baseURL)
+	return c.AddQuorumContext(context.Background(), quorumList)
+}
+
+// AddQuorumContext is AddQuorum with a context that cancels its requests
+func (c *Client) AddQuorumContext(ctx context.Context, quorumList []QuorumData) error {
+	logging.Infof("[AddQuorum] Adding %d quorum members to node at %s", len(quorumList), c.baseURL)
 
 	data, err := json.Marshal(quorumList)
 	if err != nil {
 		return fmt.Errorf("failed to marshal quorum list: %w", err)
 	}
 
-	log.Printf("[AddQuorum] Sending quorum list: %s", string(data))
+	logging.Debugf("[AddQuorum] Sending quorum list: %s", string(data))
 
-	resp, err := c.httpClient.Post(c.baseURL+"/api/addquorum", "application/json", bytes.NewBuffer(data))
+	resp, err := c.post(ctx, c.baseURL+"/api/addquorum", "application/json", bytes.NewBuffer(data))
 	if err != nil {
-		return fmt.Errorf("failed to add quorum: %w", err)
+		return fmt.Errorf("failed to add quorum: %w", unreachable(err))
 	}
 	defer resp.Body.Close()
 
 	body, _ := io.ReadAll(resp.Body)
-	log.Printf("[AddQuorum] Response: %s", string(body))
+	logging.Debugf("[AddQuorum] Response: %s", string(body))
 
 	var result BasicResponse
 	if err := json.Unmarshal(body, &result); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+		return fmt.Errorf("failed to decode response: %w", invalidResponse(err))
 	}
 
 	if !result.Status {
-		log.Printf("[AddQuorum] ERROR: Failed to add quorum: %s", result.Message)
-		return fmt.Errorf("add quorum failed: %s", result.Message)
+		logging.Errorf("[AddQuorum] ERROR: Failed to add quorum: %s", result.Message)
+		return rejected(fmt.Errorf("add quorum failed: %s", result.Message))
 	}
 
-	log.Printf("[AddQuorum] Successfully added quorum list")
+	logging.Infof("[AddQuorum] Successfully added quorum list")
 	return nil
 }
 
 // GetAllQuorum gets all quorum members
 func (c *Client) GetAllQuorum() ([]QuorumData, error) {
-	resp, err := c.httpClient.Get(c.baseURL + "/api/getallquorum")
+	return c.GetAllQuorumContext(context.Background())
+}
+
+// GetAllQuorumContext is GetAllQuorum with a context that cancels its requests
+func (c *Client) GetAllQuorumContext(ctx context.Context) ([]QuorumData, error) {
+	resp, err := c.get(ctx, c.baseURL+"/api/getallquorum")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get quorum: %w", err)
+		return nil, fmt.Errorf("failed to get quorum: %w", unreachable(err))
 	}
 	defer resp.Body.Close()
 
@@ -450,18 +662,94 @@ func (c *Client) GetAllQuorum() ([]QuorumData, error) {
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		return nil, fmt.Errorf("failed to decode response: %w", invalidResponse(err))
 	}
 
 	if !result.Status {
-		return nil, fmt.Errorf("get quorum failed: %s", result.Message)
+		return nil, rejected(fmt.Errorf("get quorum failed: %s", result.Message))
 	}
 
 	return result.Result, nil
 }
 
+// ListDIDs lists every DID held by the node, including child DIDs
+func (c *Client) ListDIDs() ([]string, error) {
+	return c.ListDIDsContext(context.Background())
+}
+
+// ListDIDsContext is ListDIDs with a context that cancels its requests
+func (c *Client) ListDIDsContext(ctx context.Context) ([]string, error) {
+	entries, err := c.ListDIDEntriesContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		dids = append(dids, entry.DID)
+	}
+	return dids, nil
+}
+
+// liteDIDType is the DID type CreateDID creates; child DIDs have a different type
+const liteDIDType = 4
+
+// DIDEntry is a DID held by a node with its type (4 = lite, 3 = child)
+type DIDEntry struct {
+	DID  string `json:"did"`
+	Type int    `json:"did_type"`
+}
+
+// ListDIDEntries is ListDIDs with each DID's type, so a node's own DID can be told apart
+// from its child DIDs
+func (c *Client) ListDIDEntries() ([]DIDEntry, error) {
+	return c.ListDIDEntriesContext(context.Background())
+}
+
+// ListDIDEntriesContext is ListDIDEntries with a context that cancels its requests
+func (c *Client) ListDIDEntriesContext(ctx context.Context) ([]DIDEntry, error) {
+	resp, err := c.get(ctx, c.baseURL+"/api/getalldid")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DIDs: %w", unreachable(err))
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Status      bool       `json:"status"`
+		Message     string     `json:"message"`
+		AccountInfo []DIDEntry `json:"account_info"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", invalidResponse(err))
+	}
+
+	if !result.Status {
+		return nil, rejected(fmt.Errorf("get all DIDs failed: %s", result.Message))
+	}
+	return result.AccountInfo, nil
+}
+
+// GetAllDIDs lists every DID held by the node, including child DIDs.
+//
+// Deprecated: use ListDIDs.
+func (c *Client) GetAllDIDs() ([]string, error) {
+	return c.ListDIDs()
+}
+
+// GetAllDIDsContext is GetAllDIDs with a context that cancels its requests.
+//
+// Deprecated: use ListDIDsContext.
+func (c *Client) GetAllDIDsContext(ctx context.Context) ([]string, error) {
+	return c.ListDIDsContext(ctx)
+}
+
 // SetupQuorum sets up the node as a quorum member
 func (c *Client) SetupQuorum(did, password, privKeyPassword string) error {
+	return c.SetupQuorumContext(context.Background(), did, password, privKeyPassword)
+}
+
+// SetupQuorumContext is SetupQuorum with a context that cancels its requests
+func (c *Client) SetupQuorumContext(ctx context.Context, did, password, privKeyPassword string) error {
 	payload := map[string]string{
 		"did":           did,
 		"password":      password,
@@ -473,19 +761,19 @@ func (c *Client) SetupQuorum(did, password, privKeyPassword string) error {
 		return fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	resp, err := c.httpClient.Post(c.baseURL+"/api/setup-quorum", "application/json", bytes.NewBuffer(data))
+	resp, err := c.post(ctx, c.baseURL+"/api/setup-quorum", "application/json", bytes.NewBuffer(data))
 	if err != nil {
-		return fmt.Errorf("failed to setup quorum: %w", err)
+		return fmt.Errorf("failed to setup quorum: %w", unreachable(err))
 	}
 	defer resp.Body.Close()
 
 	var result BasicResponse
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+		return fmt.Errorf("failed to decode response: %w", invalidResponse(err))
 	}
 
 	if !result.Status {
-		return fmt.Errorf("setup quorum failed: %s", result.Message)
+		return rejected(fmt.Errorf("setup quorum failed: %s", result.Message))
 	}
 
 	return nil
@@ -493,19 +781,24 @@ func (c *Client) SetupQuorum(did, password, privKeyPassword string) error {
 
 // GetPeerID gets the peer ID of the node
 func (c *Client) GetPeerID() (string, error) {
-	resp, err := c.httpClient.Get(c.baseURL + "/api/get-peer-id")
+	return c.GetPeerIDContext(context.Background())
+}
+
+// GetPeerIDContext is GetPeerID with a context that cancels its requests
+func (c *Client) GetPeerIDContext(ctx context.Context) (string, error) {
+	resp, err := c.get(ctx, c.baseURL+"/api/get-peer-id")
 	if err != nil {
-		return "", fmt.Errorf("failed to get peer ID: %w", err)
+		return "", fmt.Errorf("failed to get peer ID: %w", unreachable(err))
 	}
 	defer resp.Body.Close()
 
 	var result BasicResponse
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", fmt.Errorf("failed to decode response: %w", err)
+		return "", fmt.Errorf("failed to decode response: %w", invalidResponse(err))
 	}
 
 	if !result.Status {
-		return "", fmt.Errorf("get peer ID failed: %s", result.Message)
+		return "", rejected(fmt.Errorf("get peer ID failed: %s", result.Message))
 	}
 
 	return result.Message, nil
@@ -513,31 +806,70 @@ func (c *Client) GetPeerID() (string, error) {
 
 // GetAccountInfo gets account information for a DID (returns raw map for compatibility)
 func (c *Client) GetAccountInfo(did string) (map[string]interface{}, error) {
-	resp, err := c.httpClient.Get(c.baseURL + "/api/get-account-info?did=" + did)
+	return c.GetAccountInfoContext(context.Background(), did)
+}
+
+// GetAccountInfoContext is GetAccountInfo with a context that cancels its requests
+func (c *Client) GetAccountInfoContext(ctx context.Context, did string) (map[string]interface{}, error) {
+	resp, err := c.get(ctx, c.baseURL+"/api/get-account-info?did="+did)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get account info: %w", err)
+		return nil, fmt.Errorf("failed to get account info: %w", unreachable(err))
 	}
 	defer resp.Body.Close()
 
 	var result map[string]interface{}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		return nil, fmt.Errorf("failed to decode response: %w", invalidResponse(err))
 	}
 
 	if status, ok := result["status"].(bool); ok && !status {
 		if msg, ok := result["message"].(string); ok {
-			return nil, fmt.Errorf("get account info failed: %s", msg)
+			return nil, rejected(fmt.Errorf("get account info failed: %s", msg))
 		}
 	}
 
 	return result, nil
 }
 
+// GetDIDAccountInfo gets the available, pledged, locked and pinned RBT of a DID
+func (c *Client) GetDIDAccountInfo(did string) (*models.DIDAccountInfo, error) {
+	return c.GetDIDAccountInfoContext(context.Background(), did)
+}
+
+// GetDIDAccountInfoContext is GetDIDAccountInfo with a context that cancels its requests
+func (c *Client) GetDIDAccountInfoContext(ctx context.Context, did string) (*models.DIDAccountInfo, error) {
+	resp, err := c.get(ctx, c.baseURL+"/api/get-account-info?did="+did)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account info: %w", unreachable(err))
+	}
+	defer resp.Body.Close()
+
+	var accountResp models.AccountInfoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&accountResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", invalidResponse(err))
+	}
+
+	if !accountResp.Status {
+		return nil, rejected(fmt.Errorf("get account info failed: %s", accountResp.Message))
+	}
+
+	if len(accountResp.AccountInfo) > 0 {
+		return &accountResp.AccountInfo[0], nil
+	}
+
+	return nil, fmt.Errorf("no account info found for DID: %s", did)
+}
+
 // GetAccountBalance gets the available RBT balance for a DID
 func (c *Client) GetAccountBalance(did string) (float64, error) {
-	resp, err := c.httpClient.Get(c.baseURL + "/api/get-account-info?did=" + did)
+	return c.GetAccountBalanceContext(context.Background(), did)
+}
+
+// GetAccountBalanceContext is GetAccountBalance with a context that cancels its requests
+func (c *Client) GetAccountBalanceContext(ctx context.Context, did string) (float64, error) {
+	resp, err := c.get(ctx, c.baseURL+"/api/get-account-info?did="+did)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get account info: %w", err)
+		return 0, fmt.Errorf("failed to get account info: %w", unreachable(err))
 	}
 	defer resp.Body.Close()
 
@@ -552,11 +884,11 @@ func (c *Client) GetAccountBalance(did string) (float64, error) {
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&accountResp); err != nil {
-		return 0, fmt.Errorf("failed to decode response: %w", err)
+		return 0, fmt.Errorf("failed to decode response: %w", invalidResponse(err))
 	}
 
 	if !accountResp.Status {
-		return 0, fmt.Errorf("get account info failed: %s", accountResp.Message)
+		return 0, rejected(fmt.Errorf("get account info failed: %s", accountResp.Message))
 	}
 
 	// Return the balance of the first account (should only be one for the given DID)
@@ -564,7 +896,33 @@ func (c *Client) GetAccountBalance(did string) (float64, error) {
 		return accountResp.AccountInfo[0].RBTAmount, nil
 	}
 
-	return 0, fmt.Errorf("no account info found for DID: %s", did)
+	return 0, &clientError{kinds: []error{ErrNoAccountInfo}, err: fmt.Errorf("no account info found for DID: %s", did)}
+}
+
+// GetTransactionByID checks whether the node has a record of the given transaction ID
+func (c *Client) GetTransactionByID(txnID string) (bool, error) {
+	return c.GetTransactionByIDContext(context.Background(), txnID)
+}
+
+// GetTransactionByIDContext is GetTransactionByID with a context that cancels its requests
+func (c *Client) GetTransactionByIDContext(ctx context.Context, txnID string) (bool, error) {
+	resp, err := c.get(ctx, c.baseURL+"/api/get-by-txnId?txnID="+txnID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get transaction: %w", unreachable(err))
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Status     bool                     `json:"status"`
+		Message    string                   `json:"message"`
+		TxnDetails []map[string]interface{} `json:"TxnDetails"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, fmt.Errorf("failed to decode response: %w", invalidResponse(err))
+	}
+
+	return result.Status && len(result.TxnDetails) > 0, nil
 }
 
 // RBTTransferRequest represents the request for RBT transfer
@@ -576,6 +934,15 @@ type RBTTransferRequest struct {
 	Type       int     `json:"type"`
 }
 
+// NFTTransferRequest represents the request to transfer one NFT
+type NFTTransferRequest struct {
+	NFT        string `json:"nft"`
+	Owner      string `json:"owner"`
+	Receiver   string `json:"receiver"`
+	Comment    string `json:"comment"`
+	QuorumType int    `json:"quorum_type"`
+}
+
 // RBTTransferResponse represents the response from RBT transfer
 type RBTTransferResponse struct {
 	Status  bool   `json:"status"`
@@ -587,10 +954,28 @@ type RBTTransferResponse struct {
 
 // InitiateRBTTransfer initiates an RBT transfer with signature handling
 func (c *Client) InitiateRBTTransfer(sender, receiver string, amount float64, comment string, password string) (string, error) {
+	return c.InitiateRBTTransferContext(context.Background(), sender, receiver, amount, comment, password)
+}
+
+// InitiateRBTTransferContext is InitiateRBTTransfer with a context that cancels its requests
+func (c *Client) InitiateRBTTransferContext(ctx context.Context, sender, receiver string, amount float64, comment string, password string) (string, error) {
+	transactionID, _, err := c.InitiateRBTTransferTimedContext(ctx, sender, receiver, amount, comment, password)
+	return transactionID, err
+}
+
+// InitiateRBTTransferTimed is InitiateRBTTransfer that also returns the submission time:
+// how long the initial request took to come back with the signature request. The rest
+// of the call is spent on the signature response and consensus.
+func (c *Client) InitiateRBTTransferTimed(sender, receiver string, amount float64, comment string, password string) (string, time.Duration, error) {
+	return c.InitiateRBTTransferTimedContext(context.Background(), sender, receiver, amount, comment, password)
+}
+
+// InitiateRBTTransferTimedContext is InitiateRBTTransferTimed with a context that cancels its requests
+func (c *Client) InitiateRBTTransferTimedContext(ctx context.Context, sender, receiver string, amount float64, comment string, password string) (string, time.Duration, error) {
 	// Round amount to 3 decimal places as required by Rubix API
 	amount = float64(int(amount*1000)) / 1000.0
 
-	log.Printf("[InitiateRBTTransfer] Starting transfer from %s to %s, amount: %.3f", sender, receiver, amount)
+	c.debugf("[InitiateRBTTransfer] Starting transfer from %s to %s, amount: %.3f", sender, receiver, amount)
 
 	request := RBTTransferRequest{
 		Sender:     sender,
@@ -602,88 +987,186 @@ func (c *Client) InitiateRBTTransfer(sender, receiver string, amount float64, co
 
 	data, err := json.Marshal(request)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return "", 0, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	log.Printf("[InitiateRBTTransfer] Sending request with payload: %s", string(data))
+	c.debugf("[InitiateRBTTransfer] Sending request with payload: %s", string(data))
 
-	resp, err := c.httpClient.Post(c.baseURL+"/api/initiate-rbt-transfer", "application/json", bytes.NewBuffer(data))
+	startTime := time.Now()
+	resp, err := c.post(ctx, c.baseURL+"/api/initiate-rbt-transfer", "application/json", bytes.NewBuffer(data))
 	if err != nil {
-		return "", fmt.Errorf("failed to initiate transfer: %w", err)
+		return "", time.Since(startTime), fmt.Errorf("failed to initiate transfer: %w", unreachable(err))
 	}
 	defer resp.Body.Close()
 
 	body, _ := io.ReadAll(resp.Body)
-	log.Printf("[InitiateRBTTransfer] Response status: %d, body: %s", resp.StatusCode, string(body))
+	submitTime := time.Since(startTime)
+	c.debugf("[InitiateRBTTransfer] Response status: %d, body: %s", resp.StatusCode, string(body))
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("initiate transfer failed (status %d): %s", resp.StatusCode, string(body))
+		return "", submitTime, rejectedTransfer(fmt.Errorf("initiate transfer failed (status %d): %s", resp.StatusCode, string(body)), string(body))
+	}
+
+	transactionID, err := c.completeTransfer(ctx, "InitiateRBTTransfer", body, password)
+	return transactionID, submitTime, err
+}
+
+// InitiateBatchTransfer submits RBT transfers from this node concurrently instead of
+// waiting for each consensus round, with at most batchConcurrency in flight so the node
+// isn't overwhelmed. It returns one result per request, in request order; a failed
+// transfer is reported in its result rather than as an error.
+func (c *Client) InitiateBatchTransfer(requests []RBTTransferRequest, password string) ([]TransferResult, error) {
+	return c.InitiateBatchTransferContext(context.Background(), requests, password)
+}
+
+// InitiateBatchTransferContext is InitiateBatchTransfer with a context that cancels its requests
+func (c *Client) InitiateBatchTransferContext(ctx context.Context, requests []RBTTransferRequest, password string) ([]TransferResult, error) {
+	if len(requests) == 0 {
+		return nil, fmt.Errorf("no transfers to submit")
+	}
+
+	results := make([]TransferResult, len(requests))
+	sem := make(chan struct{}, c.batchConcurrency)
+	var wg sync.WaitGroup
+	for i, request := range requests {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, request RBTTransferRequest) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			startTime := time.Now()
+			transactionID, submitTime, err := c.InitiateRBTTransferTimedContext(ctx, request.Sender, request.Receiver, request.TokenCount, request.Comment, password)
+			results[i] = TransferResult{
+				Success:       err == nil,
+				TransactionID: transactionID,
+				StartedAt:     startTime,
+				SubmitTime:    submitTime,
+				TimeTaken:     time.Since(startTime),
+			}
+			if err != nil {
+				results[i].Message = err.Error()
+			}
+		}(i, request)
 	}
+	wg.Wait()
+
+	return results, nil
+}
 
+// completeTransfer handles a transfer initiation response: when the node asks for a
+// password it sends the signature response and waits for consensus, otherwise it parses
+// the direct result. It returns the transaction ID of a successful transfer.
+func (c *Client) completeTransfer(ctx context.Context, tag string, body []byte, password string) (string, error) {
 	// First try to parse as signature response
 	var sigResp SignatureResponse
 	if err := json.Unmarshal(body, &sigResp); err == nil && sigResp.Status && sigResp.Message == "Password needed" {
-		log.Printf("[InitiateRBTTransfer] Password required for DID mode %d, request ID: %s", sigResp.Result.Mode, sigResp.Result.ID)
-		log.Printf("[InitiateRBTTransfer] Sending signature response with password...")
+		c.debugf("[%s] Password required for DID mode %d, request ID: %s", tag, sigResp.Result.Mode, sigResp.Result.ID)
+		c.debugf("[%s] Sending signature response with password...", tag)
 
 		startTime := time.Now()
-		transferResult, err := c.SendSignatureResponse(sigResp.Result.ID, sigResp.Result.Mode, password)
+		transferResult, err := c.SendSignatureResponseContext(ctx, sigResp.Result.ID, sigResp.Result.Mode, password)
 		if err != nil {
-			log.Printf("[InitiateRBTTransfer] ERROR: Failed to complete transfer after %v: %v", time.Since(startTime), err)
+			logging.Errorf("[%s] ERROR: Failed to complete transfer after %v: %v", tag, time.Since(startTime), err)
 
 			// Check if we have a transfer result even with error (transaction might have failed on chain)
 			if transferResult != nil && !transferResult.Success {
-				log.Printf("[InitiateRBTTransfer] Transfer failed on blockchain: %s", transferResult.Message)
-				return "", fmt.Errorf("transfer failed: %s", transferResult.Message)
+				logging.Warnf("[%s] Transfer failed on blockchain: %s", tag, transferResult.Message)
+				return "", transferFailure(transferResult.Message)
 			}
 
-			return "", fmt.Errorf("failed to complete transfer: %w", err)
+			return "", fmt.Errorf("failed to complete transfer: %w", submittedTransfer(err, sigResp.Result.ID))
 		}
 
-		log.Printf("[InitiateRBTTransfer] Transfer completed in %v", time.Since(startTime))
+		c.debugf("[%s] Transfer completed in %v", tag, time.Since(startTime))
 
 		// Check if transaction was actually successful
 		if transferResult != nil {
 			if !transferResult.Success {
-				log.Printf("[InitiateRBTTransfer] Transfer failed: %s", transferResult.Message)
-				return "", fmt.Errorf("transfer failed: %s", transferResult.Message)
+				logging.Warnf("[%s] Transfer failed: %s", tag, transferResult.Message)
+				return "", transferFailure(transferResult.Message)
 			}
 
 			if transferResult.TransactionID != "" {
-				log.Printf("[InitiateRBTTransfer] Transfer successful, transaction ID: %s", transferResult.TransactionID)
+				c.debugf("[%s] Transfer successful, transaction ID: %s", tag, transferResult.TransactionID)
 				return transferResult.TransactionID, nil
 			}
 		}
 
 		// Fallback to request ID if no transaction ID found
-		log.Printf("[InitiateRBTTransfer] Warning: No transaction ID in result, using request ID: %s", sigResp.Result.ID)
+		logging.Warnf("[%s] Warning: No transaction ID in result, using request ID: %s", tag, sigResp.Result.ID)
 		return sigResp.Result.ID, nil
 	}
 
 	// If not a signature request, try to parse as transfer response
 	var transferResp RBTTransferResponse
 	if err := json.Unmarshal(body, &transferResp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
+		return "", fmt.Errorf("failed to parse response: %w", invalidResponse(err))
 	}
 
 	if !transferResp.Status {
-		return "", fmt.Errorf("transfer failed: %s", transferResp.Message)
+		return "", transferFailure(transferResp.Message)
 	}
 
-	log.Printf("[InitiateRBTTransfer] Transfer completed successfully")
+	c.debugf("[%s] Transfer completed successfully", tag)
 	return transferResp.Result.TransactionID, nil
 }
 
+// InitiateNFTTransfer transfers the NFT nftID from sender to receiver through the node's
+// NFT execution API (/api/execute-nft) and the same signature flow as InitiateRBTTransfer.
+// RBT transfers only take an amount; the node picks which RBT tokens move.
+func (c *Client) InitiateNFTTransfer(sender, receiver, nftID string, password string) (string, error) {
+	return c.InitiateNFTTransferContext(context.Background(), sender, receiver, nftID, password)
+}
+
+// InitiateNFTTransferContext is InitiateNFTTransfer with a context that cancels its requests
+func (c *Client) InitiateNFTTransferContext(ctx context.Context, sender, receiver, nftID string, password string) (string, error) {
+	c.debugf("[InitiateNFTTransfer] Starting transfer of NFT %s from %s to %s", nftID, sender, receiver)
+
+	request := NFTTransferRequest{
+		NFT:        nftID,
+		Owner:      sender,
+		Receiver:   receiver,
+		Comment:    fmt.Sprintf("Transfer of NFT %s", nftID),
+		QuorumType: 2,
+	}
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	resp, err := c.post(ctx, c.baseURL+"/api/execute-nft", "application/json", bytes.NewBuffer(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to initiate NFT transfer: %w", unreachable(err))
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	c.debugf("[InitiateNFTTransfer] Response status: %d, body: %s", resp.StatusCode, string(body))
+
+	if resp.StatusCode != http.StatusOK {
+		return "", rejectedTransfer(fmt.Errorf("initiate NFT transfer failed (status %d): %s", resp.StatusCode, string(body)), string(body))
+	}
+
+	return c.completeTransfer(ctx, "InitiateNFTTransfer", body, password)
+}
+
 // Ping checks if the node is responsive
 func (c *Client) Ping() error {
-	resp, err := c.httpClient.Get(c.baseURL + "/api/ping")
+	return c.PingContext(context.Background())
+}
+
+// PingContext is Ping with a context that cancels its requests
+func (c *Client) PingContext(ctx context.Context) error {
+	resp, err := c.get(ctx, c.baseURL+"/api/ping")
 	if err != nil {
-		return fmt.Errorf("failed to ping node: %w", err)
+		return fmt.Errorf("failed to ping node: %w", unreachable(err))
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("ping failed with status: %d", resp.StatusCode)
+		return rejected(fmt.Errorf("ping failed with status: %d", resp.StatusCode))
 	}
 
 	return nil
@@ -691,9 +1174,14 @@ func (c *Client) Ping() error {
 
 // GetPeerCount gets the number of connected peers
 func (c *Client) GetPeerCount() (int, error) {
-	resp, err := c.httpClient.Get(c.baseURL + "/api/get-peer-count")
+	return c.GetPeerCountContext(context.Background())
+}
+
+// GetPeerCountContext is GetPeerCount with a context that cancels its requests
+func (c *Client) GetPeerCountContext(ctx context.Context) (int, error) {
+	resp, err := c.get(ctx, c.baseURL+"/api/get-peer-count")
 	if err != nil {
-		return 0, fmt.Errorf("failed to get peer count: %w", err)
+		return 0, fmt.Errorf("failed to get peer count: %w", unreachable(err))
 	}
 	defer resp.Body.Close()
 
@@ -704,11 +1192,11 @@ func (c *Client) GetPeerCount() (int, error) {
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return 0, fmt.Errorf("failed to decode response: %w", err)
+		return 0, fmt.Errorf("failed to decode response: %w", invalidResponse(err))
 	}
 
 	if !result.Status {
-		return 0, fmt.Errorf("get peer count failed: %s", result.Message)
+		return 0, rejected(fmt.Errorf("get peer count failed: %s", result.Message))
 	}
 
 	return result.PeerCount, nil
@@ -716,15 +1204,20 @@ func (c *Client) GetPeerCount() (int, error) {
 
 // CheckQuorumStatus checks if a quorum member is properly set up
 func (c *Client) CheckQuorumStatus(quorumAddress string) (bool, error) {
-	resp, err := c.httpClient.Get(c.baseURL + "/api/check-quorum-status?quorumAddress=" + quorumAddress)
+	return c.CheckQuorumStatusContext(context.Background(), quorumAddress)
+}
+
+// CheckQuorumStatusContext is CheckQuorumStatus with a context that cancels its requests
+func (c *Client) CheckQuorumStatusContext(ctx context.Context, quorumAddress string) (bool, error) {
+	resp, err := c.get(ctx, c.baseURL+"/api/check-quorum-status?quorumAddress="+quorumAddress)
 	if err != nil {
-		return false, fmt.Errorf("failed to check quorum status: %w", err)
+		return false, fmt.Errorf("failed to check quorum status: %w", unreachable(err))
 	}
 	defer resp.Body.Close()
 
 	var result BasicResponse
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return false, fmt.Errorf("failed to decode response: %w", err)
+		return false, fmt.Errorf("failed to decode response: %w", invalidResponse(err))
 	}
 
 	return result.Status, nil
@@ -732,16 +1225,21 @@ func (c *Client) CheckQuorumStatus(quorumAddress string) (bool, error) {
 
 // WaitForNode waits for the node to be ready with exponential backoff
 func (c *Client) WaitForNode(timeout time.Duration) error {
+	return c.WaitForNodeContext(context.Background(), timeout)
+}
+
+// WaitForNodeContext is WaitForNode with a context that ends the wait early
+func (c *Client) WaitForNodeContext(ctx context.Context, timeout time.Duration) error {
 	start := time.Now()
 	attempt := 0
 	maxBackoff := 10 * time.Second
 
 	for {
 		if time.Since(start) > timeout {
-			return fmt.Errorf("timeout waiting for node to be ready after %v", timeout)
+			return unreachable(fmt.Errorf("timeout waiting for node to be ready after %v", timeout))
 		}
 
-		status, err := c.NodeStatus()
+		status, err := c.NodeStatusContext(ctx)
 		if err == nil && status {
 			return nil
 		}
@@ -749,7 +1247,7 @@ func (c *Client) WaitForNode(timeout time.Duration) error {
 		// Log progress every 5 attempts
 		attempt++
 		if attempt%5 == 0 {
-			log.Printf("Still waiting for node at %s (attempt %d, elapsed: %v)",
+			logging.Infof("Still waiting for node at %s (attempt %d, elapsed: %v)",
 				c.baseURL, attempt, time.Since(start))
 		}
 
@@ -759,21 +1257,61 @@ func (c *Client) WaitForNode(timeout time.Duration) error {
 			backoff = maxBackoff
 		}
 
-		time.Sleep(backoff)
+		if err := sleepContext(ctx, backoff); err != nil {
+			return err
+		}
+	}
+}
+
+// WaitForGrpc waits for the node's gRPC port to accept TCP connections. A node can answer
+// its HTTP status API while its gRPC server is down, and then fail transfers.
+func (c *Client) WaitForGrpc(port int, timeout time.Duration) error {
+	return c.WaitForGrpcContext(context.Background(), port, timeout)
+}
+
+// WaitForGrpcContext is WaitForGrpc with a context that ends the wait early
+func (c *Client) WaitForGrpcContext(ctx context.Context, port int, timeout time.Duration) error {
+	host := "localhost"
+	if u, err := url.Parse(c.baseURL); err == nil && u.Hostname() != "" {
+		host = u.Hostname()
+	}
+	address := net.JoinHostPort(host, strconv.Itoa(port))
+
+	dialer := net.Dialer{Timeout: 2 * time.Second}
+	start := time.Now()
+	for {
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err == nil {
+			conn.Close()
+			return nil
+		}
+		if time.Since(start) > timeout {
+			return fmt.Errorf("timeout waiting for gRPC port %s after %v: %w", address, timeout, unreachable(err))
+		}
+		if err := sleepContext(ctx, time.Second); err != nil {
+			return err
+		}
 	}
 }
 
 // WaitForNodeWithRetry waits for node with configurable retry strategy
 func (c *Client) WaitForNodeWithRetry(timeout time.Duration, maxRetries int) error {
+	return c.WaitForNodeWithRetryContext(context.Background(), timeout, maxRetries)
+}
+
+// WaitForNodeWithRetryContext is WaitForNodeWithRetry with a context that ends the wait early
+func (c *Client) WaitForNodeWithRetryContext(ctx context.Context, timeout time.Duration, maxRetries int) error {
 	var lastErr error
 
 	for retry := 0; retry < maxRetries; retry++ {
 		if retry > 0 {
-			log.Printf("Retry %d/%d waiting for node at %s", retry+1, maxRetries, c.baseURL)
-			time.Sleep(time.Duration(retry*2) * time.Second)
+			logging.Infof("Retry %d/%d waiting for node at %s", retry+1, maxRetries, c.baseURL)
+			if err := sleepContext(ctx, time.Duration(retry*2)*time.Second); err != nil {
+				return err
+			}
 		}
 
-		if err := c.WaitForNode(timeout); err != nil {
+		if err := c.WaitForNodeContext(ctx, timeout); err != nil {
 			lastErr = err
 			continue
 		}
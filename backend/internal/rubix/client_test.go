@@ -0,0 +1,225 @@
+package rubix
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const testPassword = "test-password"
+
+func TestInitiateRBTTransferSignatureFlow(t *testing.T) {
+	ts := NewTestServer()
+	defer ts.Close()
+
+	txID, err := ts.Client().InitiateRBTTransfer("sender-did", "receiver-did", 1.23456, "comment", testPassword)
+	if err != nil {
+		t.Fatalf("InitiateRBTTransfer: %v", err)
+	}
+	if txID != TestTransactionID {
+		t.Errorf("transaction ID = %q, want %q", txID, TestTransactionID)
+	}
+
+	initiated := ts.Requests("/api/initiate-rbt-transfer")
+	if len(initiated) != 1 {
+		t.Fatalf("got %d transfer requests, want 1", len(initiated))
+	}
+	var request RBTTransferRequest
+	if err := json.Unmarshal(initiated[0].Body, &request); err != nil {
+		t.Fatalf("transfer request body: %v", err)
+	}
+	if request.Sender != "sender-did" || request.Receiver != "receiver-did" || request.Type != 2 {
+		t.Errorf("transfer request = %+v", request)
+	}
+	if request.TokenCount != 1.234 {
+		t.Errorf("token count = %v, want the amount cut to 3 decimal places (1.234)", request.TokenCount)
+	}
+
+	signed := ts.Requests("/api/signature-response")
+	if len(signed) != 1 {
+		t.Fatalf("got %d signature responses, want 1", len(signed))
+	}
+	var signature struct {
+		ID       string `json:"id"`
+		Mode     int    `json:"mode"`
+		Password string `json:"password"`
+	}
+	if err := json.Unmarshal(signed[0].Body, &signature); err != nil {
+		t.Fatalf("signature response body: %v", err)
+	}
+	if signature.ID != TestRequestID || signature.Mode != 4 || signature.Password != testPassword {
+		t.Errorf("signature response = %+v, want id %q, mode 4 and the given password", signature, TestRequestID)
+	}
+}
+
+func TestInitiateRBTTransferFailures(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		resp   TestResponse
+		kinds  []error
+		txID   string // Expected transaction ID when the transfer succeeds anyway
+		noSign bool   // The signature response must not be sent
+	}{
+		{
+			name:   "initiate rejected",
+			path:   "/api/initiate-rbt-transfer",
+			resp:   TestResponse{StatusCode: http.StatusInternalServerError, Body: "boom"},
+			kinds:  []error{ErrRequestRejected},
+			noSign: true,
+		},
+		{
+			name:   "initiate rejected for balance",
+			path:   "/api/initiate-rbt-transfer",
+			resp:   TestResponse{StatusCode: http.StatusBadRequest, Body: "insufficient balance"},
+			kinds:  []error{ErrRequestRejected, ErrTransferFailed, ErrInsufficientBalance},
+			noSign: true,
+		},
+		{
+			name:   "initiate unparsable",
+			path:   "/api/initiate-rbt-transfer",
+			resp:   TestResponse{Body: "not json"},
+			kinds:  []error{ErrInvalidResponse},
+			noSign: true,
+		},
+		{
+			name:   "initiate failed directly",
+			path:   "/api/initiate-rbt-transfer",
+			resp:   TestResponse{Body: BasicResponse{Status: false, Message: "Insufficient balance"}},
+			kinds:  []error{ErrTransferFailed, ErrInsufficientBalance},
+			noSign: true,
+		},
+		{
+			name:  "signature rejected",
+			path:  "/api/signature-response",
+			resp:  TestResponse{StatusCode: http.StatusBadGateway, Body: "bad gateway"},
+			kinds: []error{ErrRequestRejected},
+		},
+		{
+			name:  "signature rejected for peer",
+			path:  "/api/signature-response",
+			resp:  TestResponse{StatusCode: http.StatusInternalServerError, Body: "peer not found"},
+			kinds: []error{ErrRequestRejected, ErrTransferFailed, ErrPeerNotFound},
+		},
+		{
+			name:  "insufficient balance",
+			path:  "/api/signature-response",
+			resp:  TestResponse{Body: BasicResponse{Status: false, Message: "Insufficient balance to transfer"}},
+			kinds: []error{ErrTransferFailed, ErrInsufficientBalance},
+		},
+		{
+			name:  "consensus timeout",
+			path:  "/api/signature-response",
+			resp:  TestResponse{Body: BasicResponse{Status: false, Message: "Consensus timed out"}},
+			kinds: []error{ErrTransferFailed, ErrConsensusTimeout},
+		},
+		{
+			name:  "consensus failed",
+			path:  "/api/signature-response",
+			resp:  TestResponse{Body: BasicResponse{Status: false, Message: "Quorum did not reach consensus"}},
+			kinds: []error{ErrTransferFailed, ErrConsensusFailed},
+		},
+		{
+			name:  "peer not found",
+			path:  "/api/signature-response",
+			resp:  TestResponse{Body: BasicResponse{Status: false, Message: "failed to get peer"}},
+			kinds: []error{ErrTransferFailed, ErrPeerNotFound},
+		},
+		{
+			name: "no transaction ID",
+			path: "/api/signature-response",
+			resp: TestResponse{Body: BasicResponse{Status: true, Message: "Transfer finished successfully"}},
+			txID: TestRequestID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := NewTestServer()
+			defer ts.Close()
+			ts.SetResponse(tt.path, tt.resp)
+
+			txID, err := ts.Client().InitiateRBTTransfer("sender-did", "receiver-did", 1, "", testPassword)
+			if len(tt.kinds) == 0 {
+				if err != nil {
+					t.Fatalf("InitiateRBTTransfer: %v", err)
+				}
+				if txID != tt.txID {
+					t.Errorf("transaction ID = %q, want %q", txID, tt.txID)
+				}
+			}
+			if len(tt.kinds) > 0 && err == nil {
+				t.Fatalf("InitiateRBTTransfer succeeded with %q, want an error", txID)
+			}
+			for _, kind := range tt.kinds {
+				if !errors.Is(err, kind) {
+					t.Errorf("error %q does not match %q", err, kind)
+				}
+			}
+			if signed := len(ts.Requests("/api/signature-response")); tt.noSign && signed != 0 {
+				t.Errorf("sent %d signature responses, want none", signed)
+			}
+		})
+	}
+}
+
+func TestParseTransactionID(t *testing.T) {
+	id := strings.Repeat("0a1b2c3d", 8)
+
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{"success message", "Transfer finished successfully in 5m51.7s with trnxid " + id, id},
+		{"uppercase keyword", "Transfer finished successfully with TRNXID " + id, id},
+		{"uppercase id", "trnxid " + strings.ToUpper(id), strings.ToUpper(id)},
+		{"trailing punctuation", "done, trnxid " + id + ".", id},
+		{"id on next line", "Transfer finished successfully with trnxid\n" + id + "\nbye", id},
+		{"multi-line message", "Signature accepted\nTransfer finished successfully with trnxid " + id + "\n", id},
+		{"no trnxid", "Transfer finished successfully", ""},
+		{"empty", "", ""},
+		{"short id", "trnxid " + id[:63], ""},
+		{"id too long", "trnxid " + id + "f", ""},
+		{"not hex", "trnxid " + strings.Repeat("z", 64), ""},
+		{"missing separator", "trnxid" + id, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseTransactionID(tt.message); got != tt.want {
+				t.Errorf("parseTransactionID(%q) = %q, want %q", tt.message, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInitiateNFTTransfer(t *testing.T) {
+	ts := NewTestServer()
+	defer ts.Close()
+	ts.SetResponse("/api/execute-nft", TestResponse{Body: passwordNeeded()})
+
+	txID, err := ts.Client().InitiateNFTTransfer("sender-did", "receiver-did", "nft-id", testPassword)
+	if err != nil {
+		t.Fatalf("InitiateNFTTransfer: %v", err)
+	}
+	if txID != TestTransactionID {
+		t.Errorf("transaction ID = %q, want %q", txID, TestTransactionID)
+	}
+
+	executed := ts.Requests("/api/execute-nft")
+	if len(executed) != 1 {
+		t.Fatalf("got %d NFT requests, want 1", len(executed))
+	}
+	var request NFTTransferRequest
+	if err := json.Unmarshal(executed[0].Body, &request); err != nil {
+		t.Fatalf("NFT request body: %v", err)
+	}
+	if request.NFT != "nft-id" || request.Owner != "sender-did" || request.Receiver != "receiver-did" {
+		t.Errorf("NFT request = %+v", request)
+	}
+	if n := len(ts.Requests("/api/signature-response")); n != 1 {
+		t.Errorf("got %d signature responses, want 1", n)
+	}
+}
@@ -0,0 +1,83 @@
+package rubix
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// DiskUsage describes how much space the Rubix data directory takes up
+type DiskUsage struct {
+	DataDir   string           `json:"dataDir"`
+	DataBytes int64            `json:"dataBytes"`
+	NodeBytes map[string]int64 `json:"nodeBytes"` // Size of each node's directory, keyed by node ID
+	FreeBytes uint64           `json:"freeBytes"` // Free space on the filesystem holding the data directory
+}
+
+// DiskUsage walks the data directory and reports its total size, the size of each
+// node's directory and the free space left on its filesystem
+func (m *Manager) DiskUsage() (*DiskUsage, error) {
+	usage := &DiskUsage{
+		DataDir:   m.dataDir,
+		NodeBytes: make(map[string]int64),
+	}
+
+	dataBytes, err := DirSize(m.dataDir)
+	if err != nil {
+		return nil, err
+	}
+	usage.DataBytes = dataBytes
+
+	nodesDir := filepath.Join(m.dataDir, "nodes")
+	entries, err := os.ReadDir(nodesDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		size, err := DirSize(filepath.Join(nodesDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		usage.NodeBytes[entry.Name()] = size
+	}
+
+	free, err := FreeSpace(m.dataDir)
+	if err != nil {
+		return nil, err
+	}
+	usage.FreeBytes = free
+
+	return usage, nil
+}
+
+// DirSize returns the total size in bytes of all files under dir. A missing directory
+// has size zero; entries that vanish while walking (e.g. a node rotating its logs) are skipped.
+func DirSize(dir string) (int64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	var total int64
+	for _, entry := range entries {
+		if entry.IsDir() {
+			size, err := DirSize(filepath.Join(dir, entry.Name()))
+			if err != nil {
+				return 0, err
+			}
+			total += size
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		total += info.Size()
+	}
+	return total, nil
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package rubix
+
+import "syscall"
+
+// FreeSpace returns the bytes available to the current user on the filesystem holding path
+func FreeSpace(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return stat.Bavail * uint64(stat.Bsize), nil
+}
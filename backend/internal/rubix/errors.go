@@ -0,0 +1,141 @@
+package rubix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Kinds of failure a Client method's error can be matched against with errors.Is. The
+// classification does not change the error's message.
+var (
+	// ErrNodeUnreachable means the node's API could not be reached or did not answer
+	ErrNodeUnreachable = errors.New("node unreachable")
+	// ErrRequestRejected means the node answered with an error status or a failed result
+	ErrRequestRejected = errors.New("request rejected by node")
+	// ErrInvalidResponse means the node's answer could not be parsed
+	ErrInvalidResponse = errors.New("invalid response from node")
+	// ErrNoAccountInfo means the node answered but holds no account info for the DID yet
+	ErrNoAccountInfo = errors.New("no account info")
+	// ErrTransferFailed means the node reported a transfer as failed. The errors below
+	// narrow down why; each of them also matches ErrTransferFailed.
+	ErrTransferFailed = errors.New("transfer failed")
+	// ErrInsufficientBalance means the sender cannot cover the transfer
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	// ErrConsensusTimeout means consensus did not finish within the signature timeout, or
+	// the node reported that it timed out
+	ErrConsensusTimeout = errors.New("consensus timed out")
+	// ErrConsensusFailed means the quorum did not reach consensus on the transfer
+	ErrConsensusFailed = errors.New("consensus failed")
+	// ErrPeerNotFound means a node could not locate its counterparty on the network
+	ErrPeerNotFound = errors.New("peer not found")
+)
+
+// clientError tags err with the kinds of failure it matches
+type clientError struct {
+	kinds     []error
+	err       error
+	requestID string // Signature request ID of a transfer the node had already accepted
+}
+
+func (e *clientError) Error() string { return e.err.Error() }
+
+func (e *clientError) Unwrap() error { return e.err }
+
+func (e *clientError) Is(target error) bool {
+	for _, kind := range e.kinds {
+		if target == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// unreachable classifies the error of a request the node did not answer. A request the
+// caller cancelled is left as it is, matching context.Canceled only.
+func unreachable(err error) error {
+	if errors.Is(err, context.Canceled) {
+		return err
+	}
+	return &clientError{kinds: []error{ErrNodeUnreachable}, err: err}
+}
+
+// rejected classifies an error built from a node's failed answer
+func rejected(err error) error {
+	return &clientError{kinds: []error{ErrRequestRejected}, err: err}
+}
+
+// invalidResponse classifies the error of parsing a node's answer
+func invalidResponse(err error) error {
+	return &clientError{kinds: []error{ErrInvalidResponse}, err: err}
+}
+
+// submittedTransfer marks err as raised after the node accepted a transfer and asked for
+// its signature under requestID, so the transfer may still complete on chain
+func submittedTransfer(err error, requestID string) error {
+	return &clientError{err: err, requestID: requestID}
+}
+
+// SubmittedTransferID returns the signature request ID of a transfer that failed after the
+// node had accepted it, or "" when err was raised before the transfer was submitted. Only
+// the latter can safely be sent again.
+func SubmittedTransferID(err error) string {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if ce, ok := err.(*clientError); ok && ce.requestID != "" {
+			return ce.requestID
+		}
+	}
+	return ""
+}
+
+// signatureRequestFailed classifies the error of a signature response request. The
+// request waits for consensus, so running out of time means consensus timed out.
+func signatureRequestFailed(err error) error {
+	if os.IsTimeout(err) {
+		return &clientError{kinds: []error{ErrConsensusTimeout}, err: err}
+	}
+	return unreachable(err)
+}
+
+// rejectedTransfer classifies an error built from a node's failed answer to a transfer
+// request. The answer's body may explain the failure like a failed result's message
+// does, so the reason is read from it as well; the error then also matches
+// ErrTransferFailed and the reason.
+func rejectedTransfer(err error, body string) error {
+	kinds := []error{ErrRequestRejected}
+	if reason := transferFailureReason(body); reason != nil {
+		kinds = append(kinds, ErrTransferFailed, reason)
+	}
+	return &clientError{kinds: kinds, err: err}
+}
+
+// transferFailure returns the error for a transfer the node reported as failed with
+// message. The node only explains failures in the message, so the reason is read from it.
+func transferFailure(message string) error {
+	kinds := []error{ErrTransferFailed}
+	if reason := transferFailureReason(message); reason != nil {
+		kinds = append(kinds, reason)
+	}
+	return &clientError{kinds: kinds, err: fmt.Errorf("transfer failed: %s", message)}
+}
+
+// transferFailureReason returns the error kind narrowing down why a node says a transfer
+// failed, or nil when message doesn't say
+func transferFailureReason(message string) error {
+	msg := strings.ToLower(message)
+	consensus := strings.Contains(msg, "consensus") || strings.Contains(msg, "quorum")
+	switch {
+	case strings.Contains(msg, "insufficient"):
+		return ErrInsufficientBalance
+	case consensus && (strings.Contains(msg, "timeout") || strings.Contains(msg, "timed out")):
+		return ErrConsensusTimeout
+	case consensus:
+		return ErrConsensusFailed
+	case strings.Contains(msg, "peer") && (strings.Contains(msg, "not found") ||
+		strings.Contains(msg, "failed to get") || strings.Contains(msg, "unable to")):
+		return ErrPeerNotFound
+	}
+	return nil
+}
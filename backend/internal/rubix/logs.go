@@ -0,0 +1,112 @@
+package rubix
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// nodeLogFileName is the file in each node's directory that captures its output
+const nodeLogFileName = "node.log"
+
+// ErrNodeLogNotFound is returned when a node has no captured output yet
+var ErrNodeLogNotFound = errors.New("no log captured")
+
+// recoveryLogLines is how much of a crashed node's log RecoverNode reports on failure
+const recoveryLogLines = 20
+
+// logTailChunk is how much of a log file is read at a time when searching backwards for lines
+const logTailChunk = 64 * 1024
+
+// nodeLogPath returns where a node's output is captured
+func (m *Manager) nodeLogPath(nodeID string) string {
+	return filepath.Join(m.dataDir, "nodes", nodeID, nodeLogFileName)
+}
+
+// GetNodeLogs returns up to the last lines lines of a node's captured output
+func (m *Manager) GetNodeLogs(nodeID string, lines int) ([]string, error) {
+	m.mu.RLock()
+	_, exists := m.nodes[nodeID]
+	m.mu.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID)
+	}
+
+	logLines, err := tailLines(m.nodeLogPath(nodeID), lines)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w for node %s yet", ErrNodeLogNotFound, nodeID)
+	}
+	return logLines, err
+}
+
+// rotateNodeLog moves an existing log aside to <path>.1, replacing the previous one
+func rotateNodeLog(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return os.Rename(path, path+".1")
+}
+
+// formatLogTail renders log lines for appending to an error message
+func formatLogTail(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return "; last node output:\n" + strings.Join(lines, "\n")
+}
+
+// tailLines reads the last n lines of a file, reading backwards in chunks so large logs
+// are not loaded whole
+func tailLines(path string, n int) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	// Grow the window from the end until it holds n complete lines or the whole file
+	size := info.Size()
+	offset := size
+	var data []byte
+	for offset > 0 && strings.Count(string(data), "\n") <= n {
+		readSize := int64(logTailChunk)
+		if readSize > offset {
+			readSize = offset
+		}
+		offset -= readSize
+
+		chunk := make([]byte, readSize)
+		if _, err := f.ReadAt(chunk, offset); err != nil && err != io.EOF {
+			return nil, err
+		}
+		data = append(chunk, data...)
+	}
+
+	text := strings.TrimRight(string(data), "\n")
+	if text == "" {
+		return []string{}, nil
+	}
+	lines := strings.Split(text, "\n")
+	if offset > 0 {
+		// The first line may be cut off by the window
+		lines = lines[1:]
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, "\r")
+	}
+	return lines, nil
+}
@@ -5,21 +5,23 @@ import (
 	"archive/zip"
 	"compress/gzip"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
+	"math"
 	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
 
-	// "sort"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/rubix-simulator/backend/config"
+	"github.com/rubix-simulator/backend/internal/logging"
 )
 
 // NodeInfo represents information about a Rubix node
@@ -32,8 +34,35 @@ type NodeInfo struct {
 	IsQuorum   bool      `json:"is_quorum"`
 	Status     string    `json:"status"`
 	Process    *exec.Cmd `json:"-"`
+
+	DIDRegistered bool `json:"did_registered"` // DID registration with the network was confirmed
+
+	Label string `json:"label,omitempty"` // Readable name shown in reports instead of the ID
 }
 
+// ErrNodeNotFound is returned when a node ID is not known to the manager
+var ErrNodeNotFound = errors.New("node not found")
+
+// ErrUptimeUnknown is returned when the manager did not launch a node's current process,
+// so it has no start time for it. Nodes don't report their own uptime.
+var ErrUptimeUnknown = errors.New("node process start time not recorded")
+
+// ErrQuorumNodeRemoval is returned when asked to remove a quorum node, which would break
+// consensus for the rest of the fleet
+var ErrQuorumNodeRemoval = errors.New("is a quorum node and cannot be removed")
+
+// ErrTooFewTransactionNodes is returned when removing nodes would leave fewer than
+// MinTransactionNodes transaction nodes
+var ErrTooFewTransactionNodes = errors.New("too few transaction nodes would remain")
+
+// ErrTooManyTransactionNodes is returned when adding nodes would take the fleet past
+// MaxTransactionNodes transaction nodes
+var ErrTooManyTransactionNodes = errors.New("too many transaction nodes")
+
+// ErrTokenCapReached is returned when generating more test tokens would exceed
+// the fleet-wide MaxTotalGeneratedTokens cap
+var ErrTokenCapReached = errors.New("generated token cap reached")
+
 // Manager manages multiple Rubix nodes
 type Manager struct {
 	nodes             map[string]*NodeInfo
@@ -46,6 +75,14 @@ type Manager struct {
 	tokenMonitorDone  chan struct{}
 	simulationActive  bool              // Flag to track if simulation is running
 	simulationMu      sync.RWMutex      // Separate mutex for simulation state
+	generatedTokens   int               // Test tokens generated across the fleet this session
+	generatedTokensMu sync.Mutex
+	startup           StartupProgress   // Progress of the current or last fleet start
+	startupMu         sync.Mutex        // Separate from mu, which a fleet start holds throughout
+	refillRunning     bool              // A background refill started by RefillInBackground is in flight
+	refillMu          sync.Mutex
+	processStarts     map[string]time.Time // When each node's current process was launched
+	processStartsMu   sync.Mutex
 }
 
 // NewManager creates a new Rubix node manager
@@ -55,6 +92,13 @@ func NewManager() *Manager {
 
 // NewManagerWithConfig creates a new Rubix node manager with custom configuration
 func NewManagerWithConfig(cfg *config.RubixConfig) *Manager {
+	// Resolve the per-instance data directory and ports
+	cfg = cfg.ForInstance()
+	if cfg.InstanceOffset > 0 {
+		logging.Infof("Using instance offset %d: data dir %s, base ports %d/%d",
+			cfg.InstanceOffset, cfg.DataDir, cfg.BaseServerPort, cfg.BaseGrpcPort)
+	}
+
 	// Create a dedicated directory for all Rubix-related data
 	os.MkdirAll(cfg.DataDir, 0o755)
 
@@ -69,8 +113,275 @@ func NewManagerWithConfig(cfg *config.RubixConfig) *Manager {
 	}
 }
 
-// StartNodes starts the specified number of nodes
-func (m *Manager) StartNodes(transactionNodeCount int, fresh bool) error {
+// generateTokens requests test tokens for a DID, enforcing the fleet-wide
+// MaxTotalGeneratedTokens cap. Successful requests count towards the cap.
+func (m *Manager) generateTokens(client *Client, did string, numberOfTokens int) error {
+	m.generatedTokensMu.Lock()
+	if limit := m.config.MaxTotalGeneratedTokens; limit > 0 && m.generatedTokens+numberOfTokens > limit {
+		generated := m.generatedTokens
+		m.generatedTokensMu.Unlock()
+		return fmt.Errorf("%w: %d of %d tokens already generated, %d more requested",
+			ErrTokenCapReached, generated, limit, numberOfTokens)
+	}
+	// Reserve up front so concurrent generation cannot overshoot the cap
+	m.generatedTokens += numberOfTokens
+	m.generatedTokensMu.Unlock()
+
+	err := client.GenerateTestTokens(did, numberOfTokens, m.config.DefaultPrivKeyPassword)
+	// An accepted but unverified or short request may still mint tokens later, so it keeps
+	// counting
+	if err != nil && !errors.Is(err, ErrTokenGenNotVerified) && !errors.Is(err, ErrTokenGenShort) {
+		m.releaseGeneratedTokens(numberOfTokens)
+	}
+	return err
+}
+
+// releaseGeneratedTokens stops counting tokens towards the MaxTotalGeneratedTokens cap
+// that were requested but are not expected to arrive
+func (m *Manager) releaseGeneratedTokens(numberOfTokens int) {
+	m.generatedTokensMu.Lock()
+	m.generatedTokens -= numberOfTokens
+	m.generatedTokensMu.Unlock()
+}
+
+// initialTokens returns the number of test tokens to generate for each new node: override
+// when positive, otherwise the configured InitialTokens
+func (m *Manager) initialTokens(override int) int {
+	if override > 0 {
+		return override
+	}
+	if m.config.InitialTokens > 0 {
+		return m.config.InitialTokens
+	}
+	return config.DefaultInitialTokens
+}
+
+// generateTokensWithRetry generates test tokens for a node, making up to TokenGenRetries
+// attempts. GenerateTestTokens only succeeds once the full amount is confirmed on the
+// node, so a nil result is definitive. When an accepted attempt comes up short or
+// unconfirmed, the balance is read again and the next attempt asks only for the tokens
+// still missing; if the balance can't be read it stops rather than risk generating twice.
+// A start balance that can't be read is returned as an error before anything is
+// generated, unless the node reports no account info for the DID yet. The last attempt's error is returned, which is ErrTokenGenShort if the node ended up
+// with part of the amount.
+func (m *Manager) generateTokensWithRetry(client *Client, nodeID, did string, numberOfTokens int) error {
+	retries := m.config.TokenGenRetries
+	if retries < 1 {
+		retries = 1
+	}
+
+	// A new DID may have no account info yet, which counts as a zero balance. Any other
+	// failure is returned: a funded DID taken as empty would make a short attempt look done.
+	startBalance, err := client.GetAccountBalance(did)
+	if err != nil && !errors.Is(err, ErrNoAccountInfo) {
+		logging.Warnf("  ✗ Not generating tokens for %s: could not read its balance: %v", nodeID, err)
+		return err
+	}
+	targetBalance := startBalance + float64(numberOfTokens) - m.config.TokenGenTolerance
+
+	requested := numberOfTokens
+	counted := 0 // Tokens this call has counted towards the cap
+	for attempt := 1; attempt <= retries; attempt++ {
+		if attempt > 1 {
+			logging.Infof("  Retry %d/%d for %s (%d tokens)...", attempt, retries, nodeID, requested)
+			time.Sleep(time.Duration(attempt) * time.Second) // Progressive backoff
+		}
+
+		err = m.generateTokens(client, did, requested)
+		if err == nil {
+			return nil
+		}
+		if errors.Is(err, ErrTokenCapReached) {
+			logging.Warnf("  ⚠ Skipping token generation for %s: %v", nodeID, err)
+			return err
+		}
+		logging.Warnf("  ✗ Failed to generate tokens for %s (attempt %d/%d): %v", nodeID, attempt, retries, err)
+		if !errors.Is(err, ErrTokenGenShort) && !errors.Is(err, ErrTokenGenNotVerified) {
+			// A request that failed outright was not counted
+			continue
+		}
+		counted += requested
+
+		balance, getErr := client.GetAccountBalance(did)
+		if getErr != nil {
+			logging.Warnf("  ✗ Not retrying token generation for %s: could not read its balance: %v", nodeID, getErr)
+			return err
+		}
+		if balance >= targetBalance {
+			// The rest arrived after the verification timeout
+			return nil
+		}
+		if attempt == retries {
+			// What hasn't arrived may still be minted, so it keeps counting
+			break
+		}
+		missing := int(math.Ceil(startBalance + float64(numberOfTokens) - balance))
+		if missing < requested {
+			requested = missing
+		}
+		// The retry asks again for whatever hasn't arrived, so only the tokens that did
+		// arrive keep counting
+		if release := counted - max(numberOfTokens-missing, 0); release > 0 {
+			m.releaseGeneratedTokens(release)
+			counted -= release
+		}
+	}
+	return err
+}
+
+// GeneratedTokenStats returns the number of test tokens generated this session
+// and the configured cap (0 = unlimited)
+func (m *Manager) GeneratedTokenStats() (generated int, limit int) {
+	m.generatedTokensMu.Lock()
+	defer m.generatedTokensMu.Unlock()
+	return m.generatedTokens, m.config.MaxTotalGeneratedTokens
+}
+
+// ensureDID returns the DID the node already holds, so a restarted node keeps the DID
+// the quorum and other nodes know it by, and only creates one when the node has none.
+// known is the DID recorded for the node, if any, and wins when the node still holds it;
+// otherwise the node's lite DID (the type CreateDID makes) is used, so child DIDs are
+// never picked. reused reports whether an existing DID was returned. A node whose DIDs
+// or peer ID cannot be read, or that holds several lite DIDs, is an error rather than a
+// reason to create yet another DID.
+func (m *Manager) ensureDID(client *Client, nodeID, known string) (did, peerID string, reused bool, err error) {
+	entries, err := client.ListDIDEntries()
+	if err != nil {
+		return "", "", false, fmt.Errorf("could not list DIDs on %s: %w", nodeID, err)
+	}
+
+	var candidates []string
+	for _, entry := range entries {
+		if known != "" && entry.DID == known {
+			candidates = []string{entry.DID}
+			break
+		}
+		if entry.Type == liteDIDType {
+			candidates = append(candidates, entry.DID)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		did, peerID, err = client.CreateDID(m.config.DefaultPrivKeyPassword)
+		return did, peerID, false, err
+	case 1:
+	default:
+		return "", "", false, fmt.Errorf("%s holds %d lite DIDs and none is the recorded one, cannot tell which is the node's", nodeID, len(candidates))
+	}
+
+	peerID, err = client.GetPeerID()
+	if err != nil {
+		return "", "", false, fmt.Errorf("could not get the peer ID of %s: %w", nodeID, err)
+	}
+	if peerID == "" {
+		return "", "", false, fmt.Errorf("%s returned an empty peer ID", nodeID)
+	}
+	return candidates[0], peerID, true, nil
+}
+
+// hasDID checks that the node still holds the DID recorded in metadata. A node whose
+// DID store was wiped comes back up fine but can no longer sign for that DID.
+// If the node cannot list its DIDs the stored DID is assumed to be valid.
+func (m *Manager) hasDID(client *Client, nodeID, did string) bool {
+	dids, err := client.ListDIDs()
+	if err != nil {
+		logging.Warnf("Warning: could not list DIDs on %s, assuming stored DID is valid: %v", nodeID, err)
+		return true
+	}
+	for _, d := range dids {
+		if d == did {
+			return true
+		}
+	}
+	return false
+}
+
+// registerDID registers a node's DID with the network and records the confirmation in
+// its metadata. Already-confirmed DIDs are skipped unless force is set.
+func (m *Manager) registerDID(nodeInfo *NodeInfo, force bool) error {
+	if nodeInfo.DID == "" {
+		return fmt.Errorf("node %s has no DID", nodeInfo.ID)
+	}
+	if nodeInfo.DIDRegistered && !force {
+		return nil
+	}
+
+	client := m.newClient(nodeInfo.ServerPort)
+	if err := client.RegisterDID(nodeInfo.DID, m.config.DefaultPrivKeyPassword); err != nil {
+		return err
+	}
+	nodeInfo.DIDRegistered = true
+	return nil
+}
+
+// RegisterDIDs re-registers the DIDs of all running nodes with the network, e.g. to
+// repair peer discovery. Without force, nodes whose registration is already confirmed
+// are skipped. It returns the number of nodes registered and any failures.
+func (m *Manager) RegisterDIDs(force bool) (int, error) {
+	// Registration makes slow network calls, so it works on a copy of the nodes and
+	// doesn't hold the lock meanwhile
+	m.mu.RLock()
+	var pending []NodeInfo
+	for _, nodeInfo := range m.nodes {
+		if nodeInfo.Status != "running" || nodeInfo.DID == "" {
+			continue
+		}
+		if nodeInfo.DIDRegistered && !force {
+			continue
+		}
+		pending = append(pending, *nodeInfo)
+	}
+	m.mu.RUnlock()
+
+	var done []NodeInfo
+	var failed []string
+	for _, nodeInfo := range pending {
+		if err := m.newClient(nodeInfo.ServerPort).RegisterDID(nodeInfo.DID, m.config.DefaultPrivKeyPassword); err != nil {
+			logging.Warnf("⚠ Warning: Failed to register DID for %s: %v", nodeInfo.ID, err)
+			failed = append(failed, nodeInfo.ID)
+			continue
+		}
+		logging.Infof("✓ Registered DID for %s", nodeInfo.ID)
+		done = append(done, nodeInfo)
+	}
+
+	m.mu.Lock()
+	for _, registered := range done {
+		// The node may have been removed or recreated with a new DID meanwhile
+		if nodeInfo, exists := m.nodes[registered.ID]; exists && nodeInfo.DID == registered.DID {
+			nodeInfo.DIDRegistered = true
+		}
+	}
+	if err := m.saveMetadata(); err != nil {
+		logging.Warnf("⚠ Warning: failed to save metadata: %v", err)
+	}
+	m.mu.Unlock()
+
+	if len(failed) > 0 {
+		return len(done), fmt.Errorf("failed to register DIDs for nodes: %v", failed)
+	}
+	return len(done), nil
+}
+
+// newClient creates a client for a node port using the manager's configuration
+func (m *Manager) newClient(port int) *Client {
+	return NewClientWithConfig(port, m.config)
+}
+
+// ValidateQuorumCount checks a requested quorum size: consensus needs a strict majority,
+// so the count must be odd, and at least 3
+func ValidateQuorumCount(quorumCount int) error {
+	if quorumCount < 3 || quorumCount%2 == 0 {
+		return fmt.Errorf("quorum count must be an odd number of at least 3, got %d", quorumCount)
+	}
+	return nil
+}
+
+// StartNodes starts the specified number of nodes. A quorumCount of 0 keeps the current
+// quorum size (that of the existing fleet, or QuorumNodeCount); any other value sizes the
+// quorum of a fresh fleet and must match the existing one otherwise. initialTokens is the
+// number of test tokens each new node gets; 0 uses InitialTokens.
+func (m *Manager) StartNodes(transactionNodeCount, quorumCount, initialTokens int, fresh bool) (err error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -80,40 +391,71 @@ func (m *Manager) StartNodes(transactionNodeCount int, fresh bool) error {
 	if transactionNodeCount > m.config.MaxTransactionNodes {
 		return fmt.Errorf("maximum %d transaction nodes allowed", m.config.MaxTransactionNodes)
 	}
+	if quorumCount != 0 {
+		if err := ValidateQuorumCount(quorumCount); err != nil {
+			return err
+		}
+	}
 
 	// On subsequent runs, just select the active nodes
 	if !fresh && m.nodeMetadataExists() {
-		log.Println("Found existing node setup. Selecting active nodes...")
-		return m.adjustNodeCount(transactionNodeCount)
+		m.beginStartup("Selecting Nodes")
+		defer func() { m.finishStartup(err) }()
+		logging.Infof("Found existing node setup. Selecting active nodes...")
+		if quorumCount != 0 {
+			// Quorum nodes occupy the first port indices, so resizing means rebuilding the fleet
+			if metadata, err := m.loadMetadata(); err == nil {
+				if existing := countQuorumNodes(metadata); existing > 0 && existing != quorumCount {
+					return fmt.Errorf("the fleet has %d quorum nodes; changing the quorum count to %d requires a fresh start", existing, quorumCount)
+				}
+			}
+		}
+		return m.adjustNodeCount(transactionNodeCount, initialTokens)
+	}
+
+	// The new quorum size only sticks once the fleet has started with it
+	if quorumCount != 0 {
+		previousQuorumCount := m.config.QuorumNodeCount
+		m.config.QuorumNodeCount = quorumCount
+		defer func() {
+			if err != nil {
+				m.config.QuorumNodeCount = previousQuorumCount
+			}
+		}()
 	}
 
+	m.beginStartup(freshStartupPhases...)
+	defer func() { m.finishStartup(err) }()
+
 	// On a fresh run, start all 20 nodes
-	log.Println("Fresh start: starting all 20 transaction nodes...")
+	logging.Infof("Fresh start: starting all 20 transaction nodes...")
 
 transactionNodeCount = m.config.MaxTransactionNodes // Always start max nodes
 
 	// Clean up if fresh start requested
 	if fresh {
-		log.Println("Fresh start requested, cleaning up existing data...")
+		logging.Infof("Fresh start requested, cleaning up existing data...")
 		m.cleanup()
 	}
 
 	// Setup rubixgoplatform - this will handle existing installations gracefully
+	m.startupPhase("Platform Setup", 0)
 	if err := m.setupRubixPlatform(); err != nil {
 		return fmt.Errorf("failed to setup rubix platform: %w", err)
 	}
 
 	totalNodes := m.config.QuorumNodeCount + transactionNodeCount
-	// log.Printf("Starting %d nodes (%d quorum + %d transaction)", totalNodes, m.config.QuorumNodeCount, transactionNodeCount)
+	// logging.Infof("Starting %d nodes (%d quorum + %d transaction)", totalNodes, m.config.QuorumNodeCount, transactionNodeCount)
 
 	// Start all nodes
 	var quorumList []QuorumData
-	log.Printf("================== PHASE 1: Starting Nodes ==================")
-	log.Printf("Total nodes to start: %d (Quorum: %d, Transaction: %d)",
+	logging.Infof("================== PHASE 1: Starting Nodes ==================")
+	m.startupPhase("Starting Nodes", totalNodes)
+	logging.Infof("Total nodes to start: %d (Quorum: %d, Transaction: %d)",
 		totalNodes, m.config.QuorumNodeCount, totalNodes-m.config.QuorumNodeCount)
 
 	for i := 0; i < totalNodes; i++ {
-		nodeID := fmt.Sprintf("node%d", i)
+		nodeID := m.nodeIDFor(i)
 		serverPort := m.config.BaseServerPort + i
 		grpcPort := m.config.BaseGrpcPort + i
 		isQuorum := i < m.config.QuorumNodeCount
@@ -123,7 +465,7 @@ transactionNodeCount = m.config.MaxTransactionNodes // Always start max nodes
 			nodeType = "quorum"
 		}
 
-		log.Printf("[%d/%d] Starting %s (%s node) on port %d", i+1, totalNodes, nodeID, nodeType, serverPort)
+		logging.Infof("[%d/%d] Starting %s (%s node) on port %d", i+1, totalNodes, nodeID, nodeType, serverPort)
 
 		// Start the node process
 		if err := m.startNodeProcess(nodeID, i); err != nil {
@@ -131,32 +473,32 @@ transactionNodeCount = m.config.MaxTransactionNodes // Always start max nodes
 		}
 
 		// Wait for node to be ready
-		client := NewClient(serverPort)
+		client := m.newClient(serverPort)
 		timeout := time.Duration(m.config.NodeStartupTimeout) * time.Second
-		log.Printf("  Waiting for %s to be ready (timeout: %v)...", nodeID, timeout)
-		if err := client.WaitForNode(timeout); err != nil {
+		logging.Infof("  Waiting for %s to be ready (timeout: %v)...", nodeID, timeout)
+		if err := m.waitForNodeReady(client, i, timeout); err != nil {
 			return fmt.Errorf("node %s failed to start: %w", nodeID, err)
 		}
-		log.Printf("  ✓ %s is ready", nodeID)
+		logging.Infof("  ✓ %s is ready", nodeID)
 
 		// Initialize the node
-		// log.Printf("  Initializing %s core...", nodeID)
+		// logging.Infof("  Initializing %s core...", nodeID)
 		// if err := client.Start(); err != nil {
-		// 	log.Printf("  ⚠ Warning: failed to initialize %s: %v", nodeID, err)
+		// 	logging.Warnf("  ⚠ Warning: failed to initialize %s: %v", nodeID, err)
 		// } else {
-		// 	log.Printf("  ✓ %s core initialized", nodeID)
+		// 	logging.Infof("  ✓ %s core initialized", nodeID)
 		// }
 
-		// Create DID
-		log.Printf("  Creating DID for %s with password...", nodeID)
-		did, peerID, err := client.CreateDID(m.config.DefaultPrivKeyPassword)
+		// Create DID, or reuse the one the node already holds
+		logging.Infof("  Creating DID for %s with password...", nodeID)
+		did, peerID, reused, err := m.ensureDID(client, nodeID, "")
 		if err != nil {
 			return fmt.Errorf("failed to create DID for %s: %w", nodeID, err)
 		}
 
 		// Log raw values for debugging
-		log.Printf("  DEBUG: Raw DID value: '%s' (length: %d)", did, len(did))
-		log.Printf("  DEBUG: Raw PeerID value: '%s' (length: %d)", peerID, len(peerID))
+		logging.Debugf("  DEBUG: Raw DID value: '%s' (length: %d)", did, len(did))
+		logging.Debugf("  DEBUG: Raw PeerID value: '%s' (length: %d)", peerID, len(peerID))
 
 		// Safe string slicing to avoid panic
 		didDisplay := did
@@ -168,10 +510,14 @@ transactionNodeCount = m.config.MaxTransactionNodes // Always start max nodes
 			peerIDDisplay = peerID[:8] + "..."
 		}
 
+		action := "created"
+		if reused {
+			action = "reused"
+		}
 		if peerID == "" {
-			log.Printf("  ⚠ DID created for %s: %s (WARNING: PeerID is empty!)", nodeID, didDisplay)
+			logging.Warnf("  ⚠ DID %s for %s: %s (WARNING: PeerID is empty!)", action, nodeID, didDisplay)
 		} else {
-			log.Printf("  ✓ DID created for %s: %s (PeerID: %s)", nodeID, didDisplay, peerIDDisplay)
+			logging.Infof("  ✓ DID %s for %s: %s (PeerID: %s)", action, nodeID, didDisplay, peerIDDisplay)
 		}
 
 		// Store node info (DID registration will happen later after all DIDs are created)
@@ -189,54 +535,57 @@ transactionNodeCount = m.config.MaxTransactionNodes // Always start max nodes
 
 		if isQuorum {
 			// Add to quorum list
-			log.Printf("  DEBUG: Adding %s to quorum list with DID: '%s' (length: %d)", nodeID, nodeInfo.DID, len(nodeInfo.DID))
+			logging.Debugf("  DEBUG: Adding %s to quorum list with DID: '%s' (length: %d)", nodeID, nodeInfo.DID, len(nodeInfo.DID))
 			quorumList = append(quorumList, QuorumData{
 				Type:    2,
 				Address: nodeInfo.DID, // Fixed: use nodeInfo.DID instead of did
 			})
-			log.Printf("  Added %s to quorum list (total quorum members: %d)", nodeID, len(quorumList))
+			logging.Infof("  Added %s to quorum list (total quorum members: %d)", nodeID, len(quorumList))
 		}
+		m.startupNodeDone(nodeID)
 	}
 
 	// Now that all DIDs are created, register them with the network
 	// This allows the pub/sub mechanism to properly distribute node information
-	log.Printf("\n================== PHASE 2: DID Registration ==================")
-	log.Printf("Registering all %d DIDs with the network (pub/sub distribution)...", len(m.nodes))
+	logging.Infof("\n================== PHASE 2: DID Registration ==================")
+	m.startupPhase("DID Registration", len(m.nodes))
+	logging.Infof("Registering all %d DIDs with the network (pub/sub distribution)...", len(m.nodes))
 	registrationSuccess := 0
 	for nodeID, nodeInfo := range m.nodes {
 		nodeType := "transaction"
 		if nodeInfo.IsQuorum {
 			nodeType = "quorum"
 		}
-		log.Printf("  DEBUG: About to register DID for %s: '%s' (length: %d)", nodeID, nodeInfo.DID, len(nodeInfo.DID))
+		logging.Debugf("  DEBUG: About to register DID for %s: '%s' (length: %d)", nodeID, nodeInfo.DID, len(nodeInfo.DID))
 		didDisplay := nodeInfo.DID
 		if len(nodeInfo.DID) > 16 {
 			didDisplay = nodeInfo.DID[:16] + "..."
 		}
-		log.Printf("[%s] Registering %s node DID: %s", nodeID, nodeType, didDisplay)
-		client := NewClient(nodeInfo.ServerPort)
-		if err := client.RegisterDID(nodeInfo.DID, m.config.DefaultPrivKeyPassword); err != nil {
-			log.Printf("  ✗ ERROR: Failed to register DID for %s: %v", nodeID, err)
+		logging.Infof("[%s] Registering %s node DID: %s", nodeID, nodeType, didDisplay)
+		if err := m.registerDID(nodeInfo, true); err != nil {
+			logging.Errorf("  ✗ ERROR: Failed to register DID for %s: %v", nodeID, err)
 		} else {
-			log.Printf("  ✓ Successfully registered DID for %s", nodeID)
+			logging.Infof("  ✓ Successfully registered DID for %s", nodeID)
 			registrationSuccess++
 		}
+		m.startupNodeDone(nodeID)
 	}
-	log.Printf("DID registration phase complete: %d/%d successful", registrationSuccess, len(m.nodes))
+	logging.Infof("DID registration phase complete: %d/%d successful", registrationSuccess, len(m.nodes))
 	if registrationSuccess < len(m.nodes) {
-		log.Printf("⚠ WARNING: Not all DIDs registered successfully!")
+		logging.Warnf("⚠ WARNING: Not all DIDs registered successfully!")
 	}
 
 	// Add quorum list to all nodes
-	log.Printf("\n================== PHASE 3: Quorum Configuration ==================")
-	log.Printf("Building quorum list with %d members:", len(quorumList))
+	logging.Infof("\n================== PHASE 3: Quorum Configuration ==================")
+	m.startupPhase("Quorum Configuration", len(m.nodes))
+	logging.Infof("Building quorum list with %d members:", len(quorumList))
 	for i, q := range quorumList {
-		log.Printf("  DEBUG: Quorum[%d] Address: '%s' (length: %d, Type: %d)", i, q.Address, len(q.Address), q.Type)
+		logging.Debugf("  DEBUG: Quorum[%d] Address: '%s' (length: %d, Type: %d)", i, q.Address, len(q.Address), q.Type)
 		addrDisplay := q.Address
 		if len(q.Address) > 16 {
 			addrDisplay = q.Address[:16] + "..."
 		}
-		log.Printf("  [%d] Quorum DID: %s (Type: %d)", i+1, addrDisplay, q.Type)
+		logging.Infof("  [%d] Quorum DID: %s (Type: %d)", i+1, addrDisplay, q.Type)
 	}
 
 	quorumAddSuccess := 0
@@ -245,124 +594,110 @@ transactionNodeCount = m.config.MaxTransactionNodes // Always start max nodes
 		if nodeInfo.IsQuorum {
 			nodeType = "quorum"
 		}
-		client := NewClient(nodeInfo.ServerPort)
-		log.Printf("[%s] Adding quorum list to %s node...", nodeID, nodeType)
+		client := m.newClient(nodeInfo.ServerPort)
+		logging.Infof("[%s] Adding quorum list to %s node...", nodeID, nodeType)
 		if err := client.AddQuorum(quorumList); err != nil {
-			log.Printf("  ✗ ERROR: Failed to add quorum to %s: %v", nodeID, err)
+			logging.Errorf("  ✗ ERROR: Failed to add quorum to %s: %v", nodeID, err)
 		} else {
-			log.Printf("  ✓ Successfully added quorum list to %s", nodeID)
+			logging.Infof("  ✓ Successfully added quorum list to %s", nodeID)
 			quorumAddSuccess++
 
 			// Verify quorum was added correctly
 			addedQuorum, err := client.GetAllQuorum()
 			if err != nil {
-				log.Printf("  ⚠ WARNING: Could not verify quorum for %s: %v", nodeID, err)
+				logging.Warnf("  ⚠ WARNING: Could not verify quorum for %s: %v", nodeID, err)
 			} else {
-				log.Printf("  ✓ Verified %s has %d quorum members", nodeID, len(addedQuorum))
+				logging.Infof("  ✓ Verified %s has %d quorum members", nodeID, len(addedQuorum))
 			}
 		}
+		m.startupNodeDone(nodeID)
 	}
-	log.Printf("Quorum configuration complete: %d/%d nodes configured", quorumAddSuccess, len(m.nodes))
+	logging.Infof("Quorum configuration complete: %d/%d nodes configured", quorumAddSuccess, len(m.nodes))
 
 	// Setup quorum for quorum nodes
-	log.Printf("\n================== PHASE 4: Quorum Setup ==================")
-	log.Printf("Setting up %d quorum nodes with quorum-specific configuration...", m.config.QuorumNodeCount)
+	logging.Infof("\n================== PHASE 4: Quorum Setup ==================")
+	m.startupPhase("Quorum Setup", m.config.QuorumNodeCount)
+	logging.Infof("Setting up %d quorum nodes with quorum-specific configuration...", m.config.QuorumNodeCount)
 	quorumSetupSuccess := 0
 	for nodeID, nodeInfo := range m.nodes {
 		if nodeInfo.IsQuorum {
-			client := NewClient(nodeInfo.ServerPort)
-			log.Printf("[%s] Setting up quorum configuration...", nodeID)
+			client := m.newClient(nodeInfo.ServerPort)
+			logging.Infof("[%s] Setting up quorum configuration...", nodeID)
 			if err := client.SetupQuorum(nodeInfo.DID, m.config.DefaultQuorumKeyPassword, m.config.DefaultPrivKeyPassword); err != nil {
-				log.Printf("  ✗ WARNING: Failed to setup quorum for %s: %v", nodeID, err)
+				logging.Warnf("  ✗ WARNING: Failed to setup quorum for %s: %v", nodeID, err)
 			} else {
-				log.Printf("  ✓ Successfully setup quorum for %s", nodeID)
+				logging.Infof("  ✓ Successfully setup quorum for %s", nodeID)
 				quorumSetupSuccess++
 			}
+			m.startupNodeDone(nodeID)
 		}
 	}
-	log.Printf("Quorum setup complete: %d/%d quorum nodes configured", quorumSetupSuccess, m.config.QuorumNodeCount)
+	logging.Infof("Quorum setup complete: %d/%d quorum nodes configured", quorumSetupSuccess, m.config.QuorumNodeCount)
 
 	// Generate test tokens for all nodes
-	log.Printf("\n================== PHASE 5: Token Generation ==================")
-	log.Printf("Generating 100 test RBT tokens for all %d nodes...", len(m.nodes))
+	logging.Infof("\n================== PHASE 5: Token Generation ==================")
+	m.startupPhase("Token Generation", len(m.nodes))
+	tokens := m.initialTokens(initialTokens)
+	logging.Infof("Generating %d test RBT tokens for all %d nodes...", tokens, len(m.nodes))
 	tokenGenSuccess := 0
+	var tokenShortfalls []string
 	for nodeID, nodeInfo := range m.nodes {
 		nodeType := "transaction"
 		if nodeInfo.IsQuorum {
 			nodeType = "quorum"
 		}
-		client := NewClient(nodeInfo.ServerPort)
+		client := m.newClient(nodeInfo.ServerPort)
 		didDisplay := nodeInfo.DID
 		if len(nodeInfo.DID) > 16 {
 			didDisplay = nodeInfo.DID[:16] + "..."
 		}
-		log.Printf("[%s] Generating test tokens for %s node (DID: %s)...", nodeID, nodeType, didDisplay)
-		maxRetries := 2
-		tokenGenerated := false
-		for attempt := 1; attempt <= maxRetries; attempt++ {
-			if attempt > 1 {
-				log.Printf("  Retry %d/%d for %s...", attempt, maxRetries, nodeID)
-			}
-			if err := client.GenerateTestTokens(nodeInfo.DID, 100, m.config.DefaultPrivKeyPassword); err != nil {
-				log.Printf("  ✗ Failed to generate tokens (attempt %d): %v", attempt, err)
-				if attempt == maxRetries {
-					break
-				}
-				continue
-			}
-
-			// Verify tokens were generated
-			log.Printf("  Checking balance for %s...", nodeID)
-			balance, err := client.GetAccountBalance(nodeInfo.DID)
-			if err != nil {
-				log.Printf("  ✗ Failed to check balance: %v", err)
-				break
-			}
-
-			log.Printf("  Balance for %s: %.3f RBT", nodeID, balance)
-
-			if balance > 0 {
-				log.Printf("  ✓ Successfully generated tokens for %s (Balance: %.3f RBT)", nodeID, balance)
-				tokenGenerated = true
-				tokenGenSuccess++
-				break
-			} else if attempt < maxRetries {
-				log.Printf("  ⚠ Balance is 0, retrying token generation...")
-				time.Sleep(5 * time.Second) // Wait a bit before retry
-			} else {
-				log.Printf("  ✗ ERROR: %s still has 0 balance after %d attempts!", nodeID, maxRetries)
+		logging.Infof("[%s] Generating test tokens for %s node (DID: %s)...", nodeID, nodeType, didDisplay)
+		if err := m.generateTokensWithRetry(client, nodeID, nodeInfo.DID, tokens); err == nil {
+			logging.Infof("  ✓ Successfully generated tokens for %s", nodeID)
+			tokenGenSuccess++
+		} else {
+			logging.Warnf("  ✗ FAILED: Token generation failed for %s: %v", nodeID, err)
+			if errors.Is(err, ErrTokenGenShort) {
+				tokenShortfalls = append(tokenShortfalls, fmt.Sprintf("%s: %v", nodeID, err))
 			}
 		}
-		if !tokenGenerated {
-			log.Printf("  ✗ FAILED: Token generation failed for %s", nodeID)
-		}
+		m.startupNodeDone(nodeID)
 	}
-	log.Printf("Token generation complete: %d/%d nodes have tokens", tokenGenSuccess, len(m.nodes))
+	logging.Infof("Token generation complete: %d/%d nodes have the full %d tokens", tokenGenSuccess, len(m.nodes), tokens)
 
 	// Save metadata
-	log.Printf("\n================== PHASE 6: Finalization ==================")
+	logging.Infof("\n================== PHASE 6: Finalization ==================")
+	m.startupPhase("Finalization", 0)
 	if err := m.saveMetadata(); err != nil {
-		log.Printf("⚠ Warning: failed to save metadata: %v", err)
+		logging.Warnf("⚠ Warning: failed to save metadata: %v", err)
 	} else {
-		log.Printf("✓ Metadata saved successfully")
+		logging.Infof("✓ Metadata saved successfully")
 	}
 
-	log.Printf("\n================== SETUP COMPLETE ==================")
-	log.Printf("Summary:")
-	log.Printf("  - Nodes started: %d/%d", len(m.nodes), totalNodes)
-	log.Printf("  - DIDs registered: %d/%d", registrationSuccess, len(m.nodes))
-	log.Printf("  - Quorum configured: %d/%d", quorumAddSuccess, len(m.nodes))
-	log.Printf("  - Quorum setup: %d/%d", quorumSetupSuccess, m.config.QuorumNodeCount)
-	log.Printf("  - Tokens generated: %d/%d", tokenGenSuccess, len(m.nodes))
+	logging.Infof("\n================== SETUP COMPLETE ==================")
+	logging.Infof("Summary:")
+	logging.Infof("  - Nodes started: %d/%d", len(m.nodes), totalNodes)
+	logging.Infof("  - DIDs registered: %d/%d", registrationSuccess, len(m.nodes))
+	logging.Infof("  - Quorum configured: %d/%d", quorumAddSuccess, len(m.nodes))
+	logging.Infof("  - Quorum setup: %d/%d", quorumSetupSuccess, m.config.QuorumNodeCount)
+	logging.Infof("  - Tokens generated: %d/%d", tokenGenSuccess, len(m.nodes))
+	if len(tokenShortfalls) > 0 {
+		// Partially funded nodes would otherwise only show up as failed transfers later
+		sort.Strings(tokenShortfalls)
+		logging.Warnf("  - Nodes short of the requested %d tokens: %d", tokens, len(tokenShortfalls))
+		for _, shortfall := range tokenShortfalls {
+			logging.Warnf("      %s", shortfall)
+		}
+	}
 
 	if registrationSuccess < len(m.nodes) || quorumAddSuccess < len(m.nodes) || tokenGenSuccess < len(m.nodes) {
-		log.Printf("⚠ WARNING: Some operations failed. Check logs above for details.")
+		logging.Warnf("⚠ WARNING: Some operations failed. Check logs above for details.")
 	} else {
-		log.Printf("✓ All nodes successfully configured and ready!")
+		logging.Infof("✓ All nodes successfully configured and ready!")
 	}
 
 	// Start token monitoring service
-	log.Printf("\n================== PHASE 7: Token Monitoring ==================")
+	logging.Infof("\n================== PHASE 7: Token Monitoring ==================")
 	m.StartTokenMonitoring()
 
 	return nil
@@ -414,7 +749,7 @@ func (m *Manager) startNodeProcess(nodeID string, index int) error {
 
 	// Copy rubixgoplatform
 	if _, err := os.Stat(nodeRubixPath); err != nil {
-		log.Printf("Copying rubixgoplatform to %s", nodeDir)
+		logging.Infof("Copying rubixgoplatform to %s", nodeDir)
 		if err := copyFile(srcRubixPath, nodeRubixPath); err != nil {
 			return fmt.Errorf("failed to copy rubixgoplatform: %w", err)
 		}
@@ -425,7 +760,7 @@ func (m *Manager) startNodeProcess(nodeID string, index int) error {
 
 	// Copy IPFS binary
 	if _, err := os.Stat(nodeIPFSPath); err != nil {
-		log.Printf("Copying IPFS binary to %s", nodeDir)
+		logging.Infof("Copying IPFS binary to %s", nodeDir)
 		if err := copyFile(srcIPFSPath, nodeIPFSPath); err != nil {
 			return fmt.Errorf("failed to copy IPFS: %w", err)
 		}
@@ -436,16 +771,26 @@ func (m *Manager) startNodeProcess(nodeID string, index int) error {
 
 	// Copy testswarm.key
 	if _, err := os.Stat(nodeSwarmKeyPath); err != nil {
-		log.Printf("Copying testswarm.key to %s", nodeDir)
+		logging.Infof("Copying testswarm.key to %s", nodeDir)
 		if err := copyFile(srcSwarmKeyPath, nodeSwarmKeyPath); err != nil {
 			return fmt.Errorf("failed to copy swarm key: %w", err)
 		}
 	}
 
+	// Keep the previous run's output as node.log.1 so each start gets a fresh log
+	if err := rotateNodeLog(filepath.Join(nodeDir, nodeLogFileName)); err != nil {
+		logging.Warnf("Warning: failed to rotate log for %s: %v", nodeID, err)
+	}
+
 	// Calculate ports
 	port := m.config.BaseServerPort + index
 	grpcPort := m.config.BaseGrpcPort + index
 
+	// A zombie node on these ports would answer the readiness checks in place of the new one
+	if err := m.checkNodePortsFree(nodeID, index); err != nil {
+		return fmt.Errorf("%w; stop the process using it (a node from an earlier run may still be running) and try again", err)
+	}
+
 	// Build args (removed -dir flag)
 	args := []string{
 		"run",
@@ -487,7 +832,8 @@ if not exist "testswarm.key" (
     pause > nul
     exit /b 1
 )
-"%s" %s
+echo Output is written to %s
+"%s" %s >> "%s" 2>&1
 echo.
 echo Node stopped. Press any key to close this window...
 pause > nul`,
@@ -497,8 +843,10 @@ pause > nul`,
 			nodeDir,
 			nodeDir,
 			rubixBinName,
+			nodeLogFileName,
 			rubixBinName,
-			strings.Join(args, " "))
+			strings.Join(args, " "),
+			nodeLogFileName)
 
 		// Write batch file
 		batchPath := filepath.Join(m.dataDir, fmt.Sprintf("node_%s.bat", nodeID))
@@ -510,8 +858,11 @@ pause > nul`,
 		cmd = exec.Command("cmd", "/c", "start", "", batchPath)
 	} else {
 		// On Linux/Mac, run in a tmux session
-		sessionName := fmt.Sprintf("rubix-node-%s", nodeID)
-		nodeCommand := fmt.Sprintf("cd %s && %s %s", nodeDir, filepath.Join(nodeDir, rubixBinName), strings.Join(args, " "))
+		sessionName := m.sessionName(nodeID)
+		// Output is also appended to node.log so it outlives the tmux session (see
+		// GetNodeLogs); tee keeps it visible when attaching to the session
+		nodeCommand := fmt.Sprintf("cd %s && %s %s 2>&1 | tee -a %s", nodeDir, filepath.Join(nodeDir, rubixBinName), strings.Join(args, " "),
+			filepath.Join(nodeDir, nodeLogFileName))
 		cmd = exec.Command("tmux", "new-session", "-d", "-s", sessionName, nodeCommand)
 	}
 
@@ -522,11 +873,11 @@ pause > nul`,
 	)
 
 	// Improved logging
-	log.Printf("Starting node %s from directory: %s",
+	logging.Infof("Starting node %s from directory: %s",
 		nodeID,
 		nodeDir,
 	)
-	log.Printf("Command: %s %s",
+	logging.Infof("Command: %s %s",
 		rubixBinName,
 		strings.Join(args, " "),
 	)
@@ -536,17 +887,81 @@ pause > nul`,
 		return fmt.Errorf("failed to start node process: %w", err)
 	}
 
-	log.Printf("Node %s process started successfully", nodeID)
+	logging.Infof("Node %s process started successfully", nodeID)
+
+	m.processStartsMu.Lock()
+	if m.processStarts == nil {
+		m.processStarts = make(map[string]time.Time)
+	}
+	m.processStarts[nodeID] = time.Now()
+	m.processStartsMu.Unlock()
 
 	// Store process handle
 	if nodeInfo, exists := m.nodes[nodeID]; exists {
 		nodeInfo.Process = cmd
 	}
 
-	// Give node some time to boot
-	time.Sleep(30 * time.Second)
+	return m.waitForProcessBoot(nodeID, port)
+}
 
-	return nil
+// waitForProcessBoot polls a freshly launched node until it answers a ping, so fast
+// machines don't wait out a fixed boot delay. It gives up after NodeStartupTimeout.
+func (m *Manager) waitForProcessBoot(nodeID string, port int) error {
+	interval := time.Duration(m.config.ProcessBootPollInterval) * time.Millisecond
+	if interval <= 0 {
+		interval = time.Second
+	}
+	timeout := time.Duration(m.config.NodeStartupTimeout) * time.Second
+
+	client := m.newClient(port)
+	start := time.Now()
+	for {
+		if err := client.Ping(); err == nil {
+			logging.Infof("Node %s reachable after %v", nodeID, time.Since(start).Round(time.Millisecond))
+			return nil
+		}
+		if time.Since(start) >= timeout {
+			return fmt.Errorf("node %s did not respond within %v of starting", nodeID, timeout)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// nodeIDFor returns the node ID for a fleet index (the port offset). With the "role"
+// naming scheme quorum nodes are quorum0.. and transaction nodes txn0..; otherwise
+// every node is node<index>.
+func (m *Manager) nodeIDFor(index int) string {
+	if m.config.NodeNaming == "role" {
+		if index < m.config.QuorumNodeCount {
+			return fmt.Sprintf("quorum%d", index)
+		}
+		return fmt.Sprintf("txn%d", index-m.config.QuorumNodeCount)
+	}
+	return fmt.Sprintf("node%d", index)
+}
+
+// nodeIndex parses the fleet index back out of a node ID in either naming scheme, so
+// nodes created before the scheme was changed keep their ports
+func (m *Manager) nodeIndex(nodeID string) int {
+	var index int
+	switch {
+	case strings.HasPrefix(nodeID, "quorum"):
+		fmt.Sscanf(nodeID, "quorum%d", &index)
+	case strings.HasPrefix(nodeID, "txn"):
+		fmt.Sscanf(nodeID, "txn%d", &index)
+		index += m.config.QuorumNodeCount
+	default:
+		fmt.Sscanf(nodeID, "node%d", &index)
+	}
+	return index
+}
+
+// sessionName returns the tmux session name for a node, unique per simulator instance
+func (m *Manager) sessionName(nodeID string) string {
+	if m.config.InstanceOffset > 0 {
+		return fmt.Sprintf("rubix-node-%d-%s", m.config.InstanceOffset, nodeID)
+	}
+	return fmt.Sprintf("rubix-node-%s", nodeID)
 }
 
 // StopAllNodes stops all running nodes
@@ -557,52 +972,132 @@ func (m *Manager) StopAllNodes() error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	log.Printf("Stopping %d nodes...", len(m.nodes))
+	logging.Infof("Stopping %d nodes...", len(m.nodes))
 
 	for nodeID, nodeInfo := range m.nodes {
-		// Try graceful shutdown first with a short timeout
-		client := NewClient(nodeInfo.ServerPort)
+		m.stopNode(nodeID, nodeInfo)
+	}
 
-		// Create a channel to handle the shutdown attempt
-		done := make(chan bool, 1)
-		go func() {
-			if err := client.Shutdown(); err != nil {
-				log.Printf("Warning: graceful shutdown failed for %s: %v", nodeID, err)
-			}
-			done <- true
-		}()
+	// Clear nodes
+	m.nodes = make(map[string]*NodeInfo)
 
-		// Wait for graceful shutdown but only for 2 seconds
-		select {
-		case <-done:
-			log.Printf("Node %s shut down gracefully", nodeID)
-		case <-time.After(2 * time.Second):
-			log.Printf("Graceful shutdown timed out for %s, force killing", nodeID)
+	logging.Infof("All nodes stopped")
+	return nil
+}
+
+// stopNode shuts a node down, gracefully if it answers within 2 seconds, then kills its
+// tmux session
+func (m *Manager) stopNode(nodeID string, nodeInfo *NodeInfo) {
+	// Try graceful shutdown first with a short timeout
+	client := m.newClient(nodeInfo.ServerPort)
+
+	// Create a channel to handle the shutdown attempt
+	done := make(chan bool, 1)
+	go func() {
+		if err := client.Shutdown(); err != nil {
+			logging.Warnf("Warning: graceful shutdown failed for %s: %v", nodeID, err)
 		}
+		done <- true
+	}()
 
-		// Force kill the process if it exists
-		if runtime.GOOS == "windows" {
-			// On Windows, the process is the `start` command, which has already exited.
-			// The actual node is in a separate window. The user is expected to close the windows manually.
-			log.Printf("Skipping process kill for %s on Windows. Please close the node window manually.", nodeID)
+	// Wait for graceful shutdown but only for 2 seconds
+	select {
+	case <-done:
+		logging.Infof("Node %s shut down gracefully", nodeID)
+	case <-time.After(2 * time.Second):
+		logging.Warnf("Graceful shutdown timed out for %s, force killing", nodeID)
+	}
+
+	// Force kill the process if it exists
+	if runtime.GOOS == "windows" {
+		// On Windows, the process is the `start` command, which has already exited.
+		// The actual node is in a separate window and is killed by port below if it is still up.
+		logging.Infof("Skipping process kill for %s on Windows; the node window is left open", nodeID)
+	} else {
+		// On Linux/Mac, kill the tmux session
+		sessionName := m.sessionName(nodeID)
+		if err := exec.Command("tmux", "kill-session", "-t", sessionName).Run(); err != nil {
+			logging.Warnf("Warning: failed to kill tmux session for %s: %v", nodeID, err)
 		} else {
-			// On Linux/Mac, kill the tmux session
-			sessionName := fmt.Sprintf("rubix-node-%s", nodeID)
-			if err := exec.Command("tmux", "kill-session", "-t", sessionName).Run(); err != nil {
-				log.Printf("Warning: failed to kill tmux session for %s: %v", nodeID, err)
-			} else {
-				log.Printf("TMUX session killed for %s", nodeID)
-			}
+			logging.Infof("TMUX session killed for %s", nodeID)
 		}
 	}
 
-	// Clear nodes
-	m.nodes = make(map[string]*NodeInfo)
+	// Last resort for a node that outlived its session or window, or was orphaned by a
+	// crashed simulator
+	m.killLeftoverNode(nodeID, nodeInfo)
+}
+
+// RemoveNodes shuts down the given transaction nodes and drops them from the manager, the
+// node metadata and disk. Every ID is checked before anything is stopped, so a bad
+// request removes nothing. Quorum nodes are refused, as is leaving fewer than
+// MinTransactionNodes transaction nodes.
+func (m *Manager) RemoveNodes(nodeIDs []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	log.Printf("All nodes stopped")
+	metadata, err := m.loadMetadata()
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to load metadata: %w", err)
+	}
+	known := make(map[string]*NodeInfo, len(metadata)+len(m.nodes))
+	for nodeID, nodeInfo := range metadata {
+		known[nodeID] = nodeInfo
+	}
+	for nodeID, nodeInfo := range m.nodes {
+		known[nodeID] = nodeInfo
+	}
+
+	removing := make(map[string]bool, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		nodeInfo, exists := known[nodeID]
+		if !exists {
+			return fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID)
+		}
+		if nodeInfo.IsQuorum {
+			return fmt.Errorf("node %s %w", nodeID, ErrQuorumNodeRemoval)
+		}
+		removing[nodeID] = true
+	}
+	remaining := len(known) - countQuorumNodes(known) - len(removing)
+	if remaining < m.config.MinTransactionNodes {
+		return fmt.Errorf("%w: removing %d node(s) would leave %d, minimum %d required",
+			ErrTooFewTransactionNodes, len(removing), remaining, m.config.MinTransactionNodes)
+	}
+
+	for nodeID := range removing {
+		logging.Infof("Removing node %s...", nodeID)
+		m.stopNode(nodeID, known[nodeID])
+		delete(m.nodes, nodeID)
+		delete(metadata, nodeID)
+		// A node added later may reuse this ID, and must not inherit the old DID
+		if err := os.RemoveAll(filepath.Join(m.dataDir, "nodes", nodeID)); err != nil {
+			logging.Warnf("Warning: failed to remove data directory of %s: %v", nodeID, err)
+		}
+	}
+
+	if metadata != nil {
+		if err := m.writeMetadata(metadata); err != nil {
+			return fmt.Errorf("nodes removed but metadata could not be saved: %w", err)
+		}
+	}
+	logging.Infof("Removed %d node(s)", len(removing))
 	return nil
 }
 
+// waitForNodeReady waits up to timeout for the node at index to answer its status API and,
+// with CheckGrpcReadiness, to accept connections on its gRPC port
+func (m *Manager) waitForNodeReady(client *Client, index int, timeout time.Duration) error {
+	start := time.Now()
+	if err := client.WaitForNode(timeout); err != nil {
+		return err
+	}
+	if !m.config.CheckGrpcReadiness {
+		return nil
+	}
+	return client.WaitForGrpc(m.config.BaseGrpcPort+index, timeout-time.Since(start))
+}
+
 // restartExistingNodes restarts nodes from saved metadata with retry logic
 func (m *Manager) restartExistingNodes() error {
 	metadata, err := m.loadMetadata()
@@ -610,19 +1105,19 @@ func (m *Manager) restartExistingNodes() error {
 		return fmt.Errorf("failed to load metadata: %w", err)
 	}
 
-	log.Printf("Restarting %d existing nodes...", len(metadata))
+	m.syncQuorumCount(metadata)
+	logging.Infof("Restarting %d existing nodes...", len(metadata))
 
 	// Restart nodes with retry logic
 	var failedNodes []string
 	for nodeID, nodeInfo := range metadata {
-		index := 0
-		fmt.Sscanf(nodeID, "node%d", &index)
+		index := m.nodeIndex(nodeID)
 
 		// Try to restart with retries
 		var lastErr error
 		for retry := 0; retry < 3; retry++ {
 			if retry > 0 {
-				log.Printf("Retry %d/3 for node %s", retry+1, nodeID)
+				logging.Infof("Retry %d/3 for node %s", retry+1, nodeID)
 				time.Sleep(time.Duration(retry*5) * time.Second)
 			}
 
@@ -633,13 +1128,19 @@ func (m *Manager) restartExistingNodes() error {
 			}
 
 			// Wait for node to be ready with increased timeout for restarts
-			client := NewClient(nodeInfo.ServerPort)
+			client := m.newClient(nodeInfo.ServerPort)
 			timeout := time.Duration(m.config.NodeStartupTimeout) * time.Second
-			if err := client.WaitForNode(timeout); err != nil {
+			if err := m.waitForNodeReady(client, index, timeout); err != nil {
 				lastErr = err
 				continue
 			}
 
+			// A wiped DID store won't be fixed by retrying, so give up on this node
+			if nodeInfo.DID != "" && !m.hasDID(client, nodeID, nodeInfo.DID) {
+				lastErr = fmt.Errorf("DID %s from metadata not found on node", nodeInfo.DID)
+				break
+			}
+
 			// Store node info
 			m.nodes[nodeID] = nodeInfo
 			nodeInfo.Status = "running"
@@ -648,18 +1149,37 @@ func (m *Manager) restartExistingNodes() error {
 		}
 
 		if lastErr != nil {
-			log.Printf("Failed to restart %s after 3 retries: %v", nodeID, lastErr)
+			logging.Errorf("Failed to restart %s after 3 retries: %v", nodeID, lastErr)
 			failedNodes = append(failedNodes, nodeID)
 			nodeInfo.Status = "failed"
 		}
 	}
 
+	// Register DIDs whose registration never completed; confirmed ones are left alone
+	newlyRegistered := 0
+	for nodeID, nodeInfo := range m.nodes {
+		if nodeInfo.Status != "running" || nodeInfo.DID == "" || nodeInfo.DIDRegistered {
+			continue
+		}
+		if err := m.registerDID(nodeInfo, false); err != nil {
+			logging.Warnf("Warning: failed to register DID for %s: %v", nodeID, err)
+		} else {
+			logging.Infof("✓ Registered DID for %s", nodeID)
+			newlyRegistered++
+		}
+	}
+	if newlyRegistered > 0 {
+		if err := m.saveMetadata(); err != nil {
+			logging.Warnf("Warning: failed to save metadata: %v", err)
+		}
+	}
+
 	// Re-setup quorum for successfully restarted quorum nodes
 	for nodeID, nodeInfo := range m.nodes {
 		if nodeInfo.IsQuorum && nodeInfo.Status == "running" {
-			client := NewClient(nodeInfo.ServerPort)
+			client := m.newClient(nodeInfo.ServerPort)
 			if err := client.SetupQuorum(nodeInfo.DID, m.config.DefaultQuorumKeyPassword, m.config.DefaultPrivKeyPassword); err != nil {
-				log.Printf("Warning: failed to setup quorum for %s: %v", nodeID, err)
+				logging.Warnf("Warning: failed to setup quorum for %s: %v", nodeID, err)
 			}
 		}
 	}
@@ -668,18 +1188,21 @@ func (m *Manager) restartExistingNodes() error {
 		return fmt.Errorf("failed to restart nodes: %v", failedNodes)
 	}
 
-	log.Printf("Successfully restarted %d nodes", len(m.nodes))
+	logging.Infof("Successfully restarted %d nodes", len(m.nodes))
 	return nil
 }
 
-// adjustNodeCount selects the first N transaction nodes as active for the simulation
-func (m *Manager) adjustNodeCount(requestedTransactionNodes int) error {
+// adjustNodeCount selects the first N transaction nodes as active for the simulation. Nodes
+// started to make up the count get initialTokens test tokens (0 uses InitialTokens).
+func (m *Manager) adjustNodeCount(requestedTransactionNodes, initialTokens int) error {
 	metadata, err := m.loadMetadata()
 	if err != nil {
 		return fmt.Errorf("failed to load metadata: %w", err)
 	}
 
-	log.Printf("Adjusting active nodes: selecting %d transaction nodes from a total of 20", requestedTransactionNodes)
+	m.syncQuorumCount(metadata)
+	logging.Infof("Adjusting active nodes: selecting %d transaction nodes from a total of 20", requestedTransactionNodes)
+	m.startupPhase("Selecting Nodes", 0)
 
 	// Reset the current nodes map
 	m.nodes = make(map[string]*NodeInfo)
@@ -691,37 +1214,97 @@ func (m *Manager) adjustNodeCount(requestedTransactionNodes int) error {
 		}
 	}
 
-	// Select the first N transaction nodes
+	// Select the first N transaction nodes in port order, whatever naming scheme created them
+	var transactionNodes []*NodeInfo
+	for _, nodeInfo := range metadata {
+		if !nodeInfo.IsQuorum {
+			transactionNodes = append(transactionNodes, nodeInfo)
+		}
+	}
+	sort.Slice(transactionNodes, func(i, j int) bool {
+		return transactionNodes[i].ServerPort < transactionNodes[j].ServerPort
+	})
+
 	transactionNodesAdded := 0
-	for i := 0; i < m.config.MaxTransactionNodes; i++ {
-		nodeID := fmt.Sprintf("node%d", m.config.QuorumNodeCount+i)
-		if nodeInfo, exists := metadata[nodeID]; exists {
-			if !nodeInfo.IsQuorum && transactionNodesAdded < requestedTransactionNodes {
-				m.nodes[nodeID] = nodeInfo
-				transactionNodesAdded++
-			}
+	for _, nodeInfo := range transactionNodes {
+		if transactionNodesAdded >= requestedTransactionNodes {
+			break
+		}
+		m.nodes[nodeInfo.ID] = nodeInfo
+		transactionNodesAdded++
+	}
+
+	logging.Infof("Selected %d quorum nodes and %d transaction nodes", m.config.QuorumNodeCount, transactionNodesAdded)
+
+	// Every existing transaction node is selected at this point, so scaling up
+	// continues numbering after them without disturbing the saved metadata
+	if missing := requestedTransactionNodes - transactionNodesAdded; missing > 0 && m.config.AutoScaleNodes {
+		logging.Infof("Only %d of %d requested transaction nodes exist, starting %d more", transactionNodesAdded, requestedTransactionNodes, missing)
+		m.planStartupPhases(addNodesPhases...)
+		if _, err := m.addTransactionNodes(missing, m.initialTokens(initialTokens)); err != nil {
+			return fmt.Errorf("failed to scale up transaction nodes: %w", err)
 		}
 	}
 
-	log.Printf("Selected %d quorum nodes and %d transaction nodes", m.config.QuorumNodeCount, transactionNodesAdded)
 	return nil
 }
 
-// addTransactionNodes adds additional transaction nodes to the existing setup
-func (m *Manager) addTransactionNodes(additionalCount int) error {
+// AddTransactionNodes starts count more transaction nodes next to the running fleet without
+// touching the existing nodes, and returns the nodes that were added. They join the
+// fleet's quorum list and get test tokens like nodes started with the fleet.
+func (m *Manager) AddTransactionNodes(count int) (added []*NodeInfo, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.nodes) == 0 {
+		return nil, fmt.Errorf("no nodes are running; start the fleet first")
+	}
+
+	metadata, err := m.loadMetadata()
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to load metadata: %w", err)
+	}
+	known := make(map[string]*NodeInfo, len(metadata)+len(m.nodes))
+	for nodeID, nodeInfo := range metadata {
+		known[nodeID] = nodeInfo
+	}
+	for nodeID, nodeInfo := range m.nodes {
+		known[nodeID] = nodeInfo
+	}
+	existing := len(known) - countQuorumNodes(known)
+	if existing+count > m.config.MaxTransactionNodes {
+		return nil, fmt.Errorf("%w: the fleet has %d transaction nodes; adding %d would exceed the maximum of %d",
+			ErrTooManyTransactionNodes, existing, count, m.config.MaxTransactionNodes)
+	}
+
+	m.beginStartup(addNodesPhases...)
+	defer func() { m.finishStartup(err) }()
+	return m.addTransactionNodes(count, m.initialTokens(0))
+}
+
+// addTransactionNodes adds additional transaction nodes to the existing setup, generating
+// tokens test tokens for each. Caller must hold m.mu.
+func (m *Manager) addTransactionNodes(additionalCount, tokens int) ([]*NodeInfo, error) {
 	if additionalCount <= 0 {
-		return nil
+		return nil, nil
 	}
 
-	log.Printf("Adding %d additional transaction nodes to existing setup", additionalCount)
+	logging.Infof("Adding %d additional transaction nodes to existing setup", additionalCount)
+
+	// Saved nodes that aren't currently selected still own their ports
+	metadata, err := m.loadMetadata()
+	if err != nil && !os.IsNotExist(err) {
+		logging.Warnf("Warning: failed to load metadata, numbering from the selected nodes only: %v", err)
+	}
 
 	// Find the highest node index to continue numbering from there
 	highestIndex := -1
-	for nodeID := range m.nodes {
-		var index int
-		fmt.Sscanf(nodeID, "node%d", &index)
-		if index > highestIndex {
-			highestIndex = index
+	for _, nodes := range []map[string]*NodeInfo{m.nodes, metadata} {
+		for nodeID := range nodes {
+			index := m.nodeIndex(nodeID)
+			if index > highestIndex {
+				highestIndex = index
+			}
 		}
 	}
 
@@ -737,27 +1320,28 @@ func (m *Manager) addTransactionNodes(additionalCount int) error {
 	}
 
 	// Start new transaction nodes
+	m.startupPhase("Starting Additional Nodes", additionalCount)
 	newNodes := make([]*NodeInfo, 0)
 	for i := 0; i < additionalCount; i++ {
 		nodeIndex := highestIndex + 1 + i
-		nodeID := fmt.Sprintf("node%d", nodeIndex)
+		nodeID := m.nodeIDFor(nodeIndex)
 		serverPort := m.config.BaseServerPort + nodeIndex
 		grpcPort := m.config.BaseGrpcPort + nodeIndex
 
-		log.Printf("Starting additional transaction node %s (ports: server=%d, grpc=%d)",
+		logging.Infof("Starting additional transaction node %s (ports: server=%d, grpc=%d)",
 			nodeID, serverPort, grpcPort)
 
 		// Start the node process
 		if err := m.startNodeProcess(nodeID, nodeIndex); err != nil {
-			log.Printf("Failed to start %s: %v", nodeID, err)
+			logging.Errorf("Failed to start %s: %v", nodeID, err)
 			continue
 		}
 
 		// Wait for node to be ready
-		client := NewClient(serverPort)
+		client := m.newClient(serverPort)
 		timeout := time.Duration(m.config.NodeStartupTimeout) * time.Second
-		if err := client.WaitForNode(timeout); err != nil {
-			log.Printf("Node %s failed to become ready: %v", nodeID, err)
+		if err := m.waitForNodeReady(client, nodeIndex, timeout); err != nil {
+			logging.Warnf("Node %s failed to become ready: %v", nodeID, err)
 			continue
 		}
 
@@ -770,106 +1354,92 @@ func (m *Manager) addTransactionNodes(additionalCount int) error {
 			Status:     "running",
 		}
 
-		// Create DID for the new node
-		log.Printf("Creating DID for %s...", nodeID)
-		did, peerID, err := client.CreateDID(m.config.DefaultPrivKeyPassword)
+		// Create DID for the new node, or reuse one left in its directory
+		logging.Infof("Creating DID for %s...", nodeID)
+		did, peerID, reused, err := m.ensureDID(client, nodeID, "")
 		if err != nil {
-			log.Printf("Failed to create DID for %s: %v", nodeID, err)
+			logging.Errorf("Failed to create DID for %s: %v", nodeID, err)
 			// Continue anyway, node might work without DID
 		} else {
 			nodeInfo.DID = did
+			action := "Created"
+			if reused {
+				action = "Reused existing"
+			}
 			// Handle peerID gracefully - it may be empty
 			if peerID != "" {
 				nodeInfo.PeerID = peerID
-				log.Printf("✓ Created DID for %s with peerID", nodeID)
+				logging.Infof("✓ %s DID for %s with peerID", action, nodeID)
 			} else {
-				log.Printf("✓ Created DID for %s (no peerID returned)", nodeID)
+				logging.Infof("✓ %s DID for %s (no peerID returned)", action, nodeID)
 			}
 		}
 
 		m.nodes[nodeID] = nodeInfo
 		newNodes = append(newNodes, nodeInfo)
+		m.startupNodeDone(nodeID)
 	}
 
 	if len(newNodes) == 0 {
-		return fmt.Errorf("failed to add any new transaction nodes")
+		return nil, fmt.Errorf("failed to add any new transaction nodes")
 	}
 
 	// Phase 2: Register DIDs for new nodes
-	log.Printf("Registering DIDs for %d new nodes...", len(newNodes))
+	logging.Infof("Registering DIDs for %d new nodes...", len(newNodes))
+	m.startupPhase("DID Registration", len(newNodes))
 	for _, nodeInfo := range newNodes {
-		if nodeInfo.DID == "" {
-			continue
-		}
-		client := NewClient(nodeInfo.ServerPort)
-		if err := client.RegisterDID(nodeInfo.DID, m.config.DefaultPrivKeyPassword); err != nil {
-			log.Printf("⚠ Warning: Failed to register DID for %s: %v", nodeInfo.ID, err)
-		} else {
-			log.Printf("✓ Registered DID for %s", nodeInfo.ID)
+		if nodeInfo.DID != "" {
+			if err := m.registerDID(nodeInfo, true); err != nil {
+				logging.Warnf("⚠ Warning: Failed to register DID for %s: %v", nodeInfo.ID, err)
+			} else {
+				logging.Infof("✓ Registered DID for %s", nodeInfo.ID)
+			}
 		}
+		m.startupNodeDone(nodeInfo.ID)
 	}
 
 	// Phase 3: Add quorum list to new nodes
-	log.Printf("Adding quorum list to new nodes...")
+	logging.Infof("Adding quorum list to new nodes...")
+	m.startupPhase("Quorum Configuration", len(newNodes))
 	for _, nodeInfo := range newNodes {
-		client := NewClient(nodeInfo.ServerPort)
+		client := m.newClient(nodeInfo.ServerPort)
 		if err := client.AddQuorum(quorumList); err != nil {
-			log.Printf("⚠ Warning: Failed to add quorum list to %s: %v", nodeInfo.ID, err)
+			logging.Warnf("⚠ Warning: Failed to add quorum list to %s: %v", nodeInfo.ID, err)
 		} else {
-			log.Printf("✓ Added quorum list to %s", nodeInfo.ID)
+			logging.Infof("✓ Added quorum list to %s", nodeInfo.ID)
 		}
+		m.startupNodeDone(nodeInfo.ID)
 	}
 
 	// Phase 4: Generate test tokens for new nodes
-	log.Printf("Generating test tokens for new nodes...")
+	logging.Infof("Generating test tokens for new nodes...")
+	m.startupPhase("Token Generation", len(newNodes))
 	for _, nodeInfo := range newNodes {
-		if nodeInfo.DID == "" {
-			continue
-		}
-		client := NewClient(nodeInfo.ServerPort)
-
-		// Try to generate tokens with retries
-		tokenGenerated := false
-		maxRetries := 3
-		for attempt := 1; attempt <= maxRetries; attempt++ {
-			if attempt > 1 {
-				log.Printf("  Retry %d/%d for %s...", attempt, maxRetries, nodeInfo.ID)
-			}
-			if err := client.GenerateTestTokens(nodeInfo.DID, 100, m.config.DefaultPrivKeyPassword); err != nil {
-				log.Printf("  ✗ Failed to generate tokens for %s (attempt %d): %v", nodeInfo.ID, attempt, err)
-				if attempt == maxRetries {
-					break
-				}
-				time.Sleep(time.Second * time.Duration(attempt))
-				continue
-			}
-
-			// Verify tokens were generated
-			balance, err := client.GetAccountBalance(nodeInfo.DID)
-			if err != nil {
-				log.Printf("  ✗ Failed to check balance for %s: %v", nodeInfo.ID, err)
-				break
-			}
-
-			if balance > 0 {
-				log.Printf("  ✓ Generated %.2f tokens for %s", balance, nodeInfo.ID)
-				tokenGenerated = true
-				break
+		if nodeInfo.DID != "" {
+			client := m.newClient(nodeInfo.ServerPort)
+			if err := m.generateTokensWithRetry(client, nodeInfo.ID, nodeInfo.DID, tokens); err == nil {
+				logging.Infof("  ✓ Generated tokens for %s", nodeInfo.ID)
+			} else {
+				logging.Warnf("  ⚠ Warning: Could not generate tokens for %s: %v", nodeInfo.ID, err)
 			}
 		}
-
-		if !tokenGenerated {
-			log.Printf("  ⚠ Warning: Could not generate tokens for %s", nodeInfo.ID)
-		}
+		m.startupNodeDone(nodeInfo.ID)
 	}
 
-	// Save updated metadata
-	if err := m.saveMetadata(); err != nil {
-		log.Printf("Warning: failed to save metadata: %v", err)
+	// Save updated metadata, keeping saved nodes that aren't currently selected
+	m.startupPhase("Finalization", 0)
+	if metadata == nil {
+		metadata = make(map[string]*NodeInfo)
+	}
+	for nodeID, nodeInfo := range m.nodes {
+		metadata[nodeID] = nodeInfo
+	}
+	if err := m.writeMetadata(metadata); err != nil {
+		logging.Warnf("Warning: failed to save metadata: %v", err)
 	}
 
-	log.Printf("Successfully added %d transaction nodes", len(newNodes))
-	return nil
+	logging.Infof("Successfully added %d transaction nodes", len(newNodes))
+	return newNodes, nil
 }
 
 // RestartNodes restarts specific nodes
@@ -880,18 +1450,16 @@ func (m *Manager) RestartNodes(nodeIDs []string) error {
 	for _, nodeID := range nodeIDs {
 		nodeInfo, exists := m.nodes[nodeID]
 		if !exists {
-			log.Printf("Node %s not found, skipping", nodeID)
+			logging.Infof("Node %s not found, skipping", nodeID)
 			continue
 		}
 
-		// Stop the node first
-		if nodeInfo.Process != nil {
-			nodeInfo.Process.Process.Kill()
-		}
+		// Stop the node first; the launcher in nodeInfo.Process has already exited, and the
+		// node itself would keep its ports
+		m.stopNode(nodeID, nodeInfo)
 
 		// Extract index from nodeID
-		index := 0
-		fmt.Sscanf(nodeID, "node%d", &index)
+		index := m.nodeIndex(nodeID)
 
 		// Restart the node
 		if err := m.startNodeProcess(nodeID, index); err != nil {
@@ -899,14 +1467,14 @@ func (m *Manager) RestartNodes(nodeIDs []string) error {
 		}
 
 		// Wait for node to be ready
-		client := NewClient(nodeInfo.ServerPort)
+		client := m.newClient(nodeInfo.ServerPort)
 		timeout := time.Duration(m.config.NodeStartupTimeout) * time.Second
-		if err := client.WaitForNode(timeout); err != nil {
+		if err := m.waitForNodeReady(client, index, timeout); err != nil {
 			return fmt.Errorf("node %s failed to restart: %w", nodeID, err)
 		}
 
 		nodeInfo.Status = "running"
-		log.Printf("Successfully restarted node %s", nodeID)
+		logging.Infof("Successfully restarted node %s", nodeID)
 	}
 
 	return nil
@@ -922,12 +1490,12 @@ func (m *Manager) RecoverNode(nodeID string) error {
 		return fmt.Errorf("node %s not found", nodeID)
 	}
 
-	log.Printf("Attempting to recover node %s", nodeID)
+	logging.Infof("Attempting to recover node %s", nodeID)
 
 	// Check if node is actually responding
-	client := NewClient(nodeInfo.ServerPort)
+	client := m.newClient(nodeInfo.ServerPort)
 	if err := client.Ping(); err == nil {
-		log.Printf("Node %s is already running", nodeID)
+		logging.Infof("Node %s is already running", nodeID)
 		nodeInfo.Status = "running"
 		return nil
 	}
@@ -938,60 +1506,60 @@ func (m *Manager) RecoverNode(nodeID string) error {
 		time.Sleep(2 * time.Second)
 	}
 
-	// Clean node directory
-	nodeDir := filepath.Join(m.dataDir, "nodes", nodeID)
-	tempDir := nodeDir + "_backup"
+	// A hung node no longer answers but still holds its ports
+	m.killLeftoverNode(nodeID, nodeInfo)
 
-	// Backup existing data
-	if err := os.Rename(nodeDir, tempDir); err != nil {
-		log.Printf("Warning: failed to backup node directory: %v", err)
-	}
-	defer os.RemoveAll(tempDir)
-
-	// Recreate node directory
-	if err := os.MkdirAll(nodeDir, 0o755); err != nil {
-		return fmt.Errorf("failed to create node directory: %w", err)
-	}
+	// Keep the crashed node's last output; the restart rotates its log
+	crashLog, _ := tailLines(m.nodeLogPath(nodeID), recoveryLogLines)
 
 	// Extract index from nodeID
-	index := 0
-	fmt.Sscanf(nodeID, "node%d", &index)
+	index := m.nodeIndex(nodeID)
 
-	// Restart the node
+	// Restart the node in its existing directory, which holds its DID key store: a node
+	// started on an empty directory would need a new DID the quorum list doesn't know
 	if err := m.startNodeProcess(nodeID, index); err != nil {
-		// Restore backup if restart fails
-		os.RemoveAll(nodeDir)
-		os.Rename(tempDir, nodeDir)
-		return fmt.Errorf("failed to recover node: %w", err)
+		return fmt.Errorf("failed to recover node: %w%s", err, formatLogTail(crashLog))
 	}
 
 	// Wait for node to be ready
 	timeout := time.Duration(m.config.NodeStartupTimeout) * time.Second
-	if err := client.WaitForNode(timeout); err != nil {
-		return fmt.Errorf("node recovery failed: %w", err)
+	if err := m.waitForNodeReady(client, index, timeout); err != nil {
+		return fmt.Errorf("node recovery failed: %w%s", err, formatLogTail(crashLog))
 	}
 
-	// Recreate DID if needed
+	// Recreate DID if needed, e.g. when the node's DID store was lost in the crash
+	knownDID := nodeInfo.DID
+	if nodeInfo.DID != "" && !m.hasDID(client, nodeID, nodeInfo.DID) {
+		logging.Warnf("⚠ DID %s for %s no longer exists on the node", nodeInfo.DID, nodeID)
+		nodeInfo.DID = ""
+	}
 	if nodeInfo.DID == "" {
-		log.Printf("Recreating DID for recovered node %s", nodeID)
-		did, peerID, err := client.CreateDID(m.config.DefaultPrivKeyPassword)
+		logging.Infof("Recreating DID for recovered node %s", nodeID)
+		did, peerID, reused, err := m.ensureDID(client, nodeID, knownDID)
 		if err != nil {
-			log.Printf("Warning: failed to recreate DID: %v", err)
+			logging.Warnf("Warning: failed to recreate DID: %v", err)
 		} else {
+			if reused {
+				logging.Infof("Reusing DID %s already on %s", did, nodeID)
+			}
 			nodeInfo.DID = did
 			nodeInfo.PeerID = peerID
+			nodeInfo.DIDRegistered = false
+			if err := m.registerDID(nodeInfo, false); err != nil {
+				logging.Warnf("Warning: failed to register recreated DID: %v", err)
+			}
 		}
 	}
 
 	// Re-setup quorum if needed
 	if nodeInfo.IsQuorum {
 		if err := client.SetupQuorum(nodeInfo.DID, m.config.DefaultQuorumKeyPassword, m.config.DefaultPrivKeyPassword); err != nil {
-			log.Printf("Warning: failed to setup quorum for recovered node: %v", err)
+			logging.Warnf("Warning: failed to setup quorum for recovered node: %v", err)
 		}
 	}
 
 	nodeInfo.Status = "running"
-	log.Printf("Successfully recovered node %s", nodeID)
+	logging.Infof("Successfully recovered node %s", nodeID)
 
 	// Save updated metadata
 	m.saveMetadata()
@@ -1001,36 +1569,41 @@ func (m *Manager) RecoverNode(nodeID string) error {
 
 // setupRubixPlatform downloads and builds rubixgoplatform
 func (m *Manager) setupRubixPlatform() error {
-	log.Println("Setting up rubixgoplatform...")
+	logging.Infof("Setting up rubixgoplatform...")
 
 	needsBuild := false
 
 	// Check if repository already exists
 	if _, err := os.Stat(m.rubixPath); err == nil {
-		log.Printf("Rubixgoplatform directory already exists at %s", m.rubixPath)
+		logging.Infof("Rubixgoplatform directory already exists at %s", m.rubixPath)
 
-		// Try to pull latest changes instead of cloning
-		cmd := exec.Command("git", "pull", "origin", m.config.RubixBranch)
-		cmd.Dir = m.rubixPath
-		output, err := cmd.CombinedOutput()
-		if err != nil {
-			log.Printf("Warning: failed to pull latest changes: %v\nOutput: %s", err, string(output))
-			// Continue anyway - existing code might work
+		if m.config.SkipPlatformUpdate {
+			// Pinned or offline: use the checkout as-is, building only if the executable is missing
+			logging.Infof("Skipping rubixgoplatform update (skipPlatformUpdate is set)")
 		} else {
-			outputStr := string(output)
-			log.Printf("Git pull output: %s", outputStr)
-
-			// Check if there were actual updates
-			if outputStr != "Already up to date.\n" && outputStr != "Already up-to-date.\n" {
-				log.Println("Repository updated with new changes, will rebuild executable")
-				needsBuild = true
+			// Try to pull latest changes instead of cloning
+			cmd := exec.Command("git", "pull", "origin", m.config.RubixBranch)
+			cmd.Dir = m.rubixPath
+			output, err := cmd.CombinedOutput()
+			if err != nil {
+				logging.Warnf("Warning: failed to pull latest changes: %v\nOutput: %s", err, string(output))
+				// Continue anyway - existing code might work
 			} else {
-				log.Println("Repository already up to date")
+				outputStr := string(output)
+				logging.Infof("Git pull output: %s", outputStr)
+
+				// Check if there were actual updates
+				if outputStr != "Already up to date.\n" && outputStr != "Already up-to-date.\n" {
+					logging.Infof("Repository updated with new changes, will rebuild executable")
+					needsBuild = true
+				} else {
+					logging.Infof("Repository already up to date")
+				}
 			}
 		}
 	} else {
 		// Clone the repository if it doesn't exist
-		log.Printf("Cloning from %s...", m.config.RubixRepoURL)
+		logging.Infof("Cloning from %s...", m.config.RubixRepoURL)
 		cmd := exec.Command("git", "clone", m.config.RubixRepoURL, m.rubixPath)
 		output, err := cmd.CombinedOutput()
 		if err != nil {
@@ -1041,7 +1614,7 @@ func (m *Manager) setupRubixPlatform() error {
 		cmd = exec.Command("git", "checkout", m.config.RubixBranch)
 		cmd.Dir = m.rubixPath
 		if err := cmd.Run(); err != nil {
-			log.Printf("Warning: failed to checkout branch %s: %v", m.config.RubixBranch, err)
+			logging.Warnf("Warning: failed to checkout branch %s: %v", m.config.RubixBranch, err)
 		}
 
 		// Fresh clone always needs build
@@ -1072,9 +1645,9 @@ func (m *Manager) setupRubixPlatform() error {
 	// Build if needed: either doesn't exist or source was updated
 	if !execExists || needsBuild {
 		if needsBuild {
-			log.Println("Rebuilding rubixgoplatform due to source updates...")
+			logging.Infof("Rebuilding rubixgoplatform due to source updates...")
 		} else {
-			log.Println("Building rubixgoplatform for the first time...")
+			logging.Infof("Building rubixgoplatform for the first time...")
 		}
 
 		// Determine the make target based on OS
@@ -1090,7 +1663,7 @@ func (m *Manager) setupRubixPlatform() error {
 			return fmt.Errorf("unsupported operating system: %s", runtime.GOOS)
 		}
 
-		log.Printf("Building rubixgoplatform using make %s...", makeTarget)
+		logging.Infof("Building rubixgoplatform using make %s...", makeTarget)
 
 		// Use make command to build
 		cmd := exec.Command("make", makeTarget)
@@ -1101,9 +1674,9 @@ func (m *Manager) setupRubixPlatform() error {
 		if err != nil {
 			return fmt.Errorf("failed to build rubixgoplatform using make %s: %w\nOutput: %s", makeTarget, err, string(output))
 		}
-		log.Println("Successfully built rubixgoplatform")
+		logging.Infof("Successfully built rubixgoplatform")
 	} else {
-		log.Printf("Using existing rubixgoplatform executable at %s", execPath)
+		logging.Infof("Using existing rubixgoplatform executable at %s", execPath)
 	}
 
 	// Download IPFS
@@ -1113,38 +1686,38 @@ func (m *Manager) setupRubixPlatform() error {
 
 	// Download test swarm key
 	if err := m.downloadSwarmKey(); err != nil {
-		log.Printf("Warning: failed to download swarm key: %v", err)
+		logging.Warnf("Warning: failed to download swarm key: %v", err)
 	}
 
-	log.Println("Rubixgoplatform setup completed successfully")
+	logging.Infof("Rubixgoplatform setup completed successfully")
 	return nil
 }
 
 // downloadSwarmKey downloads the test swarm key with retry logic
 func (m *Manager) downloadSwarmKey() error {
-	log.Println("Downloading test swarm key...")
+	logging.Infof("Downloading test swarm key...")
 
 	buildDir := m.getBuildDir()
 	destPath := filepath.Join(m.rubixPath, buildDir, "testswarm.key")
 
 	// Check if already exists
 	if _, err := os.Stat(destPath); err == nil {
-		log.Printf("Swarm key already exists at %s", destPath)
+		logging.Infof("Swarm key already exists at %s", destPath)
 		return nil
 	}
 
 	// Try to copy from the repository first
 	srcPath := filepath.Join(m.rubixPath, "testswarm.key")
 	if _, err := os.Stat(srcPath); err == nil {
-		log.Println("Copying swarm key from repository...")
+		logging.Infof("Copying swarm key from repository...")
 		return copyFile(srcPath, destPath)
 	}
 
 	// Download from URL with retry
-	log.Printf("Downloading swarm key from: %s", m.config.TestSwarmKeyURL)
+	logging.Infof("Downloading swarm key from: %s", m.config.TestSwarmKeyURL)
 	tempFile := filepath.Join(m.dataDir, "testswarm.key.tmp")
 
-	if err := m.downloadWithRetry(m.config.TestSwarmKeyURL, tempFile, 3); err != nil {
+	if err := m.downloadWithRetry(m.config.TestSwarmKeyURL, tempFile, 3, sha256Checksum(m.config.TestSwarmKeySHA256)); err != nil {
 		return fmt.Errorf("failed to download swarm key: %w", err)
 	}
 
@@ -1154,7 +1727,7 @@ func (m *Manager) downloadSwarmKey() error {
 		return fmt.Errorf("failed to move swarm key: %w", err)
 	}
 
-	log.Println("Successfully downloaded test swarm key")
+	logging.Infof("Successfully downloaded test swarm key")
 	return nil
 }
 
@@ -1176,7 +1749,7 @@ func (m *Manager) DownloadIPFSManually() error {
 
 // downloadIPFS downloads the IPFS binary with retry logic
 func (m *Manager) downloadIPFS() error {
-	log.Printf("Downloading IPFS binary (version: %s)...", m.config.IPFSVersion)
+	logging.Infof("Downloading IPFS binary (version: %s)...", m.config.IPFSVersion)
 
 	buildDir := m.getBuildDir()
 	ipfsBinName := "ipfs"
@@ -1193,41 +1766,26 @@ func (m *Manager) downloadIPFS() error {
 	// Check if IPFS already exists
 	ipfsPath := filepath.Join(m.rubixPath, buildDir, ipfsBinName)
 	if _, err := os.Stat(ipfsPath); err == nil {
-		log.Printf("IPFS binary already exists at %s", ipfsPath)
+		logging.Infof("IPFS binary already exists at %s", ipfsPath)
 		return nil
 	}
 
-	// Construct download URL based on OS
-	var downloadURL string
-	var archiveExt string
-	osArch := "amd64"
-
-	switch runtime.GOOS {
-	case "linux":
-		downloadURL = fmt.Sprintf("https://github.com/ipfs/kubo/releases/download/%s/kubo_%s_linux-%s.tar.gz",
-			m.config.IPFSVersion, m.config.IPFSVersion, osArch)
-		archiveExt = ".tar.gz"
-	case "windows":
-		downloadURL = fmt.Sprintf("https://github.com/ipfs/kubo/releases/download/%s/kubo_%s_windows-%s.zip",
-			m.config.IPFSVersion, m.config.IPFSVersion, osArch)
-		archiveExt = ".zip"
-	case "darwin":
-		downloadURL = fmt.Sprintf("https://github.com/ipfs/kubo/releases/download/%s/kubo_%s_darwin-%s.tar.gz",
-			m.config.IPFSVersion, m.config.IPFSVersion, osArch)
-		archiveExt = ".tar.gz"
-	default:
-		return fmt.Errorf("unsupported operating system for IPFS: %s", runtime.GOOS)
+	// Construct download URL based on OS and architecture
+	downloadURL, archiveExt, err := kuboDownloadURL(m.config.IPFSVersion, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return err
 	}
+	logging.Infof("Using IPFS build for %s/%s: %s", runtime.GOOS, runtime.GOARCH, downloadURL)
 
 	// Download with retry
 	tempFile := filepath.Join(m.dataDir, fmt.Sprintf("kubo_%s%s", m.config.IPFSVersion, archiveExt))
-	if err := m.downloadWithRetry(downloadURL, tempFile, 3); err != nil {
+	if err := m.downloadWithRetry(downloadURL, tempFile, 3, m.kuboChecksum(downloadURL)); err != nil {
 		return fmt.Errorf("failed to download IPFS: %w", err)
 	}
 	defer os.Remove(tempFile)
 
 	// Extract archive
-	log.Println("Extracting IPFS binary...")
+	logging.Infof("Extracting IPFS binary...")
 	tempExtractDir := filepath.Join(m.dataDir, "kubo_temp")
 	if err := os.MkdirAll(tempExtractDir, 0o755); err != nil {
 		return fmt.Errorf("failed to create temp extraction directory: %w", err)
@@ -1253,16 +1811,16 @@ func (m *Manager) downloadIPFS() error {
 		altSrcIPFS := filepath.Join(tempExtractDir, ipfsBinName)
 		if _, err2 := os.Stat(altSrcIPFS); err2 == nil {
 			srcIPFS = altSrcIPFS
-			log.Printf("Found IPFS binary at alternative location: %s", altSrcIPFS)
+			logging.Infof("Found IPFS binary at alternative location: %s", altSrcIPFS)
 		} else {
 			// List contents to debug
-			log.Printf("IPFS binary not found at expected locations. Listing extraction directory contents:")
+			logging.Infof("IPFS binary not found at expected locations. Listing extraction directory contents:")
 			m.listDirectory(tempExtractDir, 2)
 			return fmt.Errorf("IPFS binary not found at %s or %s", srcIPFS, altSrcIPFS)
 		}
 	}
 
-	log.Printf("Moving IPFS binary from %s to %s", srcIPFS, ipfsPath)
+	logging.Infof("Moving IPFS binary from %s to %s", srcIPFS, ipfsPath)
 	if err := m.moveFile(srcIPFS, ipfsPath); err != nil {
 		return fmt.Errorf("failed to move IPFS binary: %w", err)
 	}
@@ -1274,10 +1832,46 @@ func (m *Manager) downloadIPFS() error {
 		}
 	}
 
-	log.Printf("Successfully downloaded and installed IPFS %s", m.config.IPFSVersion)
+	logging.Infof("Successfully downloaded and installed IPFS %s", m.config.IPFSVersion)
 	return nil
 }
 
+// kuboPlatforms lists the architectures kubo release archives are fetched for, per OS
+var kuboPlatforms = map[string][]string{
+	"linux":   {"amd64", "arm64"},
+	"darwin":  {"amd64", "arm64"},
+	"windows": {"amd64"},
+}
+
+// kuboDownloadURL returns the kubo release archive for a platform and its extension. An
+// unsupported OS or architecture is an error rather than a fallback to amd64, whose binary
+// would only fail later when the nodes start.
+func kuboDownloadURL(version, goos, goarch string) (string, string, error) {
+	archs, ok := kuboPlatforms[goos]
+	if !ok {
+		return "", "", fmt.Errorf("unsupported operating system for IPFS: %s", goos)
+	}
+	supported := false
+	for _, arch := range archs {
+		if arch == goarch {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return "", "", fmt.Errorf("unsupported architecture for IPFS on %s: %s (supported: %s); install kubo %s manually into the build directory",
+			goos, goarch, strings.Join(archs, ", "), version)
+	}
+
+	archiveExt := ".tar.gz"
+	if goos == "windows" {
+		archiveExt = ".zip"
+	}
+	url := fmt.Sprintf("https://github.com/ipfs/kubo/releases/download/%s/kubo_%s_%s-%s%s",
+		version, version, goos, goarch, archiveExt)
+	return url, archiveExt, nil
+}
+
 // getBuildDir returns the build directory based on OS
 func (m *Manager) getBuildDir() string {
 	switch runtime.GOOS {
@@ -1311,13 +1905,77 @@ func (m *Manager) nodeMetadataExists() bool {
 
 // saveMetadata saves node metadata to file
 func (m *Manager) saveMetadata() error {
-	data, err := json.MarshalIndent(m.nodes, "", "  ")
+	return m.writeMetadata(m.nodes)
+}
+
+// writeMetadata replaces the node metadata file with the given nodes
+func (m *Manager) writeMetadata(nodes map[string]*NodeInfo) error {
+	data, err := json.MarshalIndent(nodes, "", "  ")
 	if err != nil {
 		return err
 	}
 	return os.WriteFile(m.metadataFile, data, 0o644)
 }
 
+// SetNodeLabel sets or, with an empty label, clears a node's label and persists it in the
+// node metadata. The saved metadata is updated directly so the label survives even while
+// the node is not among the currently selected transaction nodes.
+func (m *Manager) SetNodeLabel(nodeID, label string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	metadata, err := m.loadMetadata()
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return fmt.Errorf("failed to load metadata: %w", err)
+		}
+		metadata = make(map[string]*NodeInfo)
+	}
+
+	node, active := m.nodes[nodeID]
+	saved, persisted := metadata[nodeID]
+	if !active && !persisted {
+		return fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID)
+	}
+
+	if active {
+		node.Label = label
+	}
+	if persisted {
+		saved.Label = label
+	} else {
+		metadata[nodeID] = node
+	}
+	return m.writeMetadata(metadata)
+}
+
+// countQuorumNodes returns how many quorum nodes a node set has
+func countQuorumNodes(nodes map[string]*NodeInfo) int {
+	count := 0
+	for _, nodeInfo := range nodes {
+		if nodeInfo.IsQuorum {
+			count++
+		}
+	}
+	return count
+}
+
+// syncQuorumCount adopts the quorum size of a saved fleet, so a fleet started with a custom
+// quorum count keeps it across restarts. Caller must hold m.mu.
+func (m *Manager) syncQuorumCount(metadata map[string]*NodeInfo) {
+	if count := countQuorumNodes(metadata); count > 0 && count != m.config.QuorumNodeCount {
+		logging.Infof("Using the saved fleet's quorum count of %d (configured: %d)", count, m.config.QuorumNodeCount)
+		m.config.QuorumNodeCount = count
+	}
+}
+
+// QuorumCount returns the number of quorum nodes the fleet is started with
+func (m *Manager) QuorumCount() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.config.QuorumNodeCount
+}
+
 // loadMetadata loads node metadata from file
 func (m *Manager) loadMetadata() (map[string]*NodeInfo, error) {
 	data, err := os.ReadFile(m.metadataFile)
@@ -1362,7 +2020,7 @@ func (m *Manager) CleanupAll() error {
 	// Recreate the data directory for future use
 	os.MkdirAll(m.dataDir, 0o755)
 
-	log.Println("All Rubix data cleaned up")
+	logging.Infof("All Rubix data cleaned up")
 	return nil
 }
 
@@ -1395,11 +2053,28 @@ func (m *Manager) GetNode(nodeID string) (*NodeInfo, error) {
 
 	node, exists := m.nodes[nodeID]
 	if !exists {
-		return nil, fmt.Errorf("node %s not found", nodeID)
+		return nil, fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID)
 	}
 	return node, nil
 }
 
+// NodeUptime returns how long ago the manager launched a node's current process. Unlike
+// the Started time of the selected nodes, it only resets when the process is restarted,
+// e.g. by recovery. Processes launched before the simulator started give ErrUptimeUnknown.
+func (m *Manager) NodeUptime(nodeID string) (time.Duration, error) {
+	if _, err := m.GetNode(nodeID); err != nil {
+		return 0, err
+	}
+
+	m.processStartsMu.Lock()
+	started, known := m.processStarts[nodeID]
+	m.processStartsMu.Unlock()
+	if !known {
+		return 0, fmt.Errorf("%w for %s", ErrUptimeUnknown, nodeID)
+	}
+	return time.Since(started), nil
+}
+
 // CheckNodeStatus checks the status of a specific node
 func (m *Manager) CheckNodeStatus(nodeID string) (string, error) {
 	m.mu.RLock()
@@ -1411,7 +2086,7 @@ func (m *Manager) CheckNodeStatus(nodeID string) (string, error) {
 	}
 
 	// Try to ping the node
-	client := NewClient(nodeInfo.ServerPort)
+	client := m.newClient(nodeInfo.ServerPort)
 	if err := client.Ping(); err != nil {
 		nodeInfo.Status = "failed"
 		return "failed", err
@@ -1429,7 +2104,7 @@ func (m *Manager) CheckAllNodesStatus() map[string]string {
 	statuses := make(map[string]string)
 
 	for nodeID, nodeInfo := range m.nodes {
-		client := NewClient(nodeInfo.ServerPort)
+		client := m.newClient(nodeInfo.ServerPort)
 		if err := client.Ping(); err != nil {
 			nodeInfo.Status = "failed"
 			statuses[nodeID] = "failed"
@@ -1442,6 +2117,38 @@ func (m *Manager) CheckAllNodesStatus() map[string]string {
 	return statuses
 }
 
+// CheckQuorumMajority verifies that a majority of quorum nodes respond to ping and
+// report themselves ready; without a majority consensus cannot complete and every
+// transfer would hang or fail
+func (m *Manager) CheckQuorumMajority() error {
+	statuses := m.CheckAllNodesStatus()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	total, healthy := 0, 0
+	for nodeID, nodeInfo := range m.nodes {
+		if !nodeInfo.IsQuorum {
+			continue
+		}
+		total++
+		if statuses[nodeID] != "running" {
+			continue
+		}
+		if ready, err := m.newClient(nodeInfo.ServerPort).NodeStatus(); err == nil && ready {
+			healthy++
+		}
+	}
+
+	if total == 0 {
+		return nil
+	}
+	if majority := total/2 + 1; healthy < majority {
+		return fmt.Errorf("insufficient quorum: only %d of %d quorum nodes healthy, need majority (%d)", healthy, total, majority)
+	}
+	return nil
+}
+
 // GetNodeMetrics retrieves metrics from a node
 func (m *Manager) GetNodeMetrics(nodeID string) (map[string]interface{}, error) {
 	m.mu.RLock()
@@ -1449,10 +2156,10 @@ func (m *Manager) GetNodeMetrics(nodeID string) (map[string]interface{}, error)
 
 	nodeInfo, exists := m.nodes[nodeID]
 	if !exists {
-		return nil, fmt.Errorf("node %s not found", nodeID)
+		return nil, fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID)
 	}
 
-	client := NewClient(nodeInfo.ServerPort)
+	client := m.newClient(nodeInfo.ServerPort)
 
 	metrics := make(map[string]interface{})
 	metrics["node_id"] = nodeID
@@ -1501,42 +2208,54 @@ func (m *Manager) MonitorNodes(interval time.Duration, stopCh <-chan struct{}) {
 			}
 
 			if failed > 0 {
-				log.Printf("Node Status: %d running, %d failed", running, failed)
+				logging.Infof("Node Status: %d running, %d failed", running, failed)
 
 				// Attempt to recover failed nodes
 				for nodeID, status := range statuses {
 					if status == "failed" {
-						log.Printf("Attempting to auto-recover failed node %s", nodeID)
+						logging.Infof("Attempting to auto-recover failed node %s", nodeID)
 						if err := m.RecoverNode(nodeID); err != nil {
-							log.Printf("Failed to auto-recover node %s: %v", nodeID, err)
+							logging.Errorf("Failed to auto-recover node %s: %v", nodeID, err)
 						}
 					}
 				}
 			}
 
 		case <-stopCh:
-			log.Println("Stopping node monitoring")
+			logging.Infof("Stopping node monitoring")
 			return
 		}
 	}
 }
 
-// downloadWithRetry downloads a file with retry logic
-func (m *Manager) downloadWithRetry(url string, destPath string, maxRetries int) error {
+// downloadWithRetry downloads a file with retry logic, verifying it against checksum when
+// one is given (nil skips verification)
+func (m *Manager) downloadWithRetry(url string, destPath string, maxRetries int, checksum *fileChecksum) error {
 	var lastErr error
 
 	for i := 0; i < maxRetries; i++ {
 		if i > 0 {
-			log.Printf("Retry %d/%d downloading from %s", i+1, maxRetries, url)
+			logging.Infof("Retry %d/%d downloading from %s", i+1, maxRetries, url)
 			time.Sleep(time.Duration(i*2) * time.Second) // Exponential backoff
 		}
 
 		if err := m.downloadFile(url, destPath); err != nil {
 			lastErr = err
-			log.Printf("Download attempt %d failed: %v", i+1, err)
+			logging.Infof("Download attempt %d failed: %v", i+1, err)
 			continue
 		}
 
+		// A truncated or tampered download is discarded and fetched again
+		if checksum != nil {
+			if err := checksum.verify(destPath); err != nil {
+				os.Remove(destPath)
+				lastErr = err
+				logging.Warnf("Download attempt %d failed verification: %v", i+1, err)
+				continue
+			}
+			logging.Infof("✓ Verified %s checksum of %s", checksum.algorithm, destPath)
+		}
+
 		return nil
 	}
 
@@ -1711,13 +2430,13 @@ func (m *Manager) listDirectoryRecursive(dir string, currentDepth, maxDepth int,
 
 	entries, err := os.ReadDir(dir)
 	if err != nil {
-		log.Printf("%sError reading directory %s: %v", indent, dir, err)
+		logging.Infof("%sError reading directory %s: %v", indent, dir, err)
 		return
 	}
 
 	for _, entry := range entries {
 		if entry.IsDir() {
-			log.Printf("%s[DIR] %s", indent, entry.Name())
+			logging.Infof("%s[DIR] %s", indent, entry.Name())
 			if currentDepth < maxDepth {
 				subDir := filepath.Join(dir, entry.Name())
 				m.listDirectoryRecursive(subDir, currentDepth+1, maxDepth, indent+"  ")
@@ -1728,7 +2447,7 @@ func (m *Manager) listDirectoryRecursive(dir string, currentDepth, maxDepth int,
 			if info != nil {
 				size = info.Size()
 			}
-			log.Printf("%s[FILE] %s (size: %d bytes)", indent, entry.Name(), size)
+			logging.Infof("%s[FILE] %s (size: %d bytes)", indent, entry.Name(), size)
 		}
 	}
 }
@@ -1736,14 +2455,14 @@ func (m *Manager) listDirectoryRecursive(dir string, currentDepth, maxDepth int,
 // StartTokenMonitoring starts the periodic token balance monitoring and generation
 func (m *Manager) StartTokenMonitoring() {
 	if !m.config.TokenMonitoringEnabled {
-		log.Printf("Token monitoring is disabled in configuration")
+		logging.Infof("Token monitoring is disabled in configuration")
 		return
 	}
 
-	log.Printf("Starting token monitoring service...")
-	log.Printf("  Monitoring interval: %d minutes", m.config.TokenMonitoringInterval)
-	log.Printf("  Minimum balance threshold: %.2f RBT", m.config.MinTokenBalance)
-	log.Printf("  Refill amount: %d RBT", m.config.TokenRefillAmount)
+	logging.Infof("Starting token monitoring service...")
+	logging.Infof("  Monitoring interval: %d minutes", m.config.TokenMonitoringInterval)
+	logging.Infof("  Minimum balance threshold: %.2f RBT", m.config.MinTokenBalance)
+	logging.Infof("  Refill amount: %d RBT", m.config.TokenRefillAmount)
 
 	go m.tokenMonitoringLoop()
 }
@@ -1754,15 +2473,15 @@ func (m *Manager) StopTokenMonitoring() {
 		return
 	}
 
-	log.Printf("Stopping token monitoring service...")
+	logging.Infof("Stopping token monitoring service...")
 	close(m.tokenMonitorStop)
 	
 	// Wait for the monitoring loop to finish
 	select {
 	case <-m.tokenMonitorDone:
-		log.Printf("Token monitoring service stopped")
+		logging.Infof("Token monitoring service stopped")
 	case <-time.After(30 * time.Second):
-		log.Printf("Token monitoring service stop timeout")
+		logging.Warnf("Token monitoring service stop timeout")
 	}
 }
 
@@ -1774,7 +2493,7 @@ func (m *Manager) tokenMonitoringLoop() {
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
-	log.Printf("Token monitoring loop started with %v interval", interval)
+	logging.Infof("Token monitoring loop started with %v interval", interval)
 
 	// Initial check after a short delay to let the system settle
 	time.Sleep(30 * time.Second)
@@ -1785,7 +2504,7 @@ func (m *Manager) tokenMonitoringLoop() {
 		case <-ticker.C:
 			m.checkAndRefillTokens()
 		case <-m.tokenMonitorStop:
-			log.Printf("Token monitoring loop received stop signal")
+			logging.Infof("Token monitoring loop received stop signal")
 			return
 		}
 	}
@@ -1799,7 +2518,7 @@ func (m *Manager) checkAndRefillTokens() {
 	m.simulationMu.RUnlock()
 	
 	if simActive {
-		log.Printf("🔍 Token balance check skipped - simulation is currently active")
+		logging.Infof("🔍 Token balance check skipped - simulation is currently active")
 		return
 	}
 
@@ -1811,7 +2530,7 @@ func (m *Manager) checkAndRefillTokens() {
                 nodesCopy[k] = v
             }
         } else {
-            log.Printf("WARNING: Failed to load node metadata for monitoring: %v. Falling back to in-memory nodes.", err)
+            logging.Warnf("WARNING: Failed to load node metadata for monitoring: %v. Falling back to in-memory nodes.", err)
         }
     }
     // Fallback to in-memory nodes if metadata missing or empty
@@ -1824,36 +2543,35 @@ func (m *Manager) checkAndRefillTokens() {
     }
 
 	if len(nodesCopy) == 0 {
-		log.Printf("No nodes available for token monitoring")
+		logging.Infof("No nodes available for token monitoring")
 		return
 	}
 
-	log.Printf("🔍 Checking token balances for %d nodes (threshold: %.2f RBT)", len(nodesCopy), m.config.MinTokenBalance)
+	logging.Infof("🔍 Checking token balances for %d nodes (threshold: %.2f RBT)", len(nodesCopy), m.config.MinTokenBalance)
 	
 	// Debug: Log all node IDs being checked
 	var nodeIDs []string
 	for nodeID := range nodesCopy {
 		nodeIDs = append(nodeIDs, nodeID)
 	}
-	log.Printf("🔍 DEBUG: Nodes being checked: %v", nodeIDs)
+	logging.Debugf("🔍 DEBUG: Nodes being checked: %v", nodeIDs)
 	
 	lowBalanceNodes := 0
 	totalNodesChecked := 0
 	totalRefillAttempts := 0
 	successfulRefills := 0
 
+	// Query every balance up front in parallel; refills below still run one node at a time
+	balances := m.fetchBalances(nodesCopy)
+
 	for nodeID, nodeInfo := range nodesCopy {
 		if nodeInfo.DID == "" {
 			continue
 		}
 
 		totalNodesChecked++
-		client := NewClient(nodeInfo.ServerPort)
-		
-		// Check current balance
-		balance, err := client.GetAccountBalance(nodeInfo.DID)
-		if err != nil {
-			log.Printf("  ⚠ Failed to check balance for %s: %v", nodeID, err)
+		balance, ok := balances[nodeID]
+		if !ok {
 			continue
 		}
 
@@ -1864,82 +2582,125 @@ func (m *Manager) checkAndRefillTokens() {
 
 		if balance < m.config.MinTokenBalance {
 			lowBalanceNodes++
-			log.Printf("  💰 %s (%s): %.2f RBT (below threshold, refilling...)", nodeID, nodeType, balance)
+			logging.Infof("  💰 %s (%s): %.2f RBT (below threshold, refilling...)", nodeID, nodeType, balance)
 			
 			totalRefillAttempts++
 			if m.refillNodeTokens(nodeID, nodeInfo, balance) {
 				successfulRefills++
 			}
 		} else {
-			log.Printf("  ✓ %s (%s): %.2f RBT (sufficient)", nodeID, nodeType, balance)
+			logging.Infof("  ✓ %s (%s): %.2f RBT (sufficient)", nodeID, nodeType, balance)
 		}
 	}
 
 	// Summary log
 	if lowBalanceNodes > 0 {
-		log.Printf("Token monitoring summary: %d/%d nodes below threshold, %d/%d refills successful", 
+		logging.Infof("Token monitoring summary: %d/%d nodes below threshold, %d/%d refills successful", 
 			lowBalanceNodes, totalNodesChecked, successfulRefills, totalRefillAttempts)
 	} else {
-		log.Printf("Token monitoring summary: All %d nodes have sufficient balance (>= %.2f RBT)", 
+		logging.Infof("Token monitoring summary: All %d nodes have sufficient balance (>= %.2f RBT)", 
 			totalNodesChecked, m.config.MinTokenBalance)
 	}
 }
 
 // refillNodeTokens generates tokens for a specific node
 func (m *Manager) refillNodeTokens(nodeID string, nodeInfo *NodeInfo, currentBalance float64) bool {
-	client := NewClient(nodeInfo.ServerPort)
+	client := m.newClient(nodeInfo.ServerPort)
 	
-	log.Printf("    Generating %d tokens for %s (current: %.2f RBT)...", 
+	logging.Infof("    Generating %d tokens for %s (current: %.2f RBT)...", 
 		m.config.TokenRefillAmount, nodeID, currentBalance)
 
-	maxRetries := 3
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		if attempt > 1 {
-			log.Printf("    Retry %d/%d for %s...", attempt, maxRetries, nodeID)
-			time.Sleep(time.Duration(attempt) * time.Second) // Progressive backoff
-		}
+	if err := m.generateTokensWithRetry(client, nodeID, nodeInfo.DID, m.config.TokenRefillAmount); err != nil {
+		return false
+	}
 
-		// Generate tokens
-		err := client.GenerateTestTokens(nodeInfo.DID, m.config.TokenRefillAmount, m.config.DefaultPrivKeyPassword)
-		if err != nil {
-			log.Printf("    ✗ Failed to generate tokens for %s (attempt %d): %v", nodeID, attempt, err)
-			if attempt == maxRetries {
-				return false
-			}
-			continue
-		}
+	newBalance, err := client.GetAccountBalance(nodeInfo.DID)
+	if err != nil {
+		logging.Infof("    ✓ Refilled %s (new balance unavailable: %v)", nodeID, err)
+		return true
+	}
+	logging.Infof("    ✓ Successfully refilled %s: %.2f RBT → %.2f RBT (+%.2f)", 
+		nodeID, currentBalance, newBalance, newBalance-currentBalance)
+	return true
+}
 
-		// Verify tokens were generated by checking balance again
-		time.Sleep(5 * time.Second) // Wait for async operation
-		newBalance, err := client.GetAccountBalance(nodeInfo.DID)
-		if err != nil {
-			log.Printf("    ⚠ Failed to verify new balance for %s: %v", nodeID, err)
-			if attempt == maxRetries {
-				return false
+// TopUpNode generates TokenRefillAmount test tokens for a node in a single attempt,
+// counting towards MaxTotalGeneratedTokens, and returns the number of tokens requested
+func (m *Manager) TopUpNode(nodeID string) (int, error) {
+	nodeInfo, err := m.GetNode(nodeID)
+	if err != nil {
+		return 0, err
+	}
+	if nodeInfo.DID == "" {
+		return 0, fmt.Errorf("node %s has no DID yet", nodeID)
+	}
+
+	tokens := m.config.TokenRefillAmount
+	if err := m.generateTokens(m.newClient(nodeInfo.ServerPort), nodeInfo.DID, tokens); err != nil {
+		return tokens, err
+	}
+	return tokens, nil
+}
+
+// balanceCheckWorkers bounds how many nodes are queried at once when checking balances
+const balanceCheckWorkers = 8
+
+// CheckAllBalances queries the RBT balance of every current node concurrently and returns
+// it keyed by node ID. Nodes without a DID or that don't answer are left out.
+func (m *Manager) CheckAllBalances() map[string]float64 {
+	return m.fetchBalances(m.GetNodes())
+}
+
+// fetchBalances queries the balances of the given nodes with a pool of
+// balanceCheckWorkers workers. Failed queries are logged and left out of the result.
+func (m *Manager) fetchBalances(nodes map[string]*NodeInfo) map[string]float64 {
+	type balanceResult struct {
+		nodeID  string
+		balance float64
+		err     error
+	}
+
+	jobs := make(chan string)
+	results := make(chan balanceResult)
+	var wg sync.WaitGroup
+	for i := 0; i < balanceCheckWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for nodeID := range jobs {
+				nodeInfo := nodes[nodeID]
+				balance, err := m.newClient(nodeInfo.ServerPort).GetAccountBalance(nodeInfo.DID)
+				results <- balanceResult{nodeID: nodeID, balance: balance, err: err}
+			}
+		}()
+	}
+	go func() {
+		for nodeID, nodeInfo := range nodes {
+			if nodeInfo.DID != "" {
+				jobs <- nodeID
 			}
-			continue
 		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
 
-		if newBalance > currentBalance {
-			log.Printf("    ✓ Successfully refilled %s: %.2f RBT → %.2f RBT (+%.2f)", 
-				nodeID, currentBalance, newBalance, newBalance-currentBalance)
-			return true
-		} else {
-			log.Printf("    ⚠ Balance unchanged for %s after token generation (%.2f RBT)", nodeID, newBalance)
-			if attempt == maxRetries {
-				return false
-			}
+	balances := make(map[string]float64, len(nodes))
+	for result := range results {
+		if result.err != nil {
+			logging.Warnf("  ⚠ Failed to check balance for %s: %v", result.nodeID, result.err)
+			continue
 		}
+		balances[result.nodeID] = result.balance
 	}
-
-	return false
+	return balances
 }
 
 // CheckBalancesNow performs an immediate balance check and refill if needed
 // This can be called manually for testing or on-demand token management
 func (m *Manager) CheckBalancesNow() {
 	if !m.config.TokenMonitoringEnabled {
-		log.Printf("Token monitoring is disabled, skipping balance check")
+		logging.Infof("Token monitoring is disabled, skipping balance check")
 		return
 	}
 	
@@ -1949,14 +2710,37 @@ func (m *Manager) CheckBalancesNow() {
 	m.simulationMu.RUnlock()
 	
 	if simActive {
-		log.Printf("🔍 Manual token balance check skipped - simulation is active")
+		logging.Infof("🔍 Manual token balance check skipped - simulation is active")
 		return
 	}
 	
-	log.Printf("🔍 Manual token balance check requested...")
+	logging.Infof("🔍 Manual token balance check requested...")
 	m.checkAndRefillTokens()
 }
 
+// RefillInBackground runs CheckBalancesNow in its own goroutine so callers don't wait on
+// token generation. It returns false without starting anything if a previous background
+// refill is still running.
+func (m *Manager) RefillInBackground() bool {
+	m.refillMu.Lock()
+	if m.refillRunning {
+		m.refillMu.Unlock()
+		return false
+	}
+	m.refillRunning = true
+	m.refillMu.Unlock()
+
+	go func() {
+		defer func() {
+			m.refillMu.Lock()
+			m.refillRunning = false
+			m.refillMu.Unlock()
+		}()
+		m.CheckBalancesNow()
+	}()
+	return true
+}
+
 // SetSimulationActive sets the simulation state to control token monitoring
 func (m *Manager) SetSimulationActive(active bool) {
 	m.simulationMu.Lock()
@@ -1965,9 +2749,9 @@ func (m *Manager) SetSimulationActive(active bool) {
 	if m.simulationActive != active {
 		m.simulationActive = active
 		if active {
-			log.Printf("🚫 Token monitoring paused - simulation started")
+			logging.Infof("🚫 Token monitoring paused - simulation started")
 		} else {
-			log.Printf("✅ Token monitoring resumed - simulation completed")
+			logging.Infof("✅ Token monitoring resumed - simulation completed")
 		}
 	}
 }
@@ -1982,33 +2766,34 @@ func (m *Manager) IsSimulationActive() bool {
 // AutoStartTokenMonitoring automatically starts token monitoring if nodes already exist
 func (m *Manager) AutoStartTokenMonitoring() {
 	if !m.config.TokenMonitoringEnabled {
-		log.Printf("Token monitoring is disabled in configuration")
+		logging.Infof("Token monitoring is disabled in configuration")
 		return
 	}
 
 	// Check if nodes already exist (from previous startup)
 	if m.nodeMetadataExists() {
-		log.Printf("Existing node metadata found, loading nodes...")
+		logging.Infof("Existing node metadata found, loading nodes...")
 		metadata, err := m.loadMetadata()
 		if err != nil {
-			log.Printf("Failed to load existing metadata: %v", err)
+			logging.Errorf("Failed to load existing metadata: %v", err)
 			return
 		}
 
 		// Load nodes into manager
 		m.mu.Lock()
 		m.nodes = metadata
+		m.syncQuorumCount(metadata)
 		m.mu.Unlock()
 
 		nodeCount := len(metadata)
 		if nodeCount > 0 {
-			log.Printf("Loaded %d existing nodes from metadata", nodeCount)
-			log.Printf("Auto-starting token monitoring service...")
+			logging.Infof("Loaded %d existing nodes from metadata", nodeCount)
+			logging.Infof("Auto-starting token monitoring service...")
 			m.StartTokenMonitoring()
 		} else {
-			log.Printf("No existing nodes found in metadata")
+			logging.Infof("No existing nodes found in metadata")
 		}
 	} else {
-		log.Printf("No existing node metadata found - token monitoring will start when nodes are created")
+		logging.Infof("No existing node metadata found - token monitoring will start when nodes are created")
 	}
 }
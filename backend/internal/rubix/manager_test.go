@@ -0,0 +1,218 @@
+package rubix
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rubix-simulator/backend/config"
+)
+
+func TestKuboDownloadURL(t *testing.T) {
+	const version = "v0.29.0"
+	const base = "https://github.com/ipfs/kubo/releases/download/v0.29.0/kubo_v0.29.0_"
+
+	tests := []struct {
+		goos, goarch string
+		wantURL      string
+		wantExt      string
+		wantErr      string
+	}{
+		{goos: "linux", goarch: "amd64", wantURL: base + "linux-amd64.tar.gz", wantExt: ".tar.gz"},
+		{goos: "linux", goarch: "arm64", wantURL: base + "linux-arm64.tar.gz", wantExt: ".tar.gz"},
+		{goos: "darwin", goarch: "amd64", wantURL: base + "darwin-amd64.tar.gz", wantExt: ".tar.gz"},
+		{goos: "darwin", goarch: "arm64", wantURL: base + "darwin-arm64.tar.gz", wantExt: ".tar.gz"},
+		{goos: "windows", goarch: "amd64", wantURL: base + "windows-amd64.zip", wantExt: ".zip"},
+		{goos: "windows", goarch: "arm64", wantErr: "unsupported architecture for IPFS on windows: arm64"},
+		{goos: "linux", goarch: "386", wantErr: "unsupported architecture for IPFS on linux: 386"},
+		{goos: "freebsd", goarch: "amd64", wantErr: "unsupported operating system for IPFS: freebsd"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.goos+"/"+tt.goarch, func(t *testing.T) {
+			url, ext, err := kuboDownloadURL(version, tt.goos, tt.goarch)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want it to contain %q", err, tt.wantErr)
+				}
+				if url != "" || ext != "" {
+					t.Errorf("got url %q, ext %q alongside the error", url, ext)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if url != tt.wantURL {
+				t.Errorf("url = %q, want %q", url, tt.wantURL)
+			}
+			if ext != tt.wantExt {
+				t.Errorf("ext = %q, want %q", ext, tt.wantExt)
+			}
+		})
+	}
+}
+
+func TestEnsureDID(t *testing.T) {
+	const otherDID = "bafybmiotherdid"
+	const childDID = "bafybmichilddid"
+	listing := func(entries ...DIDEntry) TestResponse {
+		return TestResponse{Body: map[string]interface{}{"status": true, "account_info": entries}}
+	}
+	peerID := TestResponse{Body: BasicResponse{Status: true, Message: TestPeerID}}
+
+	tests := []struct {
+		name       string
+		known      string
+		dids       TestResponse
+		peerID     TestResponse
+		wantDID    string
+		wantReused bool
+		wantErr    bool
+	}{
+		{name: "no DIDs creates one", dids: listing(), wantDID: TestDID},
+		{name: "child DIDs only creates one", dids: listing(DIDEntry{childDID, 3}), wantDID: TestDID},
+		{name: "lite DID reused", dids: listing(DIDEntry{childDID, 3}, DIDEntry{otherDID, 4}), peerID: peerID, wantDID: otherDID, wantReused: true},
+		{name: "recorded DID wins", known: otherDID, dids: listing(DIDEntry{TestDID, 4}, DIDEntry{otherDID, 4}), peerID: peerID, wantDID: otherDID, wantReused: true},
+		{name: "several lite DIDs", dids: listing(DIDEntry{TestDID, 4}, DIDEntry{otherDID, 4}), peerID: peerID, wantErr: true},
+		{name: "listing fails", dids: TestResponse{StatusCode: http.StatusInternalServerError, Body: "boom"}, wantErr: true},
+		{name: "peer ID fails", dids: listing(DIDEntry{otherDID, 4}), peerID: TestResponse{Body: BasicResponse{Status: false, Message: "no peer"}}, wantErr: true},
+		{name: "empty peer ID", dids: listing(DIDEntry{otherDID, 4}), peerID: TestResponse{Body: BasicResponse{Status: true}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := NewTestServer()
+			defer ts.Close()
+			ts.SetResponse("/api/getalldid", tt.dids)
+			if tt.peerID.Body != nil {
+				ts.SetResponse("/api/get-peer-id", tt.peerID)
+			}
+			m := &Manager{config: config.DefaultRubixConfig()}
+
+			did, _, reused, err := m.ensureDID(ts.Client(), "node2", tt.known)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got DID %q, want an error", did)
+				}
+				if created := len(ts.Requests("/api/createdid")); created != 0 {
+					t.Errorf("created %d DID(s) despite the error", created)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ensureDID: %v", err)
+			}
+			if did != tt.wantDID || reused != tt.wantReused {
+				t.Errorf("got DID %q (reused %v), want %q (reused %v)", did, reused, tt.wantDID, tt.wantReused)
+			}
+		})
+	}
+}
+
+func TestGenerateTokensNeedsStartBalance(t *testing.T) {
+	ts := NewTestServer()
+	defer ts.Close()
+	ts.SetResponse("/api/get-account-info", TestResponse{StatusCode: http.StatusInternalServerError, Body: "boom"})
+	m := &Manager{config: config.DefaultRubixConfig()}
+
+	if err := m.generateTokensWithRetry(ts.Client(), "node2", TestDID, 100); err == nil {
+		t.Fatal("generated tokens without reading the start balance")
+	}
+	if sent := len(ts.Requests("/api/generate-test-token")); sent != 0 {
+		t.Errorf("sent %d token generation request(s), want none", sent)
+	}
+}
+
+func TestRegisterDIDsReleasesLock(t *testing.T) {
+	t.Parallel() // Registration waits 5s for the DID to propagate
+	ts := NewTestServer()
+	defer ts.Close()
+	ts.SetResponse("/api/register-did", TestResponse{Body: passwordNeeded(), Delay: 500 * time.Millisecond})
+
+	m := &Manager{
+		config:       config.DefaultRubixConfig(),
+		metadataFile: filepath.Join(t.TempDir(), "node_metadata.json"),
+		nodes: map[string]*NodeInfo{
+			"node2": {ID: "node2", ServerPort: ts.Port(), DID: TestDID, Status: "running"},
+			"node3": {ID: "node3", ServerPort: ts.Port(), DID: "bafybmistopped", Status: "stopped"},
+		},
+	}
+
+	type result struct {
+		registered int
+		err        error
+	}
+	done := make(chan result, 1)
+	go func() {
+		registered, err := m.RegisterDIDs(false)
+		done <- result{registered, err}
+	}()
+
+	// The registration is still waiting on the node; the manager must stay usable
+	time.Sleep(100 * time.Millisecond)
+	locked := make(chan struct{})
+	go func() {
+		m.mu.Lock()
+		m.mu.Unlock()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+	case <-time.After(200 * time.Millisecond):
+		t.Fatal("RegisterDIDs holds the manager lock while registering")
+	}
+
+	res := <-done
+	if res.err != nil || res.registered != 1 {
+		t.Fatalf("RegisterDIDs = %d, %v; want 1, nil", res.registered, res.err)
+	}
+	if !m.nodes["node2"].DIDRegistered {
+		t.Error("node2 is not marked registered")
+	}
+	if m.nodes["node3"].DIDRegistered {
+		t.Error("stopped node3 was marked registered")
+	}
+}
+
+func TestGetNodeLogs(t *testing.T) {
+	m := &Manager{
+		dataDir: t.TempDir(),
+		nodes:   map[string]*NodeInfo{"node2": {ID: "node2"}},
+	}
+
+	if _, err := m.GetNodeLogs("node9", 10); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("unknown node: err = %v, want ErrNodeNotFound", err)
+	}
+	if _, err := m.GetNodeLogs("node2", 10); !errors.Is(err, ErrNodeLogNotFound) {
+		t.Errorf("node without a log: err = %v, want ErrNodeLogNotFound", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(m.nodeLogPath("node2")), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(m.nodeLogPath("node2"), []byte("one\ntwo\nthree\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	lines, err := m.GetNodeLogs("node2", 2)
+	if err != nil || strings.Join(lines, ",") != "two,three" {
+		t.Errorf("GetNodeLogs = %q, %v; want [two three]", lines, err)
+	}
+}
+
+func TestNodeUptime(t *testing.T) {
+	m := &Manager{nodes: map[string]*NodeInfo{"node2": {ID: "node2"}, "node3": {ID: "node3"}}}
+	m.processStarts = map[string]time.Time{"node2": time.Now().Add(-time.Minute)}
+
+	if uptime, err := m.NodeUptime("node2"); err != nil || uptime < time.Minute || uptime > 2*time.Minute {
+		t.Errorf("NodeUptime(node2) = %v, %v; want about a minute", uptime, err)
+	}
+	if _, err := m.NodeUptime("node3"); !errors.Is(err, ErrUptimeUnknown) {
+		t.Errorf("NodeUptime(node3) err = %v, want ErrUptimeUnknown", err)
+	}
+	if _, err := m.NodeUptime("node9"); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("NodeUptime(node9) err = %v, want ErrNodeNotFound", err)
+	}
+}
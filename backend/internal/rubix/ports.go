@@ -0,0 +1,180 @@
+package rubix
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rubix-simulator/backend/internal/logging"
+)
+
+// portReleaseGrace is how long a port taken by a node that was just stopped may take to
+// be released before it counts as in use
+const portReleaseGrace = 5 * time.Second
+
+// ErrPortInUse is returned when a port a node is about to use is already taken, typically
+// by a node process left over from an earlier run
+var ErrPortInUse = errors.New("already in use")
+
+// checkPortFree reports an ErrPortInUse error naming the port and its use when it can't be
+// bound on all interfaces
+func checkPortFree(port int, use string) error {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return fmt.Errorf("port %d (%s) %w: %v", port, use, ErrPortInUse, err)
+	}
+	return listener.Close()
+}
+
+// waitPortFree retries checkPortFree until the port is free or grace has passed
+func waitPortFree(port int, use string, grace time.Duration) error {
+	deadline := time.Now().Add(grace)
+	for {
+		err := checkPortFree(port, use)
+		if err == nil || time.Now().After(deadline) {
+			return err
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+// checkNodePortsFree makes sure nothing is listening on the server and gRPC ports of the
+// node at index before it is launched, allowing portReleaseGrace for a stopping process
+func (m *Manager) checkNodePortsFree(nodeID string, index int) error {
+	if err := waitPortFree(m.config.BaseServerPort+index, nodeID+" server port", portReleaseGrace); err != nil {
+		return err
+	}
+	return waitPortFree(m.config.BaseGrpcPort+index, nodeID+" gRPC port", portReleaseGrace)
+}
+
+// KillNodeByPort terminates the process listening on port, together with the processes it
+// started such as the node's IPFS daemon. It is the last resort for nodes that outlived
+// their tmux session or console window; a free port is not an error.
+func (m *Manager) KillNodeByPort(port int) error {
+	pids, err := listeningPIDs(port)
+	if err != nil {
+		return fmt.Errorf("failed to find the process listening on port %d: %w", port, err)
+	}
+
+	for _, pid := range pids {
+		if pid == os.Getpid() {
+			continue
+		}
+		if err := killProcessTree(pid); err != nil {
+			return fmt.Errorf("failed to kill process %d listening on port %d: %w", pid, port, err)
+		}
+		logging.Infof("Killed process %d listening on port %d", pid, port)
+	}
+	return nil
+}
+
+// killLeftoverNode kills whatever still listens on a stopped node's ports once they have
+// had portReleaseGrace to be released
+func (m *Manager) killLeftoverNode(nodeID string, nodeInfo *NodeInfo) {
+	for _, port := range []int{nodeInfo.ServerPort, nodeInfo.GrpcPort} {
+		if port == 0 || waitPortFree(port, nodeID, portReleaseGrace) == nil {
+			continue
+		}
+		logging.Warnf("⚠ Port %d of %s is still in use after stopping it, killing the process", port, nodeID)
+		if err := m.KillNodeByPort(port); err != nil {
+			logging.Warnf("Warning: %v", err)
+		}
+	}
+}
+
+// ssPIDPattern matches the process IDs in the users column of `ss -p` output
+var ssPIDPattern = regexp.MustCompile(`pid=(\d+)`)
+
+// listeningPIDs returns the IDs of the processes listening on a TCP port, using netstat on
+// Windows and lsof elsewhere, falling back to ss on Linux systems without lsof
+func listeningPIDs(port int) ([]int, error) {
+	if runtime.GOOS == "windows" {
+		output, err := exec.Command("netstat", "-ano", "-p", "TCP").Output()
+		if err != nil {
+			return nil, err
+		}
+		var pids []int
+		suffix := ":" + strconv.Itoa(port)
+		scanner := bufio.NewScanner(bytes.NewReader(output))
+		for scanner.Scan() {
+			// Proto, Local Address, Foreign Address, State, PID
+			fields := strings.Fields(scanner.Text())
+			if len(fields) != 5 || fields[3] != "LISTENING" || !strings.HasSuffix(fields[1], suffix) {
+				continue
+			}
+			if pid, err := strconv.Atoi(fields[4]); err == nil {
+				pids = appendUnique(pids, pid)
+			}
+		}
+		return pids, nil
+	}
+
+	output, err := exec.Command("lsof", "-nP", "-t", fmt.Sprintf("-iTCP:%d", port), "-sTCP:LISTEN").Output()
+	if errors.Is(err, exec.ErrNotFound) && runtime.GOOS == "linux" {
+		output, err = exec.Command("ss", "-ltnpH", fmt.Sprintf("sport = :%d", port)).Output()
+		if err != nil {
+			return nil, err
+		}
+		var pids []int
+		for _, match := range ssPIDPattern.FindAllStringSubmatch(string(output), -1) {
+			if pid, err := strconv.Atoi(match[1]); err == nil {
+				pids = appendUnique(pids, pid)
+			}
+		}
+		return pids, nil
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(bytes.TrimSpace(output)) == 0 {
+		// lsof exits non-zero when nothing matches
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var pids []int
+	for _, field := range strings.Fields(string(output)) {
+		if pid, err := strconv.Atoi(field); err == nil {
+			pids = appendUnique(pids, pid)
+		}
+	}
+	return pids, nil
+}
+
+// killProcessTree force-kills a process and its child processes
+func killProcessTree(pid int) error {
+	if runtime.GOOS == "windows" {
+		return exec.Command("taskkill", "/F", "/T", "/PID", strconv.Itoa(pid)).Run()
+	}
+
+	// Children first, so they are not left running under init; pkill exits non-zero
+	// when there are none
+	_ = exec.Command("pkill", "-KILL", "-P", strconv.Itoa(pid)).Run()
+
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	if err := process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return err
+	}
+	return nil
+}
+
+func appendUnique(values []int, value int) []int {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
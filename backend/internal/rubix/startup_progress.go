@@ -0,0 +1,142 @@
+package rubix
+
+import (
+	"time"
+)
+
+// freshStartupPhases are the phases of starting a fleet from scratch, in order
+var freshStartupPhases = []string{
+	"Platform Setup",
+	"Starting Nodes",
+	"DID Registration",
+	"Quorum Configuration",
+	"Quorum Setup",
+	"Token Generation",
+	"Finalization",
+}
+
+// addNodesPhases are the phases of adding transaction nodes to a running fleet, in order
+var addNodesPhases = []string{
+	"Starting Additional Nodes",
+	"DID Registration",
+	"Quorum Configuration",
+	"Token Generation",
+	"Finalization",
+}
+
+// StartupProgress describes the fleet start in progress, or the last one to finish
+type StartupProgress struct {
+	Active         bool       `json:"active"`
+	Phase          string     `json:"phase,omitempty"`
+	PhaseIndex     int        `json:"phaseIndex"` // 1-based position of Phase in Phases
+	Phases         []string   `json:"phases"`
+	NodesTotal     int        `json:"nodesTotal"`     // Nodes the current phase works through
+	NodesCompleted []string   `json:"nodesCompleted"` // Nodes the current phase has finished, in order
+	Percent        float64    `json:"percent"`        // Progress across all phases
+	StartedAt      *time.Time `json:"startedAt,omitempty"`
+	FinishedAt     *time.Time `json:"finishedAt,omitempty"`
+	Error          string     `json:"error,omitempty"`
+}
+
+// GetStartupProgress returns a snapshot of the current or last fleet start. It does not
+// take m.mu, so it can be polled while StartNodes holds it.
+func (m *Manager) GetStartupProgress() StartupProgress {
+	m.startupMu.Lock()
+	defer m.startupMu.Unlock()
+
+	progress := m.startup
+	progress.Phases = append([]string{}, m.startup.Phases...)
+	progress.NodesCompleted = append([]string{}, m.startup.NodesCompleted...)
+	return progress
+}
+
+// beginStartup resets the progress for a new fleet start that runs through phases
+func (m *Manager) beginStartup(phases ...string) {
+	m.startupMu.Lock()
+	defer m.startupMu.Unlock()
+
+	now := time.Now()
+	m.startup = StartupProgress{
+		Active:    true,
+		Phases:    append([]string{}, phases...),
+		StartedAt: &now,
+	}
+}
+
+// planStartupPhases appends phases the running start has decided to go through
+func (m *Manager) planStartupPhases(phases ...string) {
+	m.startupMu.Lock()
+	defer m.startupMu.Unlock()
+
+	if m.startup.Active {
+		m.startup.Phases = append(m.startup.Phases, phases...)
+		m.updateStartupPercent()
+	}
+}
+
+// startupPhase moves the progress to the named phase, which works through nodes nodes.
+// The phase is matched after the current one, so names may repeat across a start.
+func (m *Manager) startupPhase(name string, nodes int) {
+	m.startupMu.Lock()
+	defer m.startupMu.Unlock()
+
+	if !m.startup.Active {
+		return
+	}
+	index := 0
+	for i := m.startup.PhaseIndex; i < len(m.startup.Phases); i++ {
+		if m.startup.Phases[i] == name {
+			index = i + 1
+			break
+		}
+	}
+	if index == 0 {
+		m.startup.Phases = append(m.startup.Phases, name)
+		index = len(m.startup.Phases)
+	}
+	m.startup.Phase = name
+	m.startup.PhaseIndex = index
+	m.startup.NodesTotal = nodes
+	m.startup.NodesCompleted = nil
+	m.updateStartupPercent()
+}
+
+// startupNodeDone records that the current phase has finished with a node
+func (m *Manager) startupNodeDone(nodeID string) {
+	m.startupMu.Lock()
+	defer m.startupMu.Unlock()
+
+	if !m.startup.Active {
+		return
+	}
+	m.startup.NodesCompleted = append(m.startup.NodesCompleted, nodeID)
+	m.updateStartupPercent()
+}
+
+// finishStartup ends the progress of a fleet start, recording err if it failed
+func (m *Manager) finishStartup(err error) {
+	m.startupMu.Lock()
+	defer m.startupMu.Unlock()
+
+	now := time.Now()
+	m.startup.Active = false
+	m.startup.FinishedAt = &now
+	if err != nil {
+		m.startup.Error = err.Error()
+		return
+	}
+	m.startup.Percent = 100
+}
+
+// updateStartupPercent recomputes the overall percentage. Caller must hold m.startupMu.
+func (m *Manager) updateStartupPercent() {
+	if len(m.startup.Phases) == 0 || m.startup.PhaseIndex == 0 {
+		m.startup.Percent = 0
+		return
+	}
+	done := float64(m.startup.PhaseIndex - 1)
+	if m.startup.NodesTotal > 0 {
+		done += float64(len(m.startup.NodesCompleted)) / float64(m.startup.NodesTotal)
+	}
+	m.startup.Percent = done / float64(len(m.startup.Phases)) * 100
+}
@@ -0,0 +1,162 @@
+package rubix
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Values the TestServer's default responses carry
+const (
+	TestDID           = "bafybmitestdid0000000000000000000000000000000000000000000"
+	TestPeerID        = "12D3KooWTestPeer000000000000000000000000000000000"
+	TestRequestID     = "test-signature-request"
+	TestTransactionID = "08765414814e03e9ffb71f3cedda61c7246f40cf1a48b2d5f6cdfdfc359b13e3"
+	TestBalance       = 100.0
+)
+
+// TestResponse is what a TestServer endpoint answers with
+type TestResponse struct {
+	StatusCode int           // HTTP status code (default 200)
+	Body       interface{}   // Encoded as JSON; a string or []byte is sent as-is
+	Delay      time.Duration // Wait before answering, e.g. to exercise timeouts
+}
+
+// TestRequest is a request a TestServer received
+type TestRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   []byte
+}
+
+// TestServer is a fake Rubix node API for exercising Client without building the
+// platform. It answers /api/node-status, /api/createdid, /api/getalldid (no DIDs),
+// /api/register-did, /api/signature-response, /api/initiate-rbt-transfer and
+// /api/get-account-info with a successful response by default; SetResponse changes what
+// any path answers. Other paths get 404.
+type TestServer struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	responses map[string]TestResponse
+	requests  []TestRequest
+}
+
+// NewTestServer starts a TestServer; Close it when done
+func NewTestServer() *TestServer {
+	ts := &TestServer{responses: map[string]TestResponse{
+		"/api/node-status": {Body: BasicResponse{
+			Status:  true,
+			Message: "Node is running",
+		}},
+		"/api/createdid": {Body: map[string]interface{}{
+			"status":  true,
+			"message": "DID created successfully",
+			"result":  map[string]string{"did": TestDID, "peerID": TestPeerID},
+		}},
+		"/api/getalldid": {Body: map[string]interface{}{
+			"status":       true,
+			"message":      "Got all DIDs",
+			"account_info": []map[string]string{},
+		}},
+		"/api/register-did":          {Body: passwordNeeded()},
+		"/api/initiate-rbt-transfer": {Body: passwordNeeded()},
+		"/api/signature-response": {Body: BasicResponse{
+			Status:  true,
+			Message: "Transfer finished successfully in 1.5s with trnxid " + TestTransactionID,
+		}},
+		"/api/get-account-info": {Body: map[string]interface{}{
+			"status":  true,
+			"message": "Got account info successfully",
+			"account_info": []map[string]interface{}{
+				{"did": TestDID, "did_type": 4, "rbt_amount": TestBalance},
+			},
+		}},
+	}}
+	ts.Server = httptest.NewServer(http.HandlerFunc(ts.serve))
+	return ts
+}
+
+// passwordNeeded is the node's answer to a request that must be signed through
+// /api/signature-response
+func passwordNeeded() map[string]interface{} {
+	return map[string]interface{}{
+		"status":  true,
+		"message": "Password needed",
+		"result":  map[string]interface{}{"id": TestRequestID, "mode": 4},
+	}
+}
+
+// SetResponse makes path answer with resp from now on
+func (ts *TestServer) SetResponse(path string, resp TestResponse) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.responses[path] = resp
+}
+
+// Requests returns the requests received for path, oldest first
+func (ts *TestServer) Requests(path string) []TestRequest {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	var requests []TestRequest
+	for _, r := range ts.requests {
+		if r.Path == path {
+			requests = append(requests, r)
+		}
+	}
+	return requests
+}
+
+// Port returns the port the server listens on
+func (ts *TestServer) Port() int {
+	u, _ := url.Parse(ts.URL)
+	port, _ := strconv.Atoi(u.Port())
+	return port
+}
+
+// Client returns a client for the server, with request logging turned down
+func (ts *TestServer) Client() *Client {
+	c := NewClient(ts.Port())
+	c.SetVerbose(false)
+	return c
+}
+
+func (ts *TestServer) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	ts.mu.Lock()
+	ts.requests = append(ts.requests, TestRequest{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query(), Body: body})
+	resp, ok := ts.responses[r.URL.Path]
+	ts.mu.Unlock()
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if resp.Delay > 0 {
+		time.Sleep(resp.Delay)
+	}
+
+	var data []byte
+	switch b := resp.Body.(type) {
+	case string:
+		data = []byte(b)
+	case []byte:
+		data = b
+	default:
+		data, _ = json.Marshal(b)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if resp.StatusCode != 0 {
+		w.WriteHeader(resp.StatusCode)
+	}
+	w.Write(data)
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/rubix-simulator/backend/internal/logging"
+)
+
+const (
+	callbackAttempts = 3                // Deliveries tried before giving up
+	callbackTimeout  = 10 * time.Second // Per delivery attempt
+)
+
+// validateCallbackURL checks that a simulation's callback URL is an absolute http(s) URL
+func validateCallbackURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("callbackUrl must be an absolute http or https URL")
+	}
+	return nil
+}
+
+// deliverCallback POSTs the finished report of simulationID to the callback URL it was
+// started with, retrying failed deliveries with a growing delay. Only the URL's host is
+// logged, as webhook URLs often embed a token. Cancelling ctx abandons the delivery, so a
+// server shutdown isn't held up by a slow or unreachable receiver.
+func (ss *SimulationService) deliverCallback(ctx context.Context, simulationID string) {
+	report, err := ss.GetReport(simulationID)
+	if err != nil || report.Config.CallbackURL == "" {
+		return
+	}
+	callbackURL := report.Config.CallbackURL
+	host := callbackURL
+	if u, err := url.Parse(callbackURL); err == nil {
+		host = u.Host
+	}
+
+	body, err := json.Marshal(report)
+	if err != nil {
+		logging.Errorf("Failed to encode simulation %s report for its callback: %v", simulationID, err)
+		return
+	}
+
+	client := &http.Client{Timeout: callbackTimeout}
+	for attempt := 1; ; attempt++ {
+		err := postCallback(ctx, client, callbackURL, simulationID, body)
+		if err == nil {
+			logging.Infof("✓ Delivered simulation %s report to callback on %s", simulationID, host)
+			return
+		}
+		if ctx.Err() != nil {
+			logging.Warnf("Warning: callback delivery for simulation %s to %s abandoned: %v",
+				simulationID, host, ctx.Err())
+			return
+		}
+		if attempt == callbackAttempts {
+			logging.Errorf("✗ Failed to deliver simulation %s report to callback on %s after %d attempts: %v",
+				simulationID, host, attempt, err)
+			return
+		}
+		logging.Warnf("Warning: callback delivery for simulation %s failed (attempt %d/%d): %v",
+			simulationID, attempt, callbackAttempts, err)
+		select {
+		case <-time.After(time.Duration(attempt) * time.Second):
+		case <-ctx.Done():
+			logging.Warnf("Warning: callback delivery for simulation %s to %s abandoned: %v",
+				simulationID, host, ctx.Err())
+			return
+		}
+	}
+}
+
+// postCallback makes one delivery; any non-2xx response counts as a failure
+func postCallback(ctx context.Context, client *http.Client, callbackURL, simulationID string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Simulation-Id", simulationID)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		// The error repeats the full URL; keep it out of the logs
+		if urlErr, ok := err.(*url.Error); ok {
+			return urlErr.Err
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("callback responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rubix-simulator/backend/internal/models"
+)
+
+// ErrSimulationUnfinished is returned when an operation needs a finished simulation
+var ErrSimulationUnfinished = errors.New("has not finished")
+
+// CompareSimulations returns the change in key metrics from simulation a to simulation b.
+// Both must exist and be finished.
+func (ss *SimulationService) CompareSimulations(a, b string) (*models.SimulationComparison, error) {
+	reportA, err := ss.finishedReport(a)
+	if err != nil {
+		return nil, err
+	}
+	reportB, err := ss.finishedReport(b)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := &models.SimulationComparison{
+		A:                 a,
+		B:                 b,
+		SuccessRate:       metricDelta(successRate(reportA), successRate(reportB)),
+		AverageLatency:    metricDelta(reportA.AverageTransactionTime, reportB.AverageTransactionTime),
+		P95Latency:        metricDelta(float64(reportA.P95TransactionTime.Milliseconds()), float64(reportB.P95TransactionTime.Milliseconds())),
+		TPS:               metricDelta(transactionsPerSecond(reportA), transactionsPerSecond(reportB)),
+		FailureCategories: make(map[string]models.MetricDelta),
+	}
+
+	// A category only one run hit counts as zero failures in the other
+	failuresA := failureCounts(reportA)
+	failuresB := failureCounts(reportB)
+	for category := range failuresB {
+		if _, ok := failuresA[category]; !ok {
+			failuresA[category] = 0
+		}
+	}
+	for category, count := range failuresA {
+		comparison.FailureCategories[category] = metricDelta(float64(count), float64(failuresB[category]))
+	}
+	return comparison, nil
+}
+
+// finishedReport returns the report of a simulation that has finished
+func (ss *SimulationService) finishedReport(simulationID string) (*models.SimulationReport, error) {
+	report, err := ss.GetReport(simulationID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrSimulationNotFound, simulationID)
+	}
+	if !report.IsFinished {
+		return nil, fmt.Errorf("simulation %s %w", simulationID, ErrSimulationUnfinished)
+	}
+	return report, nil
+}
+
+// metricDelta compares a metric's value in two simulations
+func metricDelta(a, b float64) models.MetricDelta {
+	delta := models.MetricDelta{A: a, B: b, Delta: b - a}
+	if a != 0 {
+		percent := delta.Delta / a * 100
+		delta.PercentChange = &percent
+	}
+	return delta
+}
+
+// successRate returns the percentage of a report's completed transactions that succeeded
+func successRate(report *models.SimulationReport) float64 {
+	completed := report.SuccessCount + report.FailureCount
+	if completed == 0 {
+		return 0
+	}
+	return float64(report.SuccessCount) / float64(completed) * 100
+}
+
+// transactionsPerSecond returns a report's completed transactions per second of run time
+func transactionsPerSecond(report *models.SimulationReport) float64 {
+	if report.TotalTime <= 0 {
+		return 0
+	}
+	return float64(report.SuccessCount+report.FailureCount) / report.TotalTime.Seconds()
+}
+
+// failureCounts returns a report's failed transactions per failure category
+func failureCounts(report *models.SimulationReport) map[string]int {
+	counts := make(map[string]int, len(report.FailureBreakdown))
+	for _, category := range report.FailureBreakdown {
+		counts[category.Category] = category.Count
+	}
+	return counts
+}
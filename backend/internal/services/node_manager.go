@@ -2,11 +2,13 @@ package services
 
 import (
 	"fmt"
-	"log"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/rubix-simulator/backend/internal/config"
+	"github.com/rubix-simulator/backend/internal/logging"
+	"github.com/rubix-simulator/backend/internal/metrics"
 	"github.com/rubix-simulator/backend/internal/models"
 	"github.com/rubix-simulator/backend/internal/rubix"
 )
@@ -19,30 +21,145 @@ type NodeManager struct {
 	basePort     int
 	usePython    bool
 	rubixManager *rubix.Manager
-	quorumNodes  int  // Fixed number of quorum nodes
+	external     bool // Nodes are managed outside the simulator; never start, stop or restart them
 }
 
 func NewNodeManager(cfg *config.Config) *NodeManager {
-	return &NodeManager{
+	nm := &NodeManager{
 		config:       cfg,
 		nodes:        make(map[string]*models.Node),
 		busyNodes:    make(map[string]bool), // New field
 		basePort:     20000,
 		usePython:    false, // Use Go implementation by default
-		rubixManager: rubix.NewManager(),
-		quorumNodes:  7,  // Fixed 7 quorum nodes as per requirement
+		rubixManager: rubix.NewManagerWithConfig(cfg.Rubix),
 	}
+
+	if len(cfg.Rubix.ExternalNodes) > 0 {
+		externalNodes := make([]models.Node, len(cfg.Rubix.ExternalNodes))
+		for i, n := range cfg.Rubix.ExternalNodes {
+			externalNodes[i] = models.Node{ID: n.ID, Port: n.Port, DID: n.DID}
+		}
+		if err := nm.UseExternalNodes(externalNodes); err != nil {
+			logging.Warnf("Warning: ignoring configured external nodes: %v", err)
+		}
+	}
+
+	return nm
+}
+
+// UseExternalNodes switches the manager to an externally-managed fleet. The given
+// nodes are used as transaction nodes as-is; nothing is started or stopped locally.
+func (nm *NodeManager) UseExternalNodes(externalNodes []models.Node) error {
+	nodes, err := externalFleet(externalNodes)
+	if err != nil {
+		return err
+	}
+	nm.useExternalFleet(nodes)
+	return nil
+}
+
+// externalFleet checks a list of externally-managed nodes and returns them sorted by ID,
+// without switching the manager to them
+func externalFleet(externalNodes []models.Node) ([]*models.Node, error) {
+	if len(externalNodes) < 2 {
+		return nil, fmt.Errorf("at least 2 external nodes are required, got %d", len(externalNodes))
+	}
+
+	nodes := make(map[string]*models.Node, len(externalNodes))
+	for i, n := range externalNodes {
+		if n.ID == "" {
+			n.ID = fmt.Sprintf("external%d", i+1)
+		}
+		if n.Port <= 0 {
+			return nil, fmt.Errorf("external node %s has no port", n.ID)
+		}
+		if n.DID == "" {
+			return nil, fmt.Errorf("external node %s has no DID", n.ID)
+		}
+		if _, exists := nodes[n.ID]; exists {
+			return nil, fmt.Errorf("duplicate external node ID %s", n.ID)
+		}
+		nodes[n.ID] = &models.Node{
+			ID:      n.ID,
+			Port:    n.Port,
+			DID:     n.DID,
+			Status:  "running",
+			Started: time.Now(),
+		}
+	}
+
+	return sortedNodes(nodes), nil
+}
+
+// useExternalFleet switches the manager to nodes checked by externalFleet
+func (nm *NodeManager) useExternalFleet(fleet []*models.Node) {
+	nodes := make(map[string]*models.Node, len(fleet))
+	for _, node := range fleet {
+		nodes[node.ID] = node
+	}
+
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+	defer nm.updateNodeMetrics()
+	nm.nodes = nodes
+	nm.busyNodes = make(map[string]bool)
+	nm.external = true
+
+	logging.Infof("Using externally-managed fleet of %d nodes", len(nodes))
+}
+
+// sortedNodes returns the nodes of a fleet ordered by ID
+func sortedNodes(nodes map[string]*models.Node) []*models.Node {
+	sorted := make([]*models.Node, 0, len(nodes))
+	for _, node := range nodes {
+		sorted = append(sorted, node)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	return sorted
+}
+
+// IsExternal reports whether the nodes are managed outside the simulator
+func (nm *NodeManager) IsExternal() bool {
+	nm.mu.RLock()
+	defer nm.mu.RUnlock()
+	return nm.external
+}
+
+// ExternalNodeCount returns the number of externally-managed nodes (0 when not in external mode)
+func (nm *NodeManager) ExternalNodeCount() int {
+	nm.mu.RLock()
+	defer nm.mu.RUnlock()
+	if !nm.external {
+		return 0
+	}
+	return len(nm.nodes)
 }
 
 func (nm *NodeManager) StartNodes(count int) ([]*models.Node, error) {
-	return nm.StartNodesWithOptions(count, false)
+	return nm.StartNodesWithOptions(count, 0, 0, false)
 }
 
-func (nm *NodeManager) StartNodesWithOptions(count int, fresh bool) ([]*models.Node, error) {
+// StartNodesWithOptions starts count transaction nodes. quorumCount sizes the quorum of a
+// fresh fleet; 0 keeps the current quorum size. initialTokens is the number of test tokens
+// each new node gets; 0 uses the configured InitialTokens.
+func (nm *NodeManager) StartNodesWithOptions(count, quorumCount, initialTokens int, fresh bool) ([]*models.Node, error) {
 	nm.mu.Lock()
 	defer nm.mu.Unlock()
+	defer nm.updateNodeMetrics()
 
-	// Count represents additional nodes beyond the 7 quorum nodes
+	// External fleets are already running; hand back the first count of them by ID
+	if nm.external {
+		if count > len(nm.nodes) {
+			return nil, fmt.Errorf("externally-managed fleet has %d nodes, %d requested", len(nm.nodes), count)
+		}
+		nodes := sortedNodes(nm.nodes)
+		if count > 0 {
+			nodes = nodes[:count]
+		}
+		return nodes, nil
+	}
+
+	// Count represents additional nodes beyond the quorum nodes
 	transactionNodes := count
 	if transactionNodes < 2 || transactionNodes > 20 {
 		return nil, fmt.Errorf("transaction node count must be between 2 and 20")
@@ -55,32 +172,26 @@ func (nm *NodeManager) StartNodesWithOptions(count int, fresh bool) ([]*models.N
 
 	if !nm.usePython {
 		// Use the Go implementation
-		log.Printf("Using Go implementation to start nodes")
+		logging.Infof("Using Go implementation to start nodes")
 
 		// Start nodes using the Go manager
-		if err := nm.rubixManager.StartNodes(transactionNodes, fresh); err != nil {
+		if err := nm.rubixManager.StartNodes(transactionNodes, quorumCount, initialTokens, fresh); err != nil {
 			return nil, fmt.Errorf("failed to start nodes: %w", err)
 		}
 
-		// Convert rubix.NodeInfo to models.Node
+		// Convert rubix.NodeInfo to models.Node. The manager may have selected fewer nodes
+		// than last time, so rebuild the map rather than keeping deselected nodes around.
+		nm.nodes = make(map[string]*models.Node)
 		var nodes []*models.Node
 		for _, nodeInfo := range nm.rubixManager.GetNodes() {
-			node := &models.Node{
-				ID:       nodeInfo.ID,
-				Port:     nodeInfo.ServerPort,
-				GrpcPort: nodeInfo.GrpcPort,
-				DID:      nodeInfo.DID,
-				IsQuorum: nodeInfo.IsQuorum,
-				Status:   nodeInfo.Status,
-				Started:  time.Now(),
-			}
+			node := nodeFromInfo(nodeInfo)
 			nm.nodes[node.ID] = node
 			nodes = append(nodes, node)
 		}
 
-		totalNodes := nm.quorumNodes + transactionNodes
-		log.Printf("Successfully started %d nodes (%d quorum + %d transaction) via Go manager",
-			totalNodes, nm.quorumNodes, transactionNodes)
+		quorumNodes := nm.rubixManager.QuorumCount()
+		logging.Infof("Successfully started %d nodes (%d quorum + %d transaction) via Go manager",
+			quorumNodes+transactionNodes, quorumNodes, transactionNodes)
 		return nodes, nil
 	}
 
@@ -88,36 +199,99 @@ func (nm *NodeManager) StartNodesWithOptions(count int, fresh bool) ([]*models.N
 	return nm.startSimulatedNodes(count)
 }
 
+// nodeFromInfo converts a node started by the Go manager to the API model
+func nodeFromInfo(nodeInfo *rubix.NodeInfo) *models.Node {
+	return &models.Node{
+		ID:       nodeInfo.ID,
+		Port:     nodeInfo.ServerPort,
+		GrpcPort: nodeInfo.GrpcPort,
+		DID:      nodeInfo.DID,
+		IsQuorum: nodeInfo.IsQuorum,
+		Status:   nodeInfo.Status,
+		Label:    nodeInfo.Label,
+		Started:  time.Now(),
+	}
+}
+
+// AddNodes starts count more transaction nodes without disturbing the running ones and
+// returns the added nodes
+func (nm *NodeManager) AddNodes(count int) ([]*models.Node, error) {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+	defer nm.updateNodeMetrics()
+
+	if nm.external {
+		return nil, fmt.Errorf("nodes are externally managed and cannot be added by the simulator")
+	}
+	if nm.usePython {
+		return nil, fmt.Errorf("adding nodes is not supported in simulation mode")
+	}
+
+	added, err := nm.rubixManager.AddTransactionNodes(count)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add nodes: %w", err)
+	}
+
+	nodes := make([]*models.Node, 0, len(added))
+	for _, nodeInfo := range added {
+		node := nodeFromInfo(nodeInfo)
+		nm.nodes[node.ID] = node
+		nodes = append(nodes, node)
+	}
+	logging.Infof("Added %d transaction nodes", len(nodes))
+	return nodes, nil
+}
+
+// RemoveNodes shuts down and forgets the given transaction nodes
+func (nm *NodeManager) RemoveNodes(nodeIDs []string) error {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+	defer nm.updateNodeMetrics()
+
+	if nm.external {
+		return fmt.Errorf("nodes are externally managed and cannot be removed by the simulator")
+	}
+	if nm.usePython {
+		return fmt.Errorf("removing nodes is not supported in simulation mode")
+	}
+
+	if err := nm.rubixManager.RemoveNodes(nodeIDs); err != nil {
+		return err
+	}
+	for _, nodeID := range nodeIDs {
+		delete(nm.nodes, nodeID)
+		delete(nm.busyNodes, nodeID)
+	}
+	return nil
+}
+
 func (nm *NodeManager) RestartNodes() ([]*models.Node, error) {
 	nm.mu.Lock()
 	defer nm.mu.Unlock()
+	defer nm.updateNodeMetrics()
+
+	if nm.external {
+		return nil, fmt.Errorf("nodes are externally managed and cannot be restarted by the simulator")
+	}
 
 	if !nm.usePython {
 		// Use the Go implementation to restart nodes
-		log.Printf("Using Go implementation to restart nodes")
+		logging.Infof("Using Go implementation to restart nodes")
 
 		// This will restart based on saved metadata
-		if err := nm.rubixManager.StartNodes(2, false); err != nil {
+		if err := nm.rubixManager.StartNodes(2, 0, 0, false); err != nil {
 			return nil, fmt.Errorf("failed to restart nodes: %w", err)
 		}
 
 		// Convert rubix.NodeInfo to models.Node
 		var nodes []*models.Node
 		for _, nodeInfo := range nm.rubixManager.GetNodes() {
-			node := &models.Node{
-				ID:       nodeInfo.ID,
-				Port:     nodeInfo.ServerPort,
-				GrpcPort: nodeInfo.GrpcPort,
-				DID:      nodeInfo.DID,
-				IsQuorum: nodeInfo.IsQuorum,
-				Status:   nodeInfo.Status,
-				Started:  time.Now(),
-			}
+			node := nodeFromInfo(nodeInfo)
 			nm.nodes[node.ID] = node
 			nodes = append(nodes, node)
 		}
 
-		log.Printf("Successfully restarted %d nodes", len(nodes))
+		logging.Infof("Successfully restarted %d nodes", len(nodes))
 		return nodes, nil
 	}
 
@@ -127,11 +301,16 @@ func (nm *NodeManager) RestartNodes() ([]*models.Node, error) {
 func (nm *NodeManager) ResetNodes() error {
 	nm.mu.Lock()
 	defer nm.mu.Unlock()
+	defer nm.updateNodeMetrics()
+
+	if nm.external {
+		return nm.StopAllNodesInternal()
+	}
 
 	if !nm.usePython {
 		// Stop all nodes first
 		if err := nm.rubixManager.StopAllNodes(); err != nil {
-			log.Printf("Warning: failed to stop nodes: %v", err)
+			logging.Warnf("Warning: failed to stop nodes: %v", err)
 		}
 	}
 
@@ -153,7 +332,7 @@ func (nm *NodeManager) startSimulatedNodes(count int) ([]*models.Node, error) {
 		nm.nodes[node.ID] = node
 		nodes = append(nodes, node)
 	}
-	log.Printf("Created %d simulated nodes", len(nodes))
+	logging.Infof("Created %d simulated nodes", len(nodes))
 	return nodes, nil
 }
 
@@ -165,13 +344,23 @@ func (nm *NodeManager) StopAllNodes() error {
 }
 
 func (nm *NodeManager) StopAllNodesInternal() error {
+	defer nm.updateNodeMetrics()
+
+	if nm.external {
+		// Never stop nodes we did not start; just detach from them
+		logging.Infof("Detached from externally-managed fleet (nodes left running)")
+		nm.nodes = make(map[string]*models.Node)
+		nm.external = false
+		return nil
+	}
+
 	if !nm.usePython {
 		// Use the Go implementation to stop nodes
 		if err := nm.rubixManager.StopAllNodes(); err != nil {
-			log.Printf("Warning: failed to stop nodes: %v", err)
+			logging.Warnf("Warning: failed to stop nodes: %v", err)
 			// Continue with cleanup even if stop fails
 		} else {
-			log.Printf("Stopped all nodes via Go manager")
+			logging.Infof("Stopped all nodes via Go manager")
 		}
 	}
 
@@ -181,6 +370,11 @@ func (nm *NodeManager) StopAllNodesInternal() error {
 	return nil
 }
 
+// updateNodeMetrics publishes the node count. Caller must hold nm.mu.
+func (nm *NodeManager) updateNodeMetrics() {
+	metrics.SetActiveNodes(len(nm.nodes))
+}
+
 func (nm *NodeManager) GetNodes() []*models.Node {
 	nm.mu.RLock()
 	defer nm.mu.RUnlock()
@@ -207,46 +401,220 @@ func (nm *NodeManager) checkNodeHealth(node *models.Node) error {
 	return nil
 }
 
-func (nm *NodeManager) MarkNodesAsBusy(nodes []*models.Node) {
+func (nm *NodeManager) MarkNodesAsAvailable(nodes []*models.Node) {
 	nm.mu.Lock()
 	defer nm.mu.Unlock()
 	for _, node := range nodes {
-		nm.busyNodes[node.ID] = true
+		delete(nm.busyNodes, node.ID)
 	}
 }
 
-func (nm *NodeManager) MarkNodesAsAvailable(nodes []*models.Node) {
+// ReserveNodes marks count free transaction nodes as busy and returns them ordered by ID.
+// Nodes listed in required are always among them. Nothing is reserved if a required node
+// is unknown or busy, or too few nodes are free; release them with MarkNodesAsAvailable.
+func (nm *NodeManager) ReserveNodes(count int, required ...string) ([]*models.Node, error) {
 	nm.mu.Lock()
 	defer nm.mu.Unlock()
-	for _, node := range nodes {
-		delete(nm.busyNodes, node.ID)
-	}
-}
 
-func (nm *NodeManager) GetAvailableNodes(count int) ([]*models.Node, error) {
-	nm.mu.RLock()
-	defer nm.mu.RUnlock()
+	reserved := make([]*models.Node, 0, count)
+	taken := make(map[string]bool)
+	for _, id := range required {
+		node, exists := nm.nodes[id]
+		if !exists || node.IsQuorum {
+			return nil, fmt.Errorf("node %s is not a transaction node of the fleet", id)
+		}
+		if nm.busyNodes[id] {
+			return nil, fmt.Errorf("node %s is in use by another simulation", id)
+		}
+		if !taken[id] {
+			taken[id] = true
+			reserved = append(reserved, node)
+		}
+	}
 
 	var availableNodes []*models.Node
 	for _, node := range nm.nodes {
-		// Return only available, non-quorum nodes for transactions
-		if !nm.busyNodes[node.ID] && !node.IsQuorum {
+		// Only free, non-quorum nodes run transactions
+		if !nm.busyNodes[node.ID] && !node.IsQuorum && !taken[node.ID] {
 			availableNodes = append(availableNodes, node)
 		}
 	}
+	sort.Slice(availableNodes, func(i, j int) bool { return availableNodes[i].ID < availableNodes[j].ID })
 
-	if len(availableNodes) < count {
-		return nil, fmt.Errorf("not enough available transaction nodes to run the simulation: have %d, need %d", len(availableNodes), count)
+	if len(reserved)+len(availableNodes) < count {
+		return nil, fmt.Errorf("not enough free transaction nodes to run the simulation: have %d, need %d", len(reserved)+len(availableNodes), count)
 	}
+	reserved = append(reserved, availableNodes[:count-len(reserved)]...)
+	sort.Slice(reserved, func(i, j int) bool { return reserved[i].ID < reserved[j].ID })
 
-	return availableNodes[:count], nil
+	for _, node := range reserved {
+		nm.busyNodes[node.ID] = true
+	}
+	return reserved, nil
 }
 
-// CheckTokenBalances triggers an immediate token balance check for all nodes
-func (nm *NodeManager) CheckTokenBalances() {
-	if nm.rubixManager != nil {
-		nm.rubixManager.CheckBalancesNow()
+// CountNodeStatuses pings every node and returns how many there are, how many answered
+// and how many of those are transaction nodes
+func (nm *NodeManager) CountNodeStatuses() (total, running, runningTransaction int) {
+	if nm.IsExternal() {
+		for _, node := range nm.GetNodes() {
+			total++
+			client := rubix.NewClientWithConfig(node.Port, nm.config.Rubix)
+			if err := client.Ping(); err == nil {
+				running++
+				if !node.IsQuorum {
+					runningTransaction++
+				}
+			}
+		}
+		return total, running, runningTransaction
+	}
+
+	if nm.rubixManager == nil {
+		return 0, 0, 0
+	}
+	statuses := nm.rubixManager.CheckAllNodesStatus()
+	for _, nodeInfo := range nm.rubixManager.GetNodes() {
+		total++
+		if statuses[nodeInfo.ID] == "running" {
+			running++
+			if !nodeInfo.IsQuorum {
+				runningTransaction++
+			}
+		}
+	}
+	return total, running, runningTransaction
+}
+
+// GetNodeUptime returns how long a managed node's process has been running, independent
+// of the Started timestamp (which resets whenever the nodes are reselected). Externally-
+// managed nodes were not launched by the simulator, so their uptime is unknown.
+func (nm *NodeManager) GetNodeUptime(nodeID string) (time.Duration, error) {
+	if nm.IsExternal() {
+		if _, err := nm.GetNode(nodeID); err != nil {
+			return 0, fmt.Errorf("%w: %s", rubix.ErrNodeNotFound, nodeID)
+		}
+		return 0, fmt.Errorf("%w for externally-managed node %s", rubix.ErrUptimeUnknown, nodeID)
+	}
+	if nm.rubixManager == nil {
+		return 0, fmt.Errorf("rubix manager not initialized")
+	}
+	return nm.rubixManager.NodeUptime(nodeID)
+}
+
+// GetNodeAccountInfo resolves a node's DID and queries its available, pledged, locked
+// and pinned RBT
+func (nm *NodeManager) GetNodeAccountInfo(nodeID string) (*models.DIDAccountInfo, error) {
+	var port int
+	var did string
+	if nm.IsExternal() {
+		node, err := nm.GetNode(nodeID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", rubix.ErrNodeNotFound, nodeID)
+		}
+		port, did = node.Port, node.DID
+	} else {
+		nodeInfo, err := nm.rubixManager.GetNode(nodeID)
+		if err != nil {
+			return nil, err
+		}
+		port, did = nodeInfo.ServerPort, nodeInfo.DID
+	}
+	if did == "" {
+		return nil, fmt.Errorf("node %s has no DID yet", nodeID)
+	}
+
+	client := rubix.NewClientWithConfig(port, nm.config.Rubix)
+	return client.GetDIDAccountInfo(did)
+}
+
+// GetNodeMetrics returns live metrics for a node: ports, DID, status, account info and peer count
+func (nm *NodeManager) GetNodeMetrics(nodeID string) (map[string]interface{}, error) {
+	if !nm.IsExternal() {
+		return nm.rubixManager.GetNodeMetrics(nodeID)
+	}
+
+	node, err := nm.GetNode(nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", rubix.ErrNodeNotFound, nodeID)
+	}
+
+	metrics := map[string]interface{}{
+		"node_id":     node.ID,
+		"server_port": node.Port,
+		"is_quorum":   node.IsQuorum,
+		"did":         node.DID,
+		"status":      node.Status,
+	}
+	client := rubix.NewClientWithConfig(node.Port, nm.config.Rubix)
+	if accountInfo, err := client.GetAccountInfo(node.DID); err == nil {
+		metrics["account_info"] = accountInfo
+	}
+	if peerCount, err := client.GetPeerCount(); err == nil {
+		metrics["peer_count"] = peerCount
 	}
+	return metrics, nil
+}
+
+// MinTokenBalance returns the RBT balance below which token monitoring refills a node
+func (nm *NodeManager) MinTokenBalance() float64 {
+	return nm.config.Rubix.MinTokenBalance
+}
+
+// TopUpNode generates TokenRefillAmount test tokens for a managed node and returns the
+// number requested. Externally-managed nodes are never refilled.
+func (nm *NodeManager) TopUpNode(nodeID string) (int, error) {
+	if nm.IsExternal() || nm.rubixManager == nil {
+		return 0, fmt.Errorf("node %s is not managed by the simulator and cannot be refilled", nodeID)
+	}
+	return nm.rubixManager.TopUpNode(nodeID)
+}
+
+// CheckTokenBalances returns every managed node's balance sorted by node ID, leaving out
+// nodes that didn't answer. When any node is below MinTokenBalance it also starts a
+// background refill and reports whether one was started, or why not; the returned
+// balances are the ones seen before that refill.
+func (nm *NodeManager) CheckTokenBalances() (nodes []models.NodeTokenBalance, refillStarted bool, refillSkipped string) {
+	if nm.IsExternal() || nm.rubixManager == nil {
+		return []models.NodeTokenBalance{}, false, "nodes are not managed by the simulator"
+	}
+
+	infos := nm.rubixManager.GetNodes()
+	balances := nm.rubixManager.CheckAllBalances()
+	result := make([]models.NodeTokenBalance, 0, len(balances))
+	for nodeID, balance := range balances {
+		nodeInfo, exists := infos[nodeID]
+		if !exists {
+			// Removed while the balances were being read
+			continue
+		}
+		result = append(result, models.NodeTokenBalance{
+			NodeID:         nodeID,
+			DID:            nodeInfo.DID,
+			Balance:        balance,
+			IsQuorum:       nodeInfo.IsQuorum,
+			BelowThreshold: balance < nm.config.Rubix.MinTokenBalance,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].NodeID < result[j].NodeID
+	})
+
+	low := false
+	for _, node := range result {
+		low = low || node.BelowThreshold
+	}
+	switch {
+	case !low:
+		return result, false, "no node is below the minimum balance"
+	case !nm.config.Rubix.TokenMonitoringEnabled:
+		return result, false, "token monitoring is disabled"
+	case nm.IsSimulationActive():
+		return result, false, "a simulation is running"
+	case !nm.rubixManager.RefillInBackground():
+		return result, false, "a previous refill is still running"
+	}
+	return result, true, ""
 }
 
 // AutoStartTokenMonitoring automatically starts token monitoring if nodes already exist
@@ -269,4 +637,121 @@ func (nm *NodeManager) IsSimulationActive() bool {
 		return nm.rubixManager.IsSimulationActive()
 	}
 	return false
-}
\ No newline at end of file
+}
+
+// GeneratedTokenStats returns the test tokens generated this session and the fleet-wide cap (0 = unlimited)
+func (nm *NodeManager) GeneratedTokenStats() (int, int) {
+	if nm.rubixManager != nil {
+		return nm.rubixManager.GeneratedTokenStats()
+	}
+	return 0, 0
+}
+
+// GetStartupProgress returns the progress of the current or last fleet start. Externally
+// managed fleets are never started by the simulator and report no progress.
+func (nm *NodeManager) GetStartupProgress() rubix.StartupProgress {
+	if nm.rubixManager == nil || nm.IsExternal() {
+		return rubix.StartupProgress{Phases: []string{}, NodesCompleted: []string{}}
+	}
+	return nm.rubixManager.GetStartupProgress()
+}
+
+// CheckQuorumMajority verifies that a majority of quorum nodes are healthy. Externally-managed
+// fleets bring their own quorum, which the simulator cannot see, so they are not checked.
+func (nm *NodeManager) CheckQuorumMajority() error {
+	if nm.IsExternal() || nm.rubixManager == nil {
+		return nil
+	}
+	return nm.rubixManager.CheckQuorumMajority()
+}
+
+// DiskUsage reports the size of the Rubix data directory, per node, and the free space left on its filesystem
+func (nm *NodeManager) DiskUsage() (*rubix.DiskUsage, error) {
+	if nm.rubixManager == nil {
+		return nil, fmt.Errorf("rubix manager not initialized")
+	}
+	return nm.rubixManager.DiskUsage()
+}
+
+// RegisterDIDs re-registers node DIDs with the network on demand. Without force only
+// DIDs whose registration was never confirmed are registered. Externally-managed
+// fleets register their own DIDs.
+func (nm *NodeManager) RegisterDIDs(force bool) (int, error) {
+	if nm.IsExternal() {
+		return 0, fmt.Errorf("DID registration is not managed for external nodes")
+	}
+	if nm.rubixManager == nil {
+		return 0, fmt.Errorf("rubix manager not initialized")
+	}
+	return nm.rubixManager.RegisterDIDs(force)
+}
+
+// SetNodeLabel names a node for reports. Labels of managed nodes are persisted with the
+// node metadata; labels of external nodes last until the fleet is detached.
+func (nm *NodeManager) SetNodeLabel(nodeID, label string) error {
+	if !nm.IsExternal() {
+		if nm.rubixManager == nil {
+			return fmt.Errorf("rubix manager not initialized")
+		}
+		if err := nm.rubixManager.SetNodeLabel(nodeID, label); err != nil {
+			return err
+		}
+	}
+
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+	node, exists := nm.nodes[nodeID]
+	if !exists {
+		if nm.external {
+			return fmt.Errorf("%w: %s", rubix.ErrNodeNotFound, nodeID)
+		}
+		return nil
+	}
+	node.Label = label
+	return nil
+}
+
+// QuorumCount returns the number of quorum nodes in the managed fleet, or 0 for an
+// external fleet, which brings its own quorum
+func (nm *NodeManager) QuorumCount() int {
+	if nm.IsExternal() || nm.rubixManager == nil {
+		return 0
+	}
+	return nm.rubixManager.QuorumCount()
+}
+
+// GetNodeLogs returns the last lines of a managed node's captured output
+func (nm *NodeManager) GetNodeLogs(nodeID string, lines int) ([]string, error) {
+	if nm.IsExternal() {
+		return nil, fmt.Errorf("logs are not captured for external nodes")
+	}
+	if nm.rubixManager == nil {
+		return nil, fmt.Errorf("rubix manager not initialized")
+	}
+	return nm.rubixManager.GetNodeLogs(nodeID, lines)
+}
+
+// TransactionNodeBalance sums the RBT balance of the current transaction nodes and returns
+// it with the number of nodes whose balance could be read. Unreachable nodes are skipped.
+func (nm *NodeManager) TransactionNodeBalance() (float64, int) {
+	return nm.nodesBalance(nm.GetNodes())
+}
+
+// nodesBalance sums the RBT balance of the given transaction nodes, as TransactionNodeBalance
+func (nm *NodeManager) nodesBalance(nodes []*models.Node) (float64, int) {
+	var total float64
+	read := 0
+	for _, node := range nodes {
+		if node.IsQuorum || node.DID == "" {
+			continue
+		}
+		balance, err := rubix.NewClientWithConfig(node.Port, nm.config.Rubix).GetAccountBalance(node.DID)
+		if err != nil {
+			logging.Warnf("Warning: could not read balance for %s: %v", node.ID, err)
+			continue
+		}
+		total += balance
+		read++
+	}
+	return total, read
+}
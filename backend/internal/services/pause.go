@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rubix-simulator/backend/internal/logging"
+	"github.com/rubix-simulator/backend/internal/models"
+)
+
+// ErrSimulationPaused is returned when pausing a simulation that is already paused
+var ErrSimulationPaused = errors.New("is already paused")
+
+// ErrSimulationNotPaused is returned when resuming a simulation that is not paused
+var ErrSimulationNotPaused = errors.New("is not paused")
+
+// ErrSimulationNotPausable was returned when pausing a batch simulation.
+//
+// Deprecated: batch simulations pause between batches, so it is no longer returned.
+var ErrSimulationNotPausable = errors.New("runs in batch mode and cannot be paused")
+
+// pauseGate holds a simulation's round loop between rounds while it is paused
+type pauseGate struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	paused bool
+}
+
+func newPauseGate() *pauseGate {
+	g := &pauseGate{}
+	g.cond = sync.NewCond(&g.mu)
+	return g
+}
+
+// setPaused changes the paused state, reporting false if it was already in that state
+func (g *pauseGate) setPaused(paused bool) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.paused == paused {
+		return false
+	}
+	g.paused = paused
+	if !paused {
+		g.cond.Broadcast()
+	}
+	return true
+}
+
+// wait blocks while the gate is paused, returning early once ctx is done so a paused
+// simulation can still be cancelled. A nil gate never blocks.
+func (g *pauseGate) wait(ctx context.Context) {
+	if g == nil {
+		return
+	}
+
+	// Wake the waiter when ctx ends; taking the lock avoids a broadcast landing between
+	// the ctx check and cond.Wait below
+	stop := context.AfterFunc(ctx, func() {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		g.cond.Broadcast()
+	})
+	defer stop()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for g.paused && ctx.Err() == nil {
+		g.cond.Wait()
+	}
+}
+
+// PauseSimulation holds a running simulation before its next round, or in batch mode
+// before each sender's next batch. The in-flight round or batches finish; the simulation
+// keeps its slot, so no other simulation can start meanwhile.
+func (ss *SimulationService) PauseSimulation(simulationID string) error {
+	return ss.setSimulationPaused(simulationID, true)
+}
+
+// ResumeSimulation lets a paused simulation continue with its next round
+func (ss *SimulationService) ResumeSimulation(simulationID string) error {
+	return ss.setSimulationPaused(simulationID, false)
+}
+
+func (ss *SimulationService) setSimulationPaused(simulationID string, paused bool) error {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	report, exists := ss.simulations[simulationID]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrSimulationNotFound, simulationID)
+	}
+	gate, running := ss.pauses[simulationID]
+	if report.IsFinished || !running {
+		return fmt.Errorf("simulation %s %w", simulationID, ErrSimulationFinished)
+	}
+	if !gate.setPaused(paused) {
+		if paused {
+			return fmt.Errorf("simulation %s %w", simulationID, ErrSimulationPaused)
+		}
+		return fmt.Errorf("simulation %s %w", simulationID, ErrSimulationNotPaused)
+	}
+
+	now := time.Now()
+	report.IsPaused = paused
+	if paused {
+		logging.Infof("Pausing simulation %s", simulationID)
+		report.PausedAt = &now
+	} else {
+		logging.Infof("Resuming simulation %s", simulationID)
+		report.PausedAt = nil
+		// The pause itself is not a stall; restart the staleness clock
+		report.LastHeartbeat = now
+	}
+	ss.persistSimulationToDisk(report)
+	ss.publishProgress(report)
+	return nil
+}
+
+// clearPaused drops the paused state of a finishing simulation. Caller must hold ss.mu.
+func clearPaused(report *models.SimulationReport) {
+	report.IsPaused = false
+	report.PausedAt = nil
+}
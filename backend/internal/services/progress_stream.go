@@ -0,0 +1,100 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/rubix-simulator/backend/internal/models"
+)
+
+// progressFrame extracts the streamed progress fields from a report
+func progressFrame(report *models.SimulationReport) models.ProgressFrame {
+	return models.ProgressFrame{
+		SimulationID:           report.SimulationID,
+		TransactionsCompleted:  report.TransactionsCompleted,
+		TotalTransactions:      report.TotalTransactions,
+		SuccessCount:           report.SuccessCount,
+		FailureCount:           report.FailureCount,
+		AverageTransactionTime: report.AverageTransactionTime,
+		IsPaused:               report.IsPaused,
+		IsFinished:             report.IsFinished,
+		Error:                  report.Error,
+	}
+}
+
+// SubscribeProgress registers a listener for a simulation's progress. The channel
+// receives the current progress straight away, then a frame per update, and is closed
+// once the simulation finishes. A listener that goes away earlier must call unsubscribe.
+func (ss *SimulationService) SubscribeProgress(simulationID string) (<-chan models.ProgressFrame, func(), error) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	report, exists := ss.simulations[simulationID]
+	if !exists {
+		return nil, nil, fmt.Errorf("%w: %s", ErrSimulationNotFound, simulationID)
+	}
+
+	ch := make(chan models.ProgressFrame, 1)
+	ch <- progressFrame(report)
+	if report.IsFinished {
+		close(ch)
+		return ch, func() {}, nil
+	}
+
+	if ss.streams[simulationID] == nil {
+		ss.streams[simulationID] = make(map[chan models.ProgressFrame]struct{})
+	}
+	ss.streams[simulationID][ch] = struct{}{}
+
+	unsubscribe := func() {
+		ss.mu.Lock()
+		defer ss.mu.Unlock()
+		subscribers := ss.streams[simulationID]
+		if _, ok := subscribers[ch]; ok {
+			delete(subscribers, ch)
+			close(ch)
+		}
+		if len(subscribers) == 0 {
+			delete(ss.streams, simulationID)
+		}
+	}
+	return ch, unsubscribe, nil
+}
+
+// publishProgress sends a report's progress to its listeners, replacing any frame a slow
+// listener has not read yet so the simulation never waits on a client. Listeners are
+// closed once the simulation finishes. Caller must hold ss.mu.
+func (ss *SimulationService) publishProgress(report *models.SimulationReport) {
+	subscribers := ss.streams[report.SimulationID]
+	if len(subscribers) == 0 {
+		return
+	}
+
+	frame := progressFrame(report)
+	for ch := range subscribers {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- frame
+		if report.IsFinished {
+			close(ch)
+		}
+	}
+	if report.IsFinished {
+		delete(ss.streams, report.SimulationID)
+	}
+}
+
+// CloseStreams ends every progress stream, e.g. so open connections don't hold up
+// server shutdown
+func (ss *SimulationService) CloseStreams() {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	for simulationID, subscribers := range ss.streams {
+		for ch := range subscribers {
+			close(ch)
+		}
+		delete(ss.streams, simulationID)
+	}
+}
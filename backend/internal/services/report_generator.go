@@ -1,21 +1,31 @@
 package services
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
-	"log"
+	"io"
+	"math"
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-pdf/fpdf"
 	"github.com/rubix-simulator/backend/internal/config"
+	"github.com/rubix-simulator/backend/internal/logging"
 	"github.com/rubix-simulator/backend/internal/models"
+	"github.com/rubix-simulator/backend/internal/rubix"
 )
 
 type ReportGenerator struct {
 	config      *config.Config
 	reportsPath string
+	indexPath   string     // Maintained reports index; empty when disabled
+	indexMu     sync.Mutex // Serialises read-modify-write of the index file
 }
 
 // TableRowData represents a table row with optional links
@@ -24,14 +34,40 @@ type TableRowData struct {
 	links []string // URLs for each cell (empty string means no link)
 }
 
-func NewReportGenerator(cfg *config.Config) *ReportGenerator {
-	reportsPath := filepath.Join(".", "reports")
-	os.MkdirAll(reportsPath, 0o755)
+// NewReportGenerator writes reports to cfg.ReportsPath, creating it if needed. It fails
+// when the directory can't be created or written to, so a bad REPORTS_PATH surfaces at
+// startup rather than when the first report is saved.
+func NewReportGenerator(cfg *config.Config) (*ReportGenerator, error) {
+	reportsPath := cfg.ReportsPath
+	if reportsPath == "" {
+		reportsPath = filepath.Join(".", "reports")
+	}
+	if err := os.MkdirAll(reportsPath, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create reports directory %s: %w", reportsPath, err)
+	}
+	probe, err := os.CreateTemp(reportsPath, ".write-check-*")
+	if err != nil {
+		return nil, fmt.Errorf("reports directory %s is not writable: %w", reportsPath, err)
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+
+	indexPath := ""
+	if cfg.ReportsIndex != "" && cfg.ReportsIndex != "none" {
+		indexPath = filepath.Join(reportsPath, cfg.ReportsIndex)
+	}
 
 	return &ReportGenerator{
 		config:      cfg,
 		reportsPath: reportsPath,
-	}
+		indexPath:   indexPath,
+	}, nil
+}
+
+// ReportsDiskUsage returns the reports directory and the total size of its contents
+func (rg *ReportGenerator) ReportsDiskUsage() (string, int64, error) {
+	size, err := rubix.DirSize(rg.reportsPath)
+	return rg.reportsPath, size, err
 }
 
 // formatDuration converts a time.Duration to human-readable format (e.g., "1m10s", "45s", "2m30s")
@@ -55,10 +91,31 @@ func formatDuration(d time.Duration) string {
 
 // buildExplorerLink creates a clickable explorer link for a transaction
 func (rg *ReportGenerator) buildExplorerLink(transactionID string) string {
-	if transactionID == "" {
+	return explorerLink(rg.config.ExplorerBaseURL, transactionID)
+}
+
+// explorerLink returns the explorer URL of an on-chain transaction, or "" when the ID is
+// not a chain hash (e.g. the UUID placeholder of a dry run or a transfer the node
+// returned no ID for)
+func explorerLink(baseURL, transactionID string) string {
+	if baseURL == "" || !isChainTransactionID(transactionID) {
 		return ""
 	}
-	return fmt.Sprintf("%s/%s", rg.config.ExplorerBaseURL, transactionID)
+	return fmt.Sprintf("%s/%s", strings.TrimSuffix(baseURL, "/"), transactionID)
+}
+
+// isChainTransactionID reports whether an ID looks like a Rubix transaction hash: a long
+// hex string, unlike the dashed UUIDs used as placeholders
+func isChainTransactionID(transactionID string) bool {
+	if len(transactionID) < 32 {
+		return false
+	}
+	for _, r := range transactionID {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
 }
 
 // formatTransactionDisplay creates display text and link for transaction references
@@ -79,25 +136,83 @@ func (rg *ReportGenerator) formatTransactionDisplay(tx models.Transaction) (stri
 }
 
 func (rg *ReportGenerator) GeneratePDF(report *models.SimulationReport) (string, error) {
-	filename := fmt.Sprintf("simulation-%s.pdf", report.SimulationID)
+	return rg.writePDF(report, fmt.Sprintf("simulation-%s.pdf", report.SimulationID))
+}
+
+// RenderFilteredPDF writes the PDF of a report that has been scoped with a ReportFilter to
+// w. Filtered views are rendered per request and never saved, so concurrent requests with
+// different filters can't overwrite each other's file.
+func (rg *ReportGenerator) RenderFilteredPDF(w io.Writer, report *models.SimulationReport) error {
+	if err := rg.buildPDF(report).Output(w); err != nil {
+		return fmt.Errorf("failed to render PDF: %w", err)
+	}
+	return nil
+}
+
+func (rg *ReportGenerator) writePDF(report *models.SimulationReport, filename string) (string, error) {
 	filepath := filepath.Join(rg.reportsPath, filename)
 
+	pdf := rg.buildPDF(report)
+	if err := pdf.OutputFileAndClose(filepath); err != nil {
+		return "", fmt.Errorf("failed to save PDF: %v", err)
+	}
+
+	logging.Infof("Report generated: %s", filepath)
+	rg.addToIndex(report, filename)
+	return filename, nil
+}
+
+// buildPDF lays out every section of a report's PDF
+func (rg *ReportGenerator) buildPDF(report *models.SimulationReport) *fpdf.Fpdf {
 	pdf := fpdf.New("P", "mm", "A4", "")
 	pdf.SetAutoPageBreak(true, 10)
 	pdf.AddPage()
 
 	rg.addHeader(pdf, report)
 	rg.addSummary(pdf, report)
+	rg.addSettlement(pdf, report)
+	rg.addAccountBalances(pdf, report)
 	rg.addTokenAnalysis(pdf, report) // Changed from addNodeBreakdown
 	rg.addTransactionDetails(pdf, report)
+	rg.addExplorerLinks(pdf, report)
 	rg.addCharts(pdf, report)
+	rg.addFailuresAppendix(pdf, report)
+	return pdf
+}
 
-	if err := pdf.OutputFileAndClose(filepath); err != nil {
-		return "", fmt.Errorf("failed to save PDF: %v", err)
+// WriteCSV streams the report's transaction log to w as CSV. Nothing is saved, so
+// concurrent downloads can't overwrite each other. TimeTaken is written in whole
+// milliseconds and Timestamp as RFC3339 so spreadsheets can parse both.
+func (rg *ReportGenerator) WriteCSV(w io.Writer, report *models.SimulationReport) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"ID", "Sender", "Receiver", "TokenAmount", "Status", "TimeTakenMs", "NodeID", "Timestamp", "Comment", "Error", "Attempts", "SubmitTimeMs", "ConfirmTimeMs"}); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
 	}
-
-	log.Printf("Report generated: %s", filepath)
-	return filename, nil
+	for _, tx := range report.Transactions {
+		err := writer.Write([]string{
+			tx.ID,
+			tx.Sender,
+			tx.Receiver,
+			strconv.FormatFloat(tx.TokenAmount, 'f', -1, 64),
+			tx.Status,
+			strconv.FormatInt(tx.TimeTaken.Milliseconds(), 10),
+			tx.NodeID,
+			tx.Timestamp.Format(time.RFC3339),
+			tx.Comment,
+			tx.Error,
+			strconv.Itoa(tx.Attempts),
+			strconv.FormatInt(tx.SubmitTime.Milliseconds(), 10),
+			strconv.FormatInt(tx.ConfirmTime.Milliseconds(), 10),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
 }
 
 func (rg *ReportGenerator) addHeader(pdf *fpdf.Fpdf, report *models.SimulationReport) {
@@ -107,14 +222,45 @@ func (rg *ReportGenerator) addHeader(pdf *fpdf.Fpdf, report *models.SimulationRe
 	pdf.SetFont("Arial", "", 10)
 	pdf.CellFormat(0, 8, fmt.Sprintf("Simulation ID: %s", report.SimulationID), "", 1, "C", false, 0, "")
 	pdf.CellFormat(0, 8, fmt.Sprintf("Generated: %s", report.CreatedAt.Format("2006-01-02 15:04:05")), "", 1, "C", false, 0, "")
+	if report.Filter != nil {
+		pdf.SetFont("Arial", "I", 10)
+		pdf.CellFormat(0, 8, fmt.Sprintf("Filtered view: %s", describeFilter(report.Filter)), "", 1, "C", false, 0, "")
+	}
 	pdf.Ln(10)
 }
 
+// describeFilter summarizes the active filter criteria for the report header
+func describeFilter(filter *models.ReportFilter) string {
+	var parts []string
+	if filter.Status != "" {
+		parts = append(parts, "status="+filter.Status)
+	}
+	if filter.MinAmount > 0 || filter.MaxAmount > 0 {
+		parts = append(parts, fmt.Sprintf("amount %.3f-%.3f RBT", filter.MinAmount, filter.MaxAmount))
+	}
+	if filter.Node != "" {
+		parts = append(parts, "node="+filter.Node)
+	}
+	for _, pair := range filter.NodePairs {
+		parts = append(parts, fmt.Sprintf("%s->%s", pair.Sender, pair.Receiver))
+	}
+	if len(parts) == 0 {
+		return "all transactions"
+	}
+	return strings.Join(parts, ", ")
+}
+
 func (rg *ReportGenerator) addSummary(pdf *fpdf.Fpdf, report *models.SimulationReport) {
 	pdf.SetFont("Arial", "B", 14)
 	pdf.CellFormat(0, 10, "Summary", "", 1, "L", false, 0, "")
 	pdf.SetFont("Arial", "", 10)
 
+	rg.addTable(pdf, summaryRows(report), []float64{60, 100})
+	pdf.Ln(10)
+}
+
+// summaryRows builds the summary table of a report, header row first
+func summaryRows(report *models.SimulationReport) [][]string {
 	// Convert average latency from milliseconds to Duration
 	avgTransactionTimeDuration := time.Duration(report.AverageTransactionTime) * time.Millisecond
 	
@@ -129,15 +275,188 @@ func (rg *ReportGenerator) addSummary(pdf *fpdf.Fpdf, report *models.SimulationR
 		{"Average Transaction Time", formatDuration(avgTransactionTimeDuration)},
 		{"Min Transaction Time", formatDuration(report.MinTransactionTime)},
 		{"Max Transaction Time", formatDuration(report.MaxTransactionTime)},
+		{"Avg Submission / Confirmation", fmt.Sprintf("%s / %s",
+			formatDuration(time.Duration(report.AverageSubmitTime)*time.Millisecond),
+			formatDuration(time.Duration(report.AverageConfirmTime)*time.Millisecond))},
+		{"P50 / P95 / P99 (successful)", fmt.Sprintf("%s / %s / %s", formatDuration(report.P50TransactionTime),
+			formatDuration(report.P95TransactionTime), formatDuration(report.P99TransactionTime))},
+		{"Total Tokens Requested", fmt.Sprintf("%.2f", report.TotalTokensRequested)},
 		{"Total Tokens Transferred", fmt.Sprintf("%.2f", report.TotalTokensTransferred)},
+		{"Amount-Adjusted Transactions", fmt.Sprintf("%d", report.AdjustedTransactions)},
+		{"Retried Transactions", fmt.Sprintf("%d", report.RetriedTransactions)},
 		{"Total Execution Time", formatDuration(report.TotalTime)},
 	}
+	if report.Config.AutoRefill || len(report.Refills) > 0 {
+		failedRefills := 0
+		var refillTime time.Duration
+		for _, refill := range report.Refills {
+			if refill.Error != "" {
+				failedRefills++
+			}
+			refillTime += refill.Duration
+		}
+		summaryData = append(summaryData, []string{"Token Refills (failed, time)",
+			fmt.Sprintf("%d (%d, %s)", len(report.Refills), failedRefills, formatDuration(refillTime))})
+	}
+	return summaryData
+}
 
-	rg.addTable(pdf, summaryData, []float64{60, 100})
+// addSettlement adds the confirmed vs reported reconciliation from the post-simulation settle phase
+func (rg *ReportGenerator) addSettlement(pdf *fpdf.Fpdf, report *models.SimulationReport) {
+	if report.Settlement == nil {
+		return
+	}
+	settlement := report.Settlement
+
+	pdf.SetFont("Arial", "B", 14)
+	pdf.CellFormat(0, 10, "Settlement Verification", "", 1, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 10)
+
+	rg.addTable(pdf, settlementRows(settlement), []float64{60, 100})
+	pdf.Ln(5)
+
+	if len(settlement.NodeBalances) > 0 {
+		rg.addTable(pdf, settlementBalanceRows(report, 14), []float64{30, 30, 30, 35, 35, 20})
+	}
 	pdf.Ln(10)
 }
 
+// settlementRows builds the settlement summary table, header row first
+func settlementRows(settlement *models.SettlementReconciliation) [][]string {
+	return [][]string{
+		{"Parameter", "Value"},
+		{"Settle Period", formatDuration(settlement.SettleDuration)},
+		{"Reported Successful", fmt.Sprintf("%d", settlement.ReportedSuccess)},
+		{"Confirmed On-Chain", fmt.Sprintf("%d", settlement.Confirmed)},
+		{"Not Found On-Chain", fmt.Sprintf("%d", len(settlement.Unconfirmed))},
+		{"Unverifiable", fmt.Sprintf("%d", settlement.Unverifiable)},
+	}
+}
+
+// settlementBalanceRows builds the per-node balance check table, header row first, with
+// node names cut to maxNameLen characters
+func settlementBalanceRows(report *models.SimulationReport, maxNameLen int) [][]string {
+	balanceData := [][]string{
+		{"Node", "Initial", "Final", "Expected Change", "Actual Change", "Match"},
+	}
+	nodeName := nodeNamer(report)
+	for _, nb := range report.Settlement.NodeBalances {
+		match := "Yes"
+		if !nb.Matches {
+			match = "No"
+		}
+		balanceData = append(balanceData, []string{
+			nodeName(nb.NodeID, maxNameLen),
+			fmt.Sprintf("%.3f", nb.InitialBalance),
+			fmt.Sprintf("%.3f", nb.FinalBalance),
+			fmt.Sprintf("%+.3f", nb.ExpectedDelta),
+			fmt.Sprintf("%+.3f", nb.ActualDelta),
+			match,
+		})
+	}
+	return balanceData
+}
+
+// addAccountBalances adds each transaction node's end-of-run account breakdown. Low success
+// rates often come from tokens pledged to quorum rather than actually spent.
+func (rg *ReportGenerator) addAccountBalances(pdf *fpdf.Fpdf, report *models.SimulationReport) {
+	if len(report.AccountBalances) == 0 {
+		return
+	}
+
+	pdf.SetFont("Arial", "B", 14)
+	pdf.CellFormat(0, 10, "Node Account Balances", "", 1, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 9)
+	pdf.MultiCell(0, 5, "RBT per transaction node at the end of the run. Pledged and locked tokens are held for consensus and cannot be sent until released.", "", "L", false)
+	pdf.Ln(2)
+
+	rg.addTable(pdf, accountBalanceRows(report, 18), []float64{40, 35, 35, 35, 35})
+	pdf.Ln(10)
+}
+
+// accountBalanceRows builds the end-of-run account balance table, header row first, with
+// node names cut to maxNameLen characters
+func accountBalanceRows(report *models.SimulationReport, maxNameLen int) [][]string {
+	balanceData := [][]string{
+		{"Node", "Available", "Pledged", "Locked", "Pinned"},
+	}
+	nodeName := nodeNamer(report)
+	for _, balance := range report.AccountBalances {
+		balanceData = append(balanceData, []string{
+			nodeName(balance.NodeID, maxNameLen),
+			fmt.Sprintf("%.3f", balance.Available),
+			fmt.Sprintf("%.3f", balance.Pledged),
+			fmt.Sprintf("%.3f", balance.Locked),
+			fmt.Sprintf("%.3f", balance.Pinned),
+		})
+	}
+	return balanceData
+}
+
 // addTokenAnalysis adds token transfer performance analysis grouped by token ranges
+// defaultTokenRangeBuckets is the number of amount ranges used when TOKEN_RANGE_BUCKETS is unset
+const defaultTokenRangeBuckets = 10
+
+// tokenRange is a range of transfer amounts the token analysis groups transactions by.
+// It covers [min, max), or [min, max] for the last range.
+type tokenRange struct {
+	min, max float64
+	last     bool
+	label    string
+}
+
+func (r tokenRange) contains(amount float64) bool {
+	return amount >= r.min && (amount < r.max || (r.last && amount <= r.max))
+}
+
+// tokenRanges splits the span of the transactions' amounts into buckets equal-width
+// ranges. Whole amounts spanning no more values than buckets get one range per amount.
+func tokenRanges(transactions []models.Transaction, buckets int) []tokenRange {
+	if len(transactions) == 0 {
+		return nil
+	}
+	if buckets < 1 {
+		buckets = defaultTokenRangeBuckets
+	}
+
+	minAmount, maxAmount := transactions[0].TokenAmount, transactions[0].TokenAmount
+	whole := true
+	for _, tx := range transactions {
+		minAmount = math.Min(minAmount, tx.TokenAmount)
+		maxAmount = math.Max(maxAmount, tx.TokenAmount)
+		whole = whole && tx.TokenAmount == math.Trunc(tx.TokenAmount)
+	}
+
+	if maxAmount == minAmount {
+		return []tokenRange{{min: minAmount, max: maxAmount, last: true, label: formatTokenAmount(minAmount)}}
+	}
+	if whole && maxAmount-minAmount+1 <= float64(buckets) {
+		var ranges []tokenRange
+		for amount := minAmount; amount <= maxAmount; amount++ {
+			ranges = append(ranges, tokenRange{min: amount, max: amount + 1, label: formatTokenAmount(amount)})
+		}
+		ranges[len(ranges)-1].last = true
+		return ranges
+	}
+
+	width := (maxAmount - minAmount) / float64(buckets)
+	ranges := make([]tokenRange, buckets)
+	for i := range ranges {
+		lo, hi := minAmount+float64(i)*width, minAmount+float64(i+1)*width
+		if i == buckets-1 {
+			hi = maxAmount
+		}
+		ranges[i] = tokenRange{min: lo, max: hi, last: i == buckets-1,
+			label: formatTokenAmount(lo) + "-" + formatTokenAmount(hi)}
+	}
+	return ranges
+}
+
+// formatTokenAmount formats an amount with at most the 3 decimal places transfers use
+func formatTokenAmount(amount float64) string {
+	return strconv.FormatFloat(math.Round(amount*1000)/1000, 'f', -1, 64)
+}
+
 func (rg *ReportGenerator) addTokenAnalysis(pdf *fpdf.Fpdf, report *models.SimulationReport) {
 	if len(report.Transactions) == 0 {
 		return
@@ -147,21 +466,20 @@ func (rg *ReportGenerator) addTokenAnalysis(pdf *fpdf.Fpdf, report *models.Simul
 	pdf.CellFormat(0, 10, "Token Transfer Performance Analysis", "", 1, "L", false, 0, "")
 	pdf.SetFont("Arial", "", 10)
 
-	// Define token ranges (1-10 tokens, 1 token intervals)
-	ranges := []struct {
-		min, max float64
-		label    string
-	}{
-		{1.0, 2.0, "1.0-2.0"},
-		{2.0, 3.0, "2.0-3.0"},
-		{3.0, 4.0, "3.0-4.0"},
-		{4.0, 5.0, "4.0-5.0"},
-		{5.0, 6.0, "5.0-6.0"},
-		{6.0, 7.0, "6.0-7.0"},
-		{7.0, 8.0, "7.0-8.0"},
-		{8.0, 9.0, "8.0-9.0"},
-		{9.0, 10.0, "9.0-10.0"},
+	analysisData := tokenAnalysisRows(report, rg.config.TokenRangeBuckets)
+
+	// Only render if we have data
+	if len(analysisData) > 1 {
+		rg.addTable(pdf, analysisData, []float64{30, 30, 30, 30, 30, 30})
+		pdf.Ln(10)
 	}
+}
+
+// tokenAnalysisRows builds the performance table per token range, header row first.
+// Ranges without transactions are left out.
+func tokenAnalysisRows(report *models.SimulationReport, buckets int) [][]string {
+	// Ranges follow the amounts actually transferred
+	ranges := tokenRanges(report.Transactions, buckets)
 
 	// Prepare data for table
 	analysisData := [][]string{
@@ -178,7 +496,7 @@ func (rg *ReportGenerator) addTokenAnalysis(pdf *fpdf.Fpdf, report *models.Simul
 
 		// Collect transactions in this range
 		for _, tx := range report.Transactions {
-			if tx.TokenAmount >= r.min && tx.TokenAmount < r.max {
+			if r.contains(tx.TokenAmount) {
 				transactions = append(transactions, tx)
 				totalTime += tx.TimeTaken
 
@@ -211,11 +529,7 @@ func (rg *ReportGenerator) addTokenAnalysis(pdf *fpdf.Fpdf, report *models.Simul
 		}
 	}
 
-	// Only render if we have data
-	if len(analysisData) > 1 {
-		rg.addTable(pdf, analysisData, []float64{30, 30, 30, 30, 30, 30})
-		pdf.Ln(10)
-	}
+	return analysisData
 }
 
 // Keep old function for backward compatibility but it now calls the new one
@@ -229,19 +543,15 @@ func (rg *ReportGenerator) addNodeBreakdown(pdf *fpdf.Fpdf, report *models.Simul
 	pdf.SetFont("Arial", "", 10)
 
 	nodeData := [][]string{
-		{"Node ID", "Transactions", "Success", "Failed", "Avg Transaction Time", "Tokens"},
+		{"Node", "Initiated", "Received", "Success", "Failed", "Avg Transaction Time", "Tokens"},
 	}
 
+	nodeName := nodeNamer(report)
 	for _, node := range report.NodeBreakdown {
-		// Safely truncate NodeID to prevent slice bounds error
-		nodeIDDisplay := node.NodeID
-		if len(nodeIDDisplay) > 8 {
-			nodeIDDisplay = nodeIDDisplay[:8]
-		}
-
 		nodeData = append(nodeData, []string{
-			nodeIDDisplay,
+			nodeName(node.NodeID, 12),
 			fmt.Sprintf("%d", node.TransactionsHandled),
+			fmt.Sprintf("%d", node.TransactionsReceived),
 			fmt.Sprintf("%d", node.SuccessfulTransactions),
 			fmt.Sprintf("%d", node.FailedTransactions),
 			formatDuration(node.AverageTransactionTime),
@@ -249,7 +559,25 @@ func (rg *ReportGenerator) addNodeBreakdown(pdf *fpdf.Fpdf, report *models.Simul
 		})
 	}
 
-	rg.addTable(pdf, nodeData, []float64{30, 30, 25, 25, 35, 35})
+	rg.addTable(pdf, nodeData, []float64{25, 25, 22, 22, 22, 34, 30})
+	pdf.Ln(5)
+
+	if report.Fairness != nil {
+		pdf.SetFont("Arial", "B", 12)
+		pdf.CellFormat(0, 8, "Load Fairness", "", 1, "L", false, 0, "")
+		pdf.SetFont("Arial", "", 10)
+
+		fairnessData := [][]string{
+			{"Metric", "Initiated", "Received"},
+			{"Min / Max per Node", fmt.Sprintf("%d / %d", report.Fairness.Initiated.Min, report.Fairness.Initiated.Max),
+				fmt.Sprintf("%d / %d", report.Fairness.Received.Min, report.Fairness.Received.Max)},
+			{"Min/Max Ratio (1 = even)", fmt.Sprintf("%.2f", report.Fairness.Initiated.MinMaxRatio),
+				fmt.Sprintf("%.2f", report.Fairness.Received.MinMaxRatio)},
+			{"Gini (0 = even)", fmt.Sprintf("%.3f", report.Fairness.Initiated.Gini),
+				fmt.Sprintf("%.3f", report.Fairness.Received.Gini)},
+		}
+		rg.addTable(pdf, fairnessData, []float64{60, 50, 50})
+	}
 	pdf.Ln(10)
 }
 
@@ -259,14 +587,7 @@ func (rg *ReportGenerator) addTransactionDetails(pdf *fpdf.Fpdf, report *models.
 	pdf.CellFormat(0, 10, "Transaction Log (Sorted by Token Amount)", "", 1, "L", false, 0, "")
 	pdf.SetFont("Arial", "", 8)
 
-	// Create a copy of transactions to sort (to avoid modifying original)
-	sortedTransactions := make([]models.Transaction, len(report.Transactions))
-	copy(sortedTransactions, report.Transactions)
-
-	// Sort by token amount (ascending)
-	sort.Slice(sortedTransactions, func(i, j int) bool {
-		return sortedTransactions[i].TokenAmount < sortedTransactions[j].TokenAmount
-	})
+	sortedTransactions := sortedByTokenAmount(report.Transactions)
 
 	maxTransactions := 50
 	if len(sortedTransactions) < maxTransactions {
@@ -276,28 +597,24 @@ func (rg *ReportGenerator) addTransactionDetails(pdf *fpdf.Fpdf, report *models.
 	// Prepare table data with links
 
 	tableData := []TableRowData{
-		{cells: []string{"TX ID", "Tokens", "Time", "Status", "Node"}, links: []string{"", "", "", "", ""}}, // Header row
+		{cells: []string{"TX ID", "Requested", "Tokens", "Time", "Status", "Node"}, links: []string{"", "", "", "", "", ""}}, // Header row
 	}
 
+	nodeName := nodeNamer(report)
 	for i := 0; i < maxTransactions; i++ {
 		tx := sortedTransactions[i]
 
 		// Get transaction display text and link
 		txIDDisplay, explorerURL := rg.formatTransactionDisplay(tx)
 
-		// Safely truncate node ID
-		nodeIDDisplay := tx.NodeID
-		if len(nodeIDDisplay) > 8 {
-			nodeIDDisplay = nodeIDDisplay[:8]
-		}
-
 		rowData := TableRowData{
 			cells: []string{
 				txIDDisplay,
+				fmt.Sprintf("%.3f", tx.RequestedAmount),
 				fmt.Sprintf("%.3f", tx.TokenAmount),
 				formatDuration(tx.TimeTaken),
 				tx.Status,
-				nodeIDDisplay,
+				nodeName(tx.NodeID, 14),
 			},
 			links: []string{
 				explorerURL, // Link for transaction ID column
@@ -305,13 +622,14 @@ func (rg *ReportGenerator) addTransactionDetails(pdf *fpdf.Fpdf, report *models.
 				"",
 				"",
 				"",
+				"",
 			},
 		}
 
 		tableData = append(tableData, rowData)
 	}
 
-	rg.addTableWithLinks(pdf, tableData, []float64{30, 25, 25, 30, 30})
+	rg.addTableWithLinks(pdf, tableData, []float64{30, 25, 25, 25, 25, 30})
 
 	if len(sortedTransactions) > maxTransactions {
 		pdf.SetFont("Arial", "I", 8)
@@ -319,102 +637,331 @@ func (rg *ReportGenerator) addTransactionDetails(pdf *fpdf.Fpdf, report *models.
 	}
 }
 
+// sortedByTokenAmount returns a copy of transactions sorted by token amount, ascending
+func sortedByTokenAmount(transactions []models.Transaction) []models.Transaction {
+	sorted := make([]models.Transaction, len(transactions))
+	copy(sorted, transactions)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].TokenAmount < sorted[j].TokenAmount
+	})
+	return sorted
+}
+
+// addFailuresAppendix lists every failed transaction, independent of the sorted and
+// truncated transaction log, so failures are never hidden
+func (rg *ReportGenerator) addFailuresAppendix(pdf *fpdf.Fpdf, report *models.SimulationReport) {
+	pdf.AddPage()
+	pdf.SetFont("Arial", "B", 14)
+	pdf.CellFormat(0, 10, "Appendix: Failures", "", 1, "L", false, 0, "")
+
+	failures := failedTransactions(report.Transactions)
+	if len(failures) == 0 {
+		pdf.SetFont("Arial", "", 10)
+		pdf.CellFormat(0, 8, "No failures", "", 1, "L", false, 0, "")
+		return
+	}
+
+	nodeName := nodeNamer(report)
+	nodeIDByDID := make(map[string]string)
+	for _, node := range report.Nodes {
+		nodeIDByDID[node.DID] = node.ID
+	}
+	receiverDisplay := func(did string) string {
+		if nodeID, ok := nodeIDByDID[did]; ok {
+			return nodeName(nodeID, 12)
+		}
+		if len(did) > 12 {
+			return did[:12] + "..."
+		}
+		return did
+	}
+
+	pdf.SetFont("Arial", "", 10)
+	pdf.CellFormat(0, 8, fmt.Sprintf("%d failed transaction(s)", len(failures)), "", 1, "L", false, 0, "")
+
+	rg.addTable(pdf, failureCategoryRows(report, len(failures)), []float64{60, 30, 30})
+	pdf.Ln(5)
+
+	pdf.SetFont("Arial", "", 8)
+
+	failureData := [][]string{
+		{"#", "Sender", "Receiver", "Amount", "Reason", "Error"},
+	}
+	for i, tx := range failures {
+		errorSnippet := tx.Error
+		if len(errorSnippet) > 40 {
+			errorSnippet = errorSnippet[:37] + "..."
+		}
+		failureData = append(failureData, []string{
+			fmt.Sprintf("%d", i+1),
+			nodeName(tx.NodeID, 12),
+			receiverDisplay(tx.Receiver),
+			fmt.Sprintf("%.3f", tx.RequestedAmount),
+			categorizeFailure(tx.Error),
+			errorSnippet,
+		})
+	}
+
+	rg.addTable(pdf, failureData, []float64{10, 22, 22, 18, 30, 88})
+}
+
+// failedTransactions returns the transactions that failed, in run order
+func failedTransactions(transactions []models.Transaction) []models.Transaction {
+	var failures []models.Transaction
+	for _, tx := range transactions {
+		if tx.Status == "failed" {
+			failures = append(failures, tx)
+		}
+	}
+	return failures
+}
+
+// failureCategoryRows builds the failure count per category table, header row first, with
+// each category's share of the failed transactions
+func failureCategoryRows(report *models.SimulationReport, failed int) [][]string {
+	breakdown := report.FailureBreakdown
+	if len(breakdown) == 0 {
+		// Reports saved before the breakdown existed
+		breakdown = failureBreakdown(report.Transactions)
+	}
+	categoryData := [][]string{{"Category", "Count", "Share"}}
+	for _, category := range breakdown {
+		categoryData = append(categoryData, []string{
+			category.Category,
+			fmt.Sprintf("%d", category.Count),
+			fmt.Sprintf("%.1f%%", float64(category.Count)/float64(failed)*100),
+		})
+	}
+	return categoryData
+}
+
+// Failure categories used by categorizeFailure
+const (
+	failureInsufficientBalance = "Insufficient balance"
+	failureTimeout             = "Timeout"
+	failureConsensus           = "Consensus failure"
+	failureSignature           = "Signature failure"
+	failureNetwork             = "Network error"
+	failureOther               = "Other"
+)
+
+// categorizeFailure maps a transaction error message to a failure category. Network
+// errors are matched before timeouts so a dial timeout isn't counted as a slow node, and
+// both before signatures since most transfer errors mention the signature step they hit.
+func categorizeFailure(errMsg string) string {
+	msg := strings.ToLower(errMsg)
+	switch {
+	case strings.Contains(msg, "insufficient balance"):
+		return failureInsufficientBalance
+	case strings.Contains(msg, "connection refused") || strings.Contains(msg, "connection reset") ||
+		strings.Contains(msg, "no such host") || strings.Contains(msg, "dial tcp") ||
+		strings.Contains(msg, "eof") || strings.Contains(msg, "failed to check balance"):
+		return failureNetwork
+	case strings.Contains(msg, "timeout") || strings.Contains(msg, "deadline exceeded"):
+		return failureTimeout
+	case strings.Contains(msg, "signature"):
+		return failureSignature
+	case strings.Contains(msg, "consensus") || strings.Contains(msg, "quorum"):
+		return failureConsensus
+	default:
+		return failureOther
+	}
+}
+
 func (rg *ReportGenerator) addCharts(pdf *fpdf.Fpdf, report *models.SimulationReport) {
 	pdf.AddPage()
 	pdf.SetFont("Arial", "B", 14)
 	pdf.CellFormat(0, 10, "Performance Chart", "", 1, "L", false, 0, "")
 
 	rg.drawAvgTimeVsTokenRangeChart(pdf, report, 30, 40)
+	rg.drawThroughputChart(pdf, report, 30, 160)
+
+	if len(report.ThroughputSeries) > 0 {
+		pdf.AddPage()
+		rg.drawSuccessRateChart(pdf, report, 30, 40)
+	}
 }
 
-func (rg *ReportGenerator) drawAvgTimeVsTokenRangeChart(pdf *fpdf.Fpdf, report *models.SimulationReport, x, y float64) {
-	if len(report.Transactions) == 0 {
+// drawThroughputChart plots completed transactions per second for each window of the
+// throughput series, showing whether TPS degrades as the run progresses
+func (rg *ReportGenerator) drawThroughputChart(pdf *fpdf.Fpdf, report *models.SimulationReport, x, y float64) {
+	if len(report.ThroughputSeries) == 0 {
 		return
 	}
 
 	pdf.SetFont("Arial", "B", 12)
 	pdf.SetXY(x, y-10)
-	pdf.CellFormat(150, 10, "Average Time vs. Token Range", "", 0, "C", false, 0, "")
+	pdf.CellFormat(150, 10, "Throughput over Time", "", 0, "C", false, 0, "")
 
-	// Define token ranges
-	ranges := []struct {
-		min, max float64
-		label    string
-	}{
-		{1, 1, "1"},
-		{2, 2, "2"},
-		{3, 3, "3"},
-		{4, 4, "4"},
-		{5, 5, "5"},
-		{6, 6, "6"},
-		{7, 7, "7"},
-		{8, 8, "8"},
-		{9, 9, "9"},
-		{10, 10, "10"},
-	}
-
-	// Calculate average time for each token range
-	rangeAvgTimes := make(map[string]float64)
-	rangeCounts := make(map[string]int)
-	for _, tx := range report.Transactions {
-		if tx.Status == "success" {
-			for _, r := range ranges {
-				if tx.TokenAmount >= r.min && tx.TokenAmount <= r.max {
-					rangeAvgTimes[r.label] += float64(tx.TimeTaken.Milliseconds())
-					rangeCounts[r.label]++
-					break
-				}
-			}
+	// Chart dimensions
+	chartWidth := float64(150)
+	chartHeight := float64(80)
+	chartX := x
+	chartY := y
+
+	points := throughputPoints(report.ThroughputSeries)
+	maxTPS := maxChartValue(points)
+
+	// Y-axis labels (transactions per second)
+	drawChartAxes(pdf, chartX, chartY, chartWidth, chartHeight, maxTPS, "%.2f")
+
+	// A single window is drawn as a point in the middle of the chart
+	buckets := report.ThroughputSeries
+	xPosFor := func(i int) float64 {
+		if len(buckets) == 1 {
+			return chartX + chartWidth/2
 		}
+		return chartX + (float64(i) * chartWidth / float64(len(buckets)-1))
 	}
 
+	// X-axis labels (window start in seconds), thinned to at most ~10 labels
+	labelEvery := (len(buckets) + 9) / 10
+	for i, point := range points {
+		if i%labelEvery != 0 {
+			continue
+		}
+		xPos := xPosFor(i)
+		pdf.Line(xPos, chartY, xPos, chartY+chartHeight)
+		pdf.SetXY(xPos-5, chartY+chartHeight+2)
+		pdf.CellFormat(10, 5, point.label, "", 0, "C", false, 0, "")
+	}
+
+	// Plot data points as a line chart
+	pdf.SetDrawColor(76, 175, 80) // Green for the line
+	pdf.SetFillColor(76, 175, 80)
+	pdf.SetLineWidth(0.5)
+	var lastX, lastY float64 = -1, -1
+
+	for i, point := range points {
+		xPos := xPosFor(i)
+		yPos := chartY + chartHeight - ((point.value / maxTPS) * chartHeight)
+
+		if lastX != -1 {
+			pdf.Line(lastX, lastY, xPos, yPos)
+		}
+		pdf.Circle(xPos, yPos, 0.8, "F")
+		lastX, lastY = xPos, yPos
+	}
+	pdf.SetLineWidth(0.2)
+
+	drawAxisTitles(pdf, chartX, chartY, chartWidth, chartHeight, "Elapsed Time (s)", "TPS")
+}
+
+// successRateRollingWindows is how many throughput windows each point of the success
+// rate chart covers, smoothing out windows with only a transaction or two
+const successRateRollingWindows = 3
+
+// drawSuccessRateChart plots the success rate over a rolling span of throughput windows.
+// A line that slopes down as the run goes on points at senders running out of balance,
+// which the overall percentage hides.
+func (rg *ReportGenerator) drawSuccessRateChart(pdf *fpdf.Fpdf, report *models.SimulationReport, x, y float64) {
+	buckets := report.ThroughputSeries
+	if len(buckets) == 0 {
+		return
+	}
+
+	pdf.SetFont("Arial", "B", 12)
+	pdf.SetXY(x, y-10)
+	pdf.CellFormat(150, 10, fmt.Sprintf("Success Rate over Time (rolling %d windows)", successRateRollingWindows), "", 0, "C", false, 0, "")
+
 	// Chart dimensions
 	chartWidth := float64(150)
 	chartHeight := float64(80)
 	chartX := x
 	chartY := y
 
-	// Draw axes
-	pdf.SetDrawColor(0, 0, 0)
-	pdf.Line(chartX, chartY+chartHeight, chartX+chartWidth, chartY+chartHeight) // X-axis
-	pdf.Line(chartX, chartY, chartX, chartY+chartHeight)                         // Y-axis
+	// Y-axis labels (percent)
+	drawChartAxes(pdf, chartX, chartY, chartWidth, chartHeight, 100, "%.0f")
 
-	// Find min/max values for scaling
-	maxAvgTime := 0.0
-	for label, totalTime := range rangeAvgTimes {
-		count := rangeCounts[label]
-		if count > 0 {
-			avgTime := (totalTime / float64(count)) / 1000.0 // Convert to seconds
-			if avgTime > maxAvgTime {
-				maxAvgTime = avgTime
-			}
+	// A single window is drawn as a point in the middle of the chart
+	xPosFor := func(i int) float64 {
+		if len(buckets) == 1 {
+			return chartX + chartWidth/2
 		}
+		return chartX + (float64(i) * chartWidth / float64(len(buckets)-1))
 	}
 
-	if maxAvgTime == 0 {
-		maxAvgTime = 1 // Avoid division by zero
+	// X-axis labels (window start in seconds), thinned to at most ~10 labels
+	points := rollingSuccessRates(buckets)
+	labelEvery := (len(buckets) + 9) / 10
+	for i, point := range points {
+		if i%labelEvery != 0 {
+			continue
+		}
+		xPos := xPosFor(i)
+		pdf.Line(xPos, chartY, xPos, chartY+chartHeight)
+		pdf.SetXY(xPos-5, chartY+chartHeight+2)
+		pdf.CellFormat(10, 5, point.label, "", 0, "C", false, 0, "")
 	}
 
-	// Draw grid lines and labels
-	pdf.SetDrawColor(200, 200, 200)
-	pdf.SetFont("Arial", "", 8)
+	// Plot the rolling rate; spans without transactions are skipped
+	pdf.SetDrawColor(244, 67, 54) // Red for the line
+	pdf.SetFillColor(244, 67, 54)
+	pdf.SetLineWidth(0.5)
+	var lastX, lastY float64 = -1, -1
 
-	// Y-axis labels (time in seconds)
-	for i := 0; i <= 4; i++ {
-		yPos := chartY + chartHeight - (float64(i) * chartHeight / 4)
-		pdf.Line(chartX, yPos, chartX+chartWidth, yPos)
+	for i, point := range points {
+		if !point.hasValue {
+			continue
+		}
 
-		timeValue := (float64(i) * maxAvgTime / 4)
-		pdf.SetXY(chartX-15, yPos-2)
-		pdf.CellFormat(10, 5, fmt.Sprintf("%.2f", timeValue), "", 0, "R", false, 0, "")
+		xPos := xPosFor(i)
+		yPos := chartY + chartHeight - (point.value / 100 * chartHeight)
+
+		if lastX != -1 {
+			pdf.Line(lastX, lastY, xPos, yPos)
+		}
+		pdf.Circle(xPos, yPos, 0.8, "F")
+		lastX, lastY = xPos, yPos
+	}
+	pdf.SetLineWidth(0.2)
+
+	drawAxisTitles(pdf, chartX, chartY, chartWidth, chartHeight, "Elapsed Time (s)", "Success (%)")
+}
+
+func (rg *ReportGenerator) drawAvgTimeVsTokenRangeChart(pdf *fpdf.Fpdf, report *models.SimulationReport, x, y float64) {
+	if len(report.Transactions) == 0 {
+		return
+	}
+
+	pdf.SetFont("Arial", "B", 12)
+	pdf.SetXY(x, y-10)
+	pdf.CellFormat(150, 10, "Average Time vs. Token Range", "", 0, "C", false, 0, "")
+
+	// Same ranges as the token analysis table
+	ranges := avgTimeByTokenRange(report, rg.config.TokenRangeBuckets)
+
+	// Chart dimensions
+	chartWidth := float64(150)
+	chartHeight := float64(80)
+	chartX := x
+	chartY := y
+
+	// Points are spread across the axis; a single range sits in the middle
+	pointSpacing := chartWidth
+	if len(ranges) > 1 {
+		pointSpacing = chartWidth / float64(len(ranges)-1)
 	}
+	pointX := func(i int) float64 {
+		if len(ranges) == 1 {
+			return chartX + chartWidth/2
+		}
+		return chartX + float64(i)*pointSpacing
+	}
+
+	// Find max value for scaling
+	maxAvgTime := maxChartValue(ranges)
+
+	// Y-axis labels (time in seconds)
+	drawChartAxes(pdf, chartX, chartY, chartWidth, chartHeight, maxAvgTime, "%.2f")
 
 	// X-axis labels (token ranges)
+	pdf.SetFont("Arial", "", 6)
 	for i, r := range ranges {
-		xPos := chartX + (float64(i) * chartWidth / float64(len(ranges)-1))
+		xPos := pointX(i)
 		pdf.Line(xPos, chartY, xPos, chartY+chartHeight)
-		pdf.SetXY(xPos-5, chartY+chartHeight+2)
-		pdf.CellFormat(10, 5, r.label, "", 0, "C", false, 0, "")
+		pdf.SetXY(xPos-pointSpacing/2, chartY+chartHeight+2)
+		pdf.CellFormat(pointSpacing, 5, r.label, "", 0, "C", false, 0, "")
 	}
 
 	// Plot data points as a line chart
@@ -423,12 +970,10 @@ func (rg *ReportGenerator) drawAvgTimeVsTokenRangeChart(pdf *fpdf.Fpdf, report *
 	var lastX, lastY float64 = -1, -1
 
 	for i, r := range ranges {
-		if count, ok := rangeCounts[r.label]; ok && count > 0 {
-			avgTime := (rangeAvgTimes[r.label] / float64(count)) / 1000.0 // Convert to seconds
-
+		if r.hasValue {
 			// Calculate position
-			xPos := chartX + (float64(i) * chartWidth / float64(len(ranges)-1))
-			yPos := chartY + chartHeight - ((avgTime / maxAvgTime) * chartHeight)
+			xPos := pointX(i)
+			yPos := chartY + chartHeight - ((r.value / maxAvgTime) * chartHeight)
 
 			if lastX != -1 {
 				pdf.Line(lastX, lastY, xPos, yPos)
@@ -437,13 +982,123 @@ func (rg *ReportGenerator) drawAvgTimeVsTokenRangeChart(pdf *fpdf.Fpdf, report *
 		}
 	}
 
-	// Add axis labels
+	drawAxisTitles(pdf, chartX, chartY, chartWidth, chartHeight, "Token Range", "Avg Time (s)")
+}
+
+// chartPoint is one point of a report chart. A point without a value has no
+// transactions behind it and is not plotted.
+type chartPoint struct {
+	label    string
+	value    float64
+	hasValue bool
+}
+
+// avgTimeByTokenRange returns the average time in seconds of the successful transactions
+// in each of the token analysis ranges
+func avgTimeByTokenRange(report *models.SimulationReport, buckets int) []chartPoint {
+	ranges := tokenRanges(report.Transactions, buckets)
+	totals := make([]time.Duration, len(ranges))
+	counts := make([]int, len(ranges))
+	for _, tx := range report.Transactions {
+		if tx.Status != "success" {
+			continue
+		}
+		for i, r := range ranges {
+			if r.contains(tx.TokenAmount) {
+				totals[i] += tx.TimeTaken
+				counts[i]++
+				break
+			}
+		}
+	}
+
+	points := make([]chartPoint, len(ranges))
+	for i, r := range ranges {
+		points[i].label = r.label
+		if counts[i] > 0 {
+			points[i].value = (totals[i] / time.Duration(counts[i])).Seconds()
+			points[i].hasValue = true
+		}
+	}
+	return points
+}
+
+// throughputPoints returns the TPS of each throughput window, labelled with the window's
+// start in seconds
+func throughputPoints(series []models.ThroughputBucket) []chartPoint {
+	points := make([]chartPoint, len(series))
+	for i, bucket := range series {
+		points[i] = chartPoint{label: fmt.Sprintf("%.0f", bucket.OffsetSeconds), value: bucket.TPS, hasValue: true}
+	}
+	return points
+}
+
+// rollingSuccessRates returns, for each throughput window, the success percentage over the
+// successRateRollingWindows windows ending with it. Spans without transactions have no value.
+func rollingSuccessRates(series []models.ThroughputBucket) []chartPoint {
+	points := make([]chartPoint, len(series))
+	for i, bucket := range series {
+		points[i].label = fmt.Sprintf("%.0f", bucket.OffsetSeconds)
+		completed, successful := 0, 0
+		for j := i - successRateRollingWindows + 1; j <= i; j++ {
+			if j >= 0 {
+				completed += series[j].Completed
+				successful += series[j].Successful
+			}
+		}
+		if completed > 0 {
+			points[i].value = float64(successful) / float64(completed) * 100
+			points[i].hasValue = true
+		}
+	}
+	return points
+}
+
+// maxChartValue returns the largest value among points, or 1 when there is none so a
+// chart can always scale by it
+func maxChartValue(points []chartPoint) float64 {
+	maxValue := 0.0
+	for _, point := range points {
+		if point.hasValue && point.value > maxValue {
+			maxValue = point.value
+		}
+	}
+	if maxValue == 0 {
+		return 1 // Avoid division by zero
+	}
+	return maxValue
+}
+
+// drawChartAxes draws a chart's axes and grid, labelling the y-axis from 0 to maxValue
+// with format. It leaves the grid colour and a small font set for the x-axis labels.
+func drawChartAxes(pdf *fpdf.Fpdf, chartX, chartY, chartWidth, chartHeight, maxValue float64, format string) {
+	// Draw axes
+	pdf.SetDrawColor(0, 0, 0)
+	pdf.Line(chartX, chartY+chartHeight, chartX+chartWidth, chartY+chartHeight) // X-axis
+	pdf.Line(chartX, chartY, chartX, chartY+chartHeight)                         // Y-axis
+
+	// Draw grid lines and labels
+	pdf.SetDrawColor(200, 200, 200)
+	pdf.SetFont("Arial", "", 8)
+
+	for i := 0; i <= 4; i++ {
+		yPos := chartY + chartHeight - (float64(i) * chartHeight / 4)
+		pdf.Line(chartX, yPos, chartX+chartWidth, yPos)
+
+		value := (float64(i) * maxValue / 4)
+		pdf.SetXY(chartX-15, yPos-2)
+		pdf.CellFormat(10, 5, fmt.Sprintf(format, value), "", 0, "R", false, 0, "")
+	}
+}
+
+// drawAxisTitles writes the titles under the x-axis and beside the y-axis of a chart
+func drawAxisTitles(pdf *fpdf.Fpdf, chartX, chartY, chartWidth, chartHeight float64, xTitle, yTitle string) {
 	pdf.SetFont("Arial", "", 9)
 	pdf.SetXY(chartX+chartWidth/2-20, chartY+chartHeight+10)
-	pdf.CellFormat(40, 5, "Token Range", "", 0, "C", false, 0, "")
+	pdf.CellFormat(40, 5, xTitle, "", 0, "C", false, 0, "")
 
 	pdf.SetXY(chartX-25, chartY+chartHeight/2-5)
-	pdf.CellFormat(20, 5, "Avg Time (s)", "", 0, "C", false, 0, "")
+	pdf.CellFormat(20, 5, yTitle, "", 0, "C", false, 0, "")
 }
 
 func (rg *ReportGenerator) addTable(pdf *fpdf.Fpdf, data [][]string, widths []float64) {
@@ -464,6 +1119,70 @@ func (rg *ReportGenerator) addTable(pdf *fpdf.Fpdf, data [][]string, widths []fl
 	}
 }
 
+// addExplorerLinks lists every successful transaction that has an on-chain ID, linked to
+// its record on the explorer
+func (rg *ReportGenerator) addExplorerLinks(pdf *fpdf.Fpdf, report *models.SimulationReport) {
+	tableData := []TableRowData{
+		{cells: []string{"#", "Transaction", "Sender Node", "Tokens", "Time"}, links: []string{"", "", "", "", ""}},
+	}
+	nodeName := nodeNamer(report)
+	for i, tx := range report.Transactions {
+		link := rg.buildExplorerLink(tx.ID)
+		if tx.Status != "success" || link == "" {
+			continue
+		}
+
+		txIDDisplay := tx.ID
+		if len(txIDDisplay) > 24 {
+			txIDDisplay = txIDDisplay[:24] + "..."
+		}
+		tableData = append(tableData, TableRowData{
+			cells: []string{
+				fmt.Sprintf("%d", i+1),
+				txIDDisplay,
+				nodeName(tx.NodeID, 18),
+				fmt.Sprintf("%.3f", tx.TokenAmount),
+				formatDuration(tx.TimeTaken),
+			},
+			links: []string{"", link, "", "", ""},
+		})
+	}
+	if len(tableData) == 1 {
+		return
+	}
+
+	pdf.AddPage()
+	pdf.SetFont("Arial", "B", 14)
+	pdf.CellFormat(0, 10, "Explorer Links", "", 1, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 9)
+	pdf.CellFormat(0, 6, fmt.Sprintf("%d on-chain transactions; click an ID to open it on the explorer.", len(tableData)-1), "", 1, "L", false, 0, "")
+	pdf.Ln(2)
+
+	rg.addTableWithLinks(pdf, tableData, []float64{15, 70, 35, 30, 30})
+	pdf.Ln(10)
+}
+
+// nodeNamer returns a function naming a node in PDF tables: its label when it had one
+// during the run, otherwise its ID, cut to maxLen characters to fit the column
+func nodeNamer(report *models.SimulationReport) func(nodeID string, maxLen int) string {
+	labels := make(map[string]string)
+	for _, node := range report.Nodes {
+		if node.Label != "" {
+			labels[node.ID] = node.Label
+		}
+	}
+	return func(nodeID string, maxLen int) string {
+		name := nodeID
+		if label, ok := labels[nodeID]; ok {
+			name = label
+		}
+		if len(name) > maxLen {
+			name = name[:maxLen]
+		}
+		return name
+	}
+}
+
 // addTableWithLinks creates a table with clickable links support
 func (rg *ReportGenerator) addTableWithLinks(pdf *fpdf.Fpdf, data []TableRowData, widths []float64) {
 	for i, rowData := range data {
@@ -500,7 +1219,44 @@ func (rg *ReportGenerator) GetReportPath(filename string) string {
 	return filepath.Join(rg.reportsPath, filename)
 }
 
+// ListReports returns the generated reports from the index, falling back to scanning the
+// reports directory when the index is disabled. A missing index is rebuilt from the scan.
 func (rg *ReportGenerator) ListReports() ([]models.ReportInfo, error) {
+	if rg.indexPath == "" {
+		return rg.scanReports()
+	}
+
+	rg.indexMu.Lock()
+	defer rg.indexMu.Unlock()
+
+	reports, err := rg.readIndex()
+	if err == nil {
+		// Indexes written before derived PDFs were left out may still list them
+		listed := reports[:0]
+		for _, entry := range reports {
+			if !isDerivedReport(entry.Filename) {
+				listed = append(listed, entry)
+			}
+		}
+		return listed, nil
+	}
+	if !os.IsNotExist(err) {
+		logging.Warnf("Warning: reports index unreadable, rebuilding: %v", err)
+	}
+
+	reports, err = rg.scanReports()
+	if err != nil {
+		return nil, err
+	}
+	if err := rg.writeIndex(reports); err != nil {
+		logging.Warnf("Warning: failed to write reports index: %v", err)
+	}
+	return reports, nil
+}
+
+// scanReports lists the PDF files in the reports directory with file metadata only,
+// leaving out derived PDFs
+func (rg *ReportGenerator) scanReports() ([]models.ReportInfo, error) {
 	files, err := os.ReadDir(rg.reportsPath)
 	if err != nil {
 		return nil, err
@@ -508,20 +1264,231 @@ func (rg *ReportGenerator) ListReports() ([]models.ReportInfo, error) {
 
 	var reports []models.ReportInfo
 	for _, file := range files {
-		if filepath.Ext(file.Name()) == ".pdf" {
+		if filepath.Ext(file.Name()) == ".pdf" && !isDerivedReport(file.Name()) {
 			info, err := file.Info()
 			if err != nil {
 				continue
 			}
 
-			reports = append(reports, models.ReportInfo{
-				ID:        file.Name()[:len(file.Name())-4],
-				Filename:  file.Name(),
-				CreatedAt: info.ModTime(),
-				Size:      info.Size(),
-			})
+			entry := models.ReportInfo{
+				ID:       file.Name()[:len(file.Name())-4],
+				Filename: file.Name(),
+				Path:     filepath.Join(rg.reportsPath, file.Name()),
+			}
+			// Recover full metrics from the run's saved JSON report when there is one
+			if report, err := rg.readReportJSON(entry.ID + ".json"); err == nil {
+				entry = newReportInfo(report, file.Name(), entry.Path)
+			}
+			entry.CreatedAt = info.ModTime()
+			entry.Size = info.Size()
+
+			reports = append(reports, entry)
 		}
 	}
 
 	return reports, nil
+}
+
+// isDerivedReport reports whether a PDF is derived from a run's report, such as a
+// filtered view saved by earlier versions, rather than the run's own report. Derived
+// PDFs are not listed.
+func isDerivedReport(filename string) bool {
+	return strings.HasSuffix(filename, "-filtered.pdf")
+}
+
+// addToIndex records a newly written PDF in the reports index, replacing any earlier
+// entry for the same file. Derived PDFs are left out.
+func (rg *ReportGenerator) addToIndex(report *models.SimulationReport, filename string) {
+	if rg.indexPath == "" || isDerivedReport(filename) {
+		return
+	}
+
+	path := filepath.Join(rg.reportsPath, filename)
+	entry := newReportInfo(report, filename, path)
+	entry.CreatedAt = time.Now()
+	if info, err := os.Stat(path); err == nil {
+		entry.Size = info.Size()
+	}
+
+	rg.indexMu.Lock()
+	defer rg.indexMu.Unlock()
+
+	reports, err := rg.readIndex()
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logging.Warnf("Warning: reports index unreadable, rebuilding: %v", err)
+		}
+		// Seed a missing or broken index from whatever is already on disk
+		if reports, err = rg.scanReports(); err != nil {
+			logging.Warnf("Warning: failed to scan reports for index: %v", err)
+		}
+	}
+
+	updated := make([]models.ReportInfo, 0, len(reports)+1)
+	for _, existing := range reports {
+		if existing.Filename != filename {
+			updated = append(updated, existing)
+		}
+	}
+	updated = append(updated, entry)
+
+	if err := rg.writeIndex(updated); err != nil {
+		logging.Warnf("Warning: failed to update reports index: %v", err)
+	}
+}
+
+// newReportInfo builds the list entry for a report file from the simulation's metrics
+func newReportInfo(report *models.SimulationReport, filename, path string) models.ReportInfo {
+	entry := models.ReportInfo{
+		ID:           strings.TrimSuffix(filename, ".pdf"),
+		Filename:     filename,
+		SimulationID: report.SimulationID,
+		Path:         path,
+		Nodes:        len(report.Nodes),
+		Transactions: report.TotalTransactions,
+		SuccessCount: report.SuccessCount,
+		FailureCount: report.FailureCount,
+	}
+	if completed := report.SuccessCount + report.FailureCount; completed > 0 {
+		entry.SuccessRate = float64(report.SuccessCount) / float64(completed) * 100
+	}
+
+	shortID := report.SimulationID
+	if len(shortID) > 8 {
+		shortID = shortID[:8]
+	}
+	entry.Name = "Simulation " + shortID
+	if report.Filter != nil {
+		entry.Name += " (filtered)"
+		entry.Tags = append(entry.Tags, "filtered")
+	}
+	if report.FailureCount > 0 {
+		entry.Tags = append(entry.Tags, "has-failures")
+	}
+	if report.Error != "" {
+		entry.Tags = append(entry.Tags, "error")
+	}
+	return entry
+}
+
+// SaveReportJSON writes a finished simulation report to simulation-<id>.json in the
+// reports directory, next to its PDF, so it outlives the in-memory simulation state
+func (rg *ReportGenerator) SaveReportJSON(report *models.SimulationReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(rg.reportsPath, fmt.Sprintf("simulation-%s.json", report.SimulationID))
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// LoadReportJSON reads a simulation report saved by SaveReportJSON
+func (rg *ReportGenerator) LoadReportJSON(simulationID string) (*models.SimulationReport, error) {
+	return rg.readReportJSON(fmt.Sprintf("simulation-%s.json", simulationID))
+}
+
+func (rg *ReportGenerator) readReportJSON(filename string) (*models.SimulationReport, error) {
+	data, err := os.ReadFile(filepath.Join(rg.reportsPath, filename))
+	if err != nil {
+		return nil, err
+	}
+	var report models.SimulationReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// PruneReports deletes report files (PDF, JSON and CSV) last modified before the cutoff and
+// drops them from the reports index. It returns the names of the deleted files.
+func (rg *ReportGenerator) PruneReports(cutoff time.Time) ([]string, error) {
+	files, err := os.ReadDir(rg.reportsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	for _, file := range files {
+		if file.IsDir() || filepath.Join(rg.reportsPath, file.Name()) == rg.indexPath {
+			continue
+		}
+		switch filepath.Ext(file.Name()) {
+		case ".pdf", ".json", ".csv":
+		default:
+			continue
+		}
+		info, err := file.Info()
+		if err != nil || !info.ModTime().Before(cutoff) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(rg.reportsPath, file.Name())); err != nil {
+			logging.Warnf("Warning: failed to remove old report %s: %v", file.Name(), err)
+			continue
+		}
+		removed = append(removed, file.Name())
+	}
+
+	if len(removed) > 0 && rg.indexPath != "" {
+		rg.removeFromIndex(removed)
+	}
+	return removed, nil
+}
+
+// removeFromIndex drops entries for deleted files from the reports index
+func (rg *ReportGenerator) removeFromIndex(filenames []string) {
+	rg.indexMu.Lock()
+	defer rg.indexMu.Unlock()
+
+	reports, err := rg.readIndex()
+	if err != nil {
+		// A missing index is rebuilt from the directory on the next listing anyway
+		if !os.IsNotExist(err) {
+			logging.Warnf("Warning: reports index unreadable, not updated: %v", err)
+		}
+		return
+	}
+
+	deleted := make(map[string]bool, len(filenames))
+	for _, name := range filenames {
+		deleted[name] = true
+	}
+	kept := make([]models.ReportInfo, 0, len(reports))
+	for _, report := range reports {
+		if !deleted[report.Filename] {
+			kept = append(kept, report)
+		}
+	}
+	if err := rg.writeIndex(kept); err != nil {
+		logging.Warnf("Warning: failed to update reports index: %v", err)
+	}
+}
+
+// readIndex loads the reports index. Caller must hold rg.indexMu.
+func (rg *ReportGenerator) readIndex() ([]models.ReportInfo, error) {
+	data, err := os.ReadFile(rg.indexPath)
+	if err != nil {
+		return nil, err
+	}
+	var reports []models.ReportInfo
+	if err := json.Unmarshal(data, &reports); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// writeIndex atomically replaces the reports index. Caller must hold rg.indexMu.
+func (rg *ReportGenerator) writeIndex(reports []models.ReportInfo) error {
+	data, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmpPath := rg.indexPath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, rg.indexPath)
 }
\ No newline at end of file
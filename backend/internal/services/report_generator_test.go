@@ -0,0 +1,131 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rubix-simulator/backend/internal/config"
+	"github.com/rubix-simulator/backend/internal/models"
+)
+
+func TestReportsIndexSkipsFilteredPDFs(t *testing.T) {
+	rg, err := NewReportGenerator(&config.Config{ReportsPath: t.TempDir(), ReportsIndex: "index.json"})
+	if err != nil {
+		t.Fatalf("NewReportGenerator: %v", err)
+	}
+
+	report := &models.SimulationReport{SimulationID: "sim-index", IsFinished: true}
+	if _, err := rg.GeneratePDF(report); err != nil {
+		t.Fatalf("GeneratePDF: %v", err)
+	}
+	// Earlier versions saved filtered views next to the reports
+	legacy := rg.GetReportPath("simulation-sim-index-filtered.pdf")
+	if err := os.WriteFile(legacy, []byte("%PDF-"), 0o644); err != nil {
+		t.Fatalf("writing legacy filtered PDF: %v", err)
+	}
+	rg.addToIndex(report, filepath.Base(legacy))
+
+	assertListed := func(t *testing.T) {
+		t.Helper()
+		reports, err := rg.ListReports()
+		if err != nil {
+			t.Fatalf("ListReports: %v", err)
+		}
+		if len(reports) != 1 || reports[0].Filename != "simulation-sim-index.pdf" {
+			t.Errorf("listed %+v, want only simulation-sim-index.pdf", reports)
+		}
+	}
+	t.Run("index", assertListed)
+
+	// A rebuilt index is scanned from disk and must skip the filtered PDF too
+	if err := os.Remove(filepath.Join(rg.reportsPath, "index.json")); err != nil {
+		t.Fatalf("removing index: %v", err)
+	}
+	t.Run("rebuilt index", assertListed)
+}
+
+func TestRenderFilteredPDFLeavesNoFile(t *testing.T) {
+	rg, err := NewReportGenerator(&config.Config{ReportsPath: t.TempDir()})
+	if err != nil {
+		t.Fatalf("NewReportGenerator: %v", err)
+	}
+
+	report := &models.SimulationReport{
+		SimulationID: "sim-filtered",
+		IsFinished:   true,
+		Filter:       &models.ReportFilter{Status: "success"},
+	}
+	var pdf bytes.Buffer
+	if err := rg.RenderFilteredPDF(&pdf, report); err != nil {
+		t.Fatalf("RenderFilteredPDF: %v", err)
+	}
+	if !bytes.HasPrefix(pdf.Bytes(), []byte("%PDF-")) {
+		t.Errorf("output does not start like a PDF: %q", pdf.Bytes()[:min(16, pdf.Len())])
+	}
+
+	files, err := os.ReadDir(rg.reportsPath)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(files) != 0 {
+		t.Errorf("reports directory has %d file(s), want none", len(files))
+	}
+}
+
+// failingWriter fails every write, like a client that went away
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("connection reset") }
+
+func TestWriteCSV(t *testing.T) {
+	rg := &ReportGenerator{}
+	report := &models.SimulationReport{SimulationID: "sim-csv", Transactions: []models.Transaction{
+		{ID: "tx1", Sender: "a", Receiver: "b", TokenAmount: 1.5, Status: "success", TimeTaken: 1500 * time.Millisecond, Attempts: 1},
+		{ID: "tx2", Sender: "b", Receiver: "a", TokenAmount: 2, Status: "failed", Error: "boom, again", Attempts: 2},
+	}}
+
+	var out bytes.Buffer
+	if err := rg.WriteCSV(&out, report); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+	rows, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV back: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, want a header and 2 transactions", len(rows))
+	}
+	if rows[1][0] != "tx1" || rows[1][5] != "1500" || rows[2][9] != "boom, again" {
+		t.Errorf("unexpected rows %q", rows[1:])
+	}
+
+	if err := rg.WriteCSV(failingWriter{}, report); err == nil {
+		t.Error("WriteCSV to a failing writer succeeded")
+	}
+}
+
+func TestCategorizeFailure(t *testing.T) {
+	tests := []struct {
+		errMsg string
+		want   string
+	}{
+		{"transfer failed: Insufficient balance", failureInsufficientBalance},
+		{`failed to send signature response: Post "http://localhost:20010/api/signature-response": dial tcp 127.0.0.1:20010: connect: connection refused`, failureNetwork},
+		{"failed to send signature response: dial tcp 127.0.0.1:20010: i/o timeout", failureNetwork},
+		{`failed to send signature response: Post "http://localhost:20010/api/signature-response": context deadline exceeded (Client.Timeout exceeded while awaiting headers)`, failureTimeout},
+		{"signature response failed (status 500): invalid password", failureSignature},
+		{"transfer failed: Consensus failed", failureConsensus},
+		{"transfer failed: not enough quorum peers", failureConsensus},
+		{"transfer failed: Failed to pin token", failureOther},
+	}
+	for _, tt := range tests {
+		if got := categorizeFailure(tt.errMsg); got != tt.want {
+			t.Errorf("categorizeFailure(%q) = %q, want %q", tt.errMsg, got, tt.want)
+		}
+	}
+}
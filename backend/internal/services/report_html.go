@@ -0,0 +1,312 @@
+package services
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/rubix-simulator/backend/internal/models"
+)
+
+// htmlTable is a table of the HTML report. A cell with a link is rendered as an anchor.
+type htmlTable struct {
+	Header []string
+	Rows   [][]htmlCell
+}
+
+type htmlCell struct {
+	Text string
+	Link string
+}
+
+// newHTMLTable turns rows built for the PDF, header row first, into an HTML table
+func newHTMLTable(rows [][]string) htmlTable {
+	var table htmlTable
+	for i, row := range rows {
+		if i == 0 {
+			table.Header = row
+			continue
+		}
+		cells := make([]htmlCell, len(row))
+		for j, text := range row {
+			cells[j] = htmlCell{Text: text}
+		}
+		table.Rows = append(table.Rows, cells)
+	}
+	return table
+}
+
+// htmlReportData is what the HTML report template renders
+type htmlReportData struct {
+	Report             *models.SimulationReport
+	Filter             string
+	Summary            htmlTable
+	Settlement         htmlTable
+	SettlementBalances htmlTable
+	AccountBalances    htmlTable
+	TokenAnalysis      htmlTable
+	Transactions       htmlTable
+	Charts             []template.HTML
+	Failures           int
+	FailureCategories  htmlTable
+	FailureLog         htmlTable
+}
+
+// RenderHTML writes the report to w as a self-contained HTML page, with inline CSS and SVG
+// charts. It has the sections of the PDF, but the transaction log lists every transaction.
+func (rg *ReportGenerator) RenderHTML(w io.Writer, report *models.SimulationReport) error {
+	data := htmlReportData{
+		Report:  report,
+		Summary: newHTMLTable(summaryRows(report)),
+	}
+	if report.Filter != nil {
+		data.Filter = describeFilter(report.Filter)
+	}
+	if report.Settlement != nil {
+		data.Settlement = newHTMLTable(settlementRows(report.Settlement))
+		if len(report.Settlement.NodeBalances) > 0 {
+			data.SettlementBalances = newHTMLTable(settlementBalanceRows(report, maxNodeNameLen))
+		}
+	}
+	if len(report.AccountBalances) > 0 {
+		data.AccountBalances = newHTMLTable(accountBalanceRows(report, maxNodeNameLen))
+	}
+	if len(report.Transactions) > 0 {
+		data.TokenAnalysis = newHTMLTable(tokenAnalysisRows(report, rg.config.TokenRangeBuckets))
+	}
+	data.Transactions = rg.htmlTransactionLog(report)
+	data.Charts = rg.htmlCharts(report)
+
+	failures := failedTransactions(report.Transactions)
+	data.Failures = len(failures)
+	if len(failures) > 0 {
+		data.FailureCategories = newHTMLTable(failureCategoryRows(report, len(failures)))
+		data.FailureLog = htmlFailureLog(report, failures)
+	}
+
+	if err := htmlReportTemplate.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to write HTML report: %v", err)
+	}
+	return nil
+}
+
+// maxNodeNameLen is long enough that HTML tables show node names and IDs in full
+const maxNodeNameLen = 64
+
+// htmlTransactionLog lists every transaction sorted by token amount, with successful
+// transactions linked to the explorer
+func (rg *ReportGenerator) htmlTransactionLog(report *models.SimulationReport) htmlTable {
+	table := htmlTable{Header: []string{"TX ID", "Requested", "Tokens", "Time", "Status", "Node"}}
+	nodeName := nodeNamer(report)
+	for _, tx := range sortedByTokenAmount(report.Transactions) {
+		txIDDisplay, explorerURL := rg.formatTransactionDisplay(tx)
+		table.Rows = append(table.Rows, []htmlCell{
+			{Text: txIDDisplay, Link: explorerURL},
+			{Text: fmt.Sprintf("%.3f", tx.RequestedAmount)},
+			{Text: fmt.Sprintf("%.3f", tx.TokenAmount)},
+			{Text: formatDuration(tx.TimeTaken)},
+			{Text: tx.Status},
+			{Text: nodeName(tx.NodeID, maxNodeNameLen)},
+		})
+	}
+	return table
+}
+
+// htmlFailureLog lists the failed transactions with their full error
+func htmlFailureLog(report *models.SimulationReport, failures []models.Transaction) htmlTable {
+	nodeName := nodeNamer(report)
+	nodeIDByDID := make(map[string]string)
+	for _, node := range report.Nodes {
+		nodeIDByDID[node.DID] = node.ID
+	}
+
+	table := htmlTable{Header: []string{"#", "Sender", "Receiver", "Amount", "Reason", "Error"}}
+	for i, tx := range failures {
+		receiver := tx.Receiver
+		if nodeID, ok := nodeIDByDID[tx.Receiver]; ok {
+			receiver = nodeName(nodeID, maxNodeNameLen)
+		}
+		table.Rows = append(table.Rows, []htmlCell{
+			{Text: fmt.Sprintf("%d", i+1)},
+			{Text: nodeName(tx.NodeID, maxNodeNameLen)},
+			{Text: receiver},
+			{Text: fmt.Sprintf("%.3f", tx.RequestedAmount)},
+			{Text: categorizeFailure(tx.Error)},
+			{Text: tx.Error},
+		})
+	}
+	return table
+}
+
+// htmlCharts draws the PDF's charts as inline SVG
+func (rg *ReportGenerator) htmlCharts(report *models.SimulationReport) []template.HTML {
+	var charts []template.HTML
+	if len(report.Transactions) > 0 {
+		points := avgTimeByTokenRange(report, rg.config.TokenRangeBuckets)
+		charts = append(charts, svgLineChart("Average Time vs. Token Range", points,
+			maxChartValue(points), "%.2f", "Token Range", "Avg Time (s)", "#2196f3"))
+	}
+	if len(report.ThroughputSeries) > 0 {
+		points := throughputPoints(report.ThroughputSeries)
+		charts = append(charts, svgLineChart("Throughput over Time", points,
+			maxChartValue(points), "%.2f", "Elapsed Time (s)", "TPS", "#4caf50"))
+
+		points = rollingSuccessRates(report.ThroughputSeries)
+		charts = append(charts, svgLineChart(fmt.Sprintf("Success Rate over Time (rolling %d windows)", successRateRollingWindows),
+			points, 100, "%.0f", "Elapsed Time (s)", "Success (%)", "#f44336"))
+	}
+	return charts
+}
+
+// Size of the SVG charts and the margins around their plot area, in pixels
+const (
+	svgChartWidth  = 640
+	svgChartHeight = 340
+	svgMarginLeft  = 70
+	svgMarginRight = 30
+	svgMarginTop   = 40
+	svgMarginBot   = 70
+)
+
+// svgLineChart draws points as a line chart scaled from 0 to maxValue, with the y-axis
+// labelled with format. Like the PDF charts, x-axis labels are thinned to about 10 and
+// points without a value are skipped.
+func svgLineChart(title string, points []chartPoint, maxValue float64, format, xTitle, yTitle, color string) template.HTML {
+	plotWidth := float64(svgChartWidth - svgMarginLeft - svgMarginRight)
+	plotHeight := float64(svgChartHeight - svgMarginTop - svgMarginBot)
+	bottom := float64(svgMarginTop) + plotHeight
+	xPos := func(i int) float64 {
+		if len(points) == 1 {
+			return svgMarginLeft + plotWidth/2
+		}
+		return svgMarginLeft + float64(i)*plotWidth/float64(len(points)-1)
+	}
+	yPos := func(value float64) float64 {
+		return bottom - value/maxValue*plotHeight
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" class="chart" role="img">`, svgChartWidth, svgChartHeight)
+	fmt.Fprintf(&b, `<text x="%d" y="20" class="chart-title">%s</text>`, svgChartWidth/2, template.HTMLEscapeString(title))
+
+	// Horizontal grid lines with the y-axis labels
+	for i := 0; i <= 4; i++ {
+		value := float64(i) * maxValue / 4
+		y := yPos(value)
+		fmt.Fprintf(&b, `<line x1="%d" y1="%.1f" x2="%.1f" y2="%.1f" class="grid"/>`, svgMarginLeft, y, svgMarginLeft+plotWidth, y)
+		fmt.Fprintf(&b, `<text x="%d" y="%.1f" class="y-label">%s</text>`, svgMarginLeft-8, y+4, fmt.Sprintf(format, value))
+	}
+
+	// Vertical grid lines with the x-axis labels
+	labelEvery := (len(points) + 9) / 10
+	for i, point := range points {
+		if i%labelEvery != 0 {
+			continue
+		}
+		x := xPos(i)
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%d" x2="%.1f" y2="%.1f" class="grid"/>`, x, svgMarginTop, x, bottom)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" class="x-label">%s</text>`, x, bottom+16, template.HTMLEscapeString(point.label))
+	}
+
+	// Axes
+	fmt.Fprintf(&b, `<line x1="%d" y1="%.1f" x2="%.1f" y2="%.1f" class="axis"/>`, svgMarginLeft, bottom, svgMarginLeft+plotWidth, bottom)
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%.1f" class="axis"/>`, svgMarginLeft, svgMarginTop, svgMarginLeft, bottom)
+
+	// The line and its points
+	var line []string
+	for i, point := range points {
+		if !point.hasValue {
+			continue
+		}
+		x, y := xPos(i), yPos(point.value)
+		line = append(line, fmt.Sprintf("%.1f,%.1f", x, y))
+		fmt.Fprintf(&b, `<circle cx="%.1f" cy="%.1f" r="3" fill="%s"><title>%s: %s</title></circle>`,
+			x, y, color, template.HTMLEscapeString(point.label), fmt.Sprintf(format, point.value))
+	}
+	if len(line) > 1 {
+		fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`, strings.Join(line, " "), color)
+	}
+
+	// Axis titles
+	fmt.Fprintf(&b, `<text x="%.1f" y="%d" class="axis-title">%s</text>`, svgMarginLeft+plotWidth/2, svgChartHeight-15, template.HTMLEscapeString(xTitle))
+	fmt.Fprintf(&b, `<text x="18" y="%.1f" class="axis-title" transform="rotate(-90 18 %.1f)">%s</text>`,
+		float64(svgMarginTop)+plotHeight/2, float64(svgMarginTop)+plotHeight/2, template.HTMLEscapeString(yTitle))
+	b.WriteString(`</svg>`)
+
+	// Every value written above is escaped or formatted by this function
+	return template.HTML(b.String())
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Rubix Network Simulation Report - {{.Report.SimulationID}}</title>
+<style>
+body { font-family: Arial, Helvetica, sans-serif; color: #222; margin: 0 auto; max-width: 960px; padding: 24px; }
+h1 { text-align: center; margin-bottom: 4px; }
+h2 { border-bottom: 2px solid #eee; padding-bottom: 4px; margin-top: 36px; }
+.meta { text-align: center; color: #555; margin: 2px 0; }
+.filter { font-style: italic; }
+.note { color: #555; font-size: 14px; }
+table { border-collapse: collapse; width: 100%; margin: 12px 0; font-size: 14px; }
+th, td { border: 1px solid #ccc; padding: 6px 8px; text-align: center; }
+th { background: #f0f0f0; }
+tr:nth-child(even) td { background: #fafafa; }
+td.error { text-align: left; word-break: break-word; }
+a { color: #1565c0; }
+.chart { width: 100%; max-width: 640px; display: block; margin: 16px auto; }
+.chart-title { font-size: 15px; font-weight: bold; text-anchor: middle; }
+.grid { stroke: #ddd; stroke-width: 1; }
+.axis { stroke: #000; stroke-width: 1; }
+.x-label { font-size: 10px; text-anchor: middle; }
+.y-label { font-size: 11px; text-anchor: end; }
+.axis-title { font-size: 12px; text-anchor: middle; }
+</style>
+</head>
+<body>
+{{define "table"}}<table>
+<thead><tr>{{range .Header}}<th>{{.}}</th>{{end}}</tr></thead>
+<tbody>
+{{range .Rows}}<tr>{{range .}}<td>{{if .Link}}<a href="{{.Link}}" target="_blank" rel="noopener">{{.Text}}</a>{{else}}{{.Text}}{{end}}</td>{{end}}</tr>
+{{end}}</tbody>
+</table>{{end}}
+<h1>Rubix Network Simulation Report</h1>
+<p class="meta">Simulation ID: {{.Report.SimulationID}}</p>
+<p class="meta">Generated: {{.Report.CreatedAt.Format "2006-01-02 15:04:05"}}</p>
+{{if .Filter}}<p class="meta filter">Filtered view: {{.Filter}}</p>{{end}}
+
+<h2>Summary</h2>
+{{template "table" .Summary}}
+
+{{if .Settlement.Header}}<h2>Settlement Verification</h2>
+{{template "table" .Settlement}}
+{{if .SettlementBalances.Header}}{{template "table" .SettlementBalances}}{{end}}
+{{end}}
+
+{{if .AccountBalances.Header}}<h2>Node Account Balances</h2>
+<p class="note">RBT per transaction node at the end of the run. Pledged and locked tokens are held for consensus and cannot be sent until released.</p>
+{{template "table" .AccountBalances}}
+{{end}}
+
+{{if .TokenAnalysis.Rows}}<h2>Token Transfer Performance Analysis</h2>
+{{template "table" .TokenAnalysis}}
+{{end}}
+
+<h2>Transaction Log (Sorted by Token Amount)</h2>
+{{if .Transactions.Rows}}{{template "table" .Transactions}}{{else}}<p class="note">No transactions</p>{{end}}
+
+{{if .Charts}}<h2>Performance Charts</h2>
+{{range .Charts}}{{.}}
+{{end}}{{end}}
+
+<h2>Appendix: Failures</h2>
+{{if .Failures}}<p>{{.Failures}} failed transaction(s)</p>
+{{template "table" .FailureCategories}}
+{{template "table" .FailureLog}}
+{{else}}<p class="note">No failures</p>{{end}}
+</body>
+</html>
+`))
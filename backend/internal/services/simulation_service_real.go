@@ -1,40 +1,76 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"math"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	rubixconfig "github.com/rubix-simulator/backend/config"
+	"github.com/rubix-simulator/backend/internal/config"
+	"github.com/rubix-simulator/backend/internal/logging"
+	"github.com/rubix-simulator/backend/internal/metrics"
 	"github.com/rubix-simulator/backend/internal/models"
 )
 
+// ErrSimulationNotFound is returned when a simulation ID is not known to the service
+var ErrSimulationNotFound = errors.New("simulation not found")
+
+// reportCleanupInterval is how often old simulations and reports are looked for when a
+// retention period is configured
+const reportCleanupInterval = time.Hour
+
+// ErrSimulationFinished is returned when an operation needs a running simulation
+var ErrSimulationFinished = errors.New("is not running")
+
 type SimulationService struct {
+	config              *config.Config
 	nodeManager         *NodeManager
 	transactionExecutor *TransactionExecutor
 	reportGenerator     *ReportGenerator
 	simulations         map[string]*models.SimulationReport
 	mu                  sync.RWMutex
-	isSimulationRunning bool
-	simMu               sync.Mutex // Mutex for isSimulationRunning flag
+	runningSimulations  int        // Simulations started and not yet finished
+	nodeSimulations     int        // Running simulations that use the fleet (all but dry runs)
+	preparingFleet      bool       // A simulation is starting or resizing the fleet; others wait until it holds its nodes
+	simMu               sync.Mutex // Guards the three fields above
 	persistenceDir      string    // Directory to store simulation state
+	lastSnapshot        map[string]time.Time // Last progress snapshot written per simulation
+	ctx                 context.Context    // Cancelled on server shutdown
+	cancel              context.CancelFunc
+	cancels             map[string]context.CancelFunc // Per-simulation cancellation of running simulations
+	pauses              map[string]*pauseGate // Per-simulation pause control of running simulations
+	streams             map[string]map[chan models.ProgressFrame]struct{} // Progress stream listeners per simulation
+	runs                sync.WaitGroup     // Running simulation goroutines
 }
 
-func NewSimulationService(nm *NodeManager, te *TransactionExecutor, rg *ReportGenerator) *SimulationService {
+func NewSimulationService(cfg *config.Config, nm *NodeManager, te *TransactionExecutor, rg *ReportGenerator) *SimulationService {
 	// Create persistence directory
 	persistenceDir := "simulation-state"
 	os.MkdirAll(persistenceDir, 0755)
 	
+	ctx, cancel := context.WithCancel(context.Background())
+
 	ss := &SimulationService{
+		ctx:                 ctx,
+		cancel:              cancel,
+		config:              cfg,
 		nodeManager:         nm,
 		transactionExecutor: te,
 		reportGenerator:     rg,
 		simulations:         make(map[string]*models.SimulationReport),
-		isSimulationRunning: false,
+		lastSnapshot:        make(map[string]time.Time),
+		cancels:             make(map[string]context.CancelFunc),
+		pauses:              make(map[string]*pauseGate),
+		streams:             make(map[string]map[chan models.ProgressFrame]struct{}),
 		persistenceDir:      persistenceDir,
 	}
 	
@@ -48,28 +84,421 @@ func (ss *SimulationService) GetNodeManager() *NodeManager {
 	return ss.nodeManager
 }
 
-func (ss *SimulationService) StartSimulation(nodeCount, transactionCount int) (string, error) {
+// validateTransactionPlan checks the requested transaction count against the
+// paired-round model used by the executor, where each round runs at most
+// nodeCount/2 non-overlapping sender/receiver pairs. Counts that are valid but
+// leave nodes idle or the final round partially filled are reported as warnings
+// so the caller knows the shape of the run up front.
+func validateTransactionPlan(nodeCount, transactionCount int) []string {
+	var warnings []string
+
+	maxPairs := nodeCount / 2
+	if maxPairs < 1 {
+		return warnings
+	}
+
+	if nodeCount%2 != 0 {
+		warnings = append(warnings, fmt.Sprintf(
+			"odd node count %d: at most %d pair(s) run per round, so at least one node idles every round",
+			nodeCount, maxPairs))
+	}
+
+	if transactionCount < maxPairs {
+		warnings = append(warnings, fmt.Sprintf(
+			"%d transaction(s) cannot fill a single round of %d pair(s); some nodes will not transact",
+			transactionCount, maxPairs))
+	} else if remainder := transactionCount % maxPairs; remainder != 0 {
+		warnings = append(warnings, fmt.Sprintf(
+			"%d transaction(s) do not divide evenly into rounds of %d pair(s); at least %d rounds, the last with %d pair(s)",
+			transactionCount, maxPairs, transactionCount/maxPairs+1, remainder))
+	}
+
+	return warnings
+}
+
+// transferAmountRange applies the default 1-10 RBT range to unset bounds and validates the result
+func transferAmountRange(req models.SimulationRequest) (float64, float64, error) {
+	minAmount, maxAmount := req.MinTokenAmount, req.MaxTokenAmount
+	if minAmount == 0 {
+		minAmount = defaultMinTransferAmount
+	}
+	if maxAmount == 0 {
+		maxAmount = defaultMaxTransferAmount
+	}
+	if minAmount < minTransferPrecision {
+		return 0, 0, fmt.Errorf("minTokenAmount must be at least %.3f RBT", minTransferPrecision)
+	}
+	if maxAmount > maxTransferAmount {
+		return 0, 0, fmt.Errorf("maxTokenAmount must be at most %.0f RBT", maxTransferAmount)
+	}
+	if !hasTransferPrecision(minAmount) || !hasTransferPrecision(maxAmount) {
+		return 0, 0, fmt.Errorf("minTokenAmount and maxTokenAmount may have at most 3 decimal places")
+	}
+	if maxAmount < minAmount {
+		return 0, 0, fmt.Errorf("maxTokenAmount (%g) must be greater than or equal to minTokenAmount (%g)", maxAmount, minAmount)
+	}
+	return minAmount, maxAmount, nil
+}
+
+// defaultMaxTransactions is the per-simulation cap used when MaxTransactions is unset
+const defaultMaxTransactions = 500
+
+
+// maxTransactions returns the most transactions a single simulation may request
+func (ss *SimulationService) maxTransactions() int {
+	if ss.config.MaxTransactions < 1 {
+		return defaultMaxTransactions
+	}
+	return ss.config.MaxTransactions
+}
+
+// newNodeTokens returns the number of test tokens generated for each new transaction node
+func (ss *SimulationService) newNodeTokens() int {
+	if ss.config.Rubix != nil && ss.config.Rubix.InitialTokens > 0 {
+		return ss.config.Rubix.InitialTokens
+	}
+	return rubixconfig.DefaultInitialTokens
+}
+
+// checkFleetBalance compares a run's expected transfer volume with the combined balance of
+// the current transaction nodes. It returns a warning when the balance is below the
+// expected volume, and an error when it cannot cover even the minimum amount of every
+// transaction. Before the fleet is started the balance is estimated from the test tokens
+// each of nodeCount new nodes receives, and only a warning is given. A non-nil
+// externalNodes is checked in place of the current fleet.
+func (ss *SimulationService) checkFleetBalance(nodeCount, transactionCount int, minAmount, maxAmount float64, externalNodes []*models.Node) (string, error) {
+	minimum := float64(transactionCount) * minAmount
+	expected := float64(transactionCount) * (minAmount + maxAmount) / 2
+
+	balance, nodes := ss.nodeManager.TransactionNodeBalance()
+	if externalNodes != nil {
+		balance, nodes = ss.nodeManager.nodesBalance(externalNodes)
+	}
+	if nodes == 0 {
+		if externalNodes != nil || ss.nodeManager.IsExternal() {
+			return "", nil
+		}
+		estimate := float64(nodeCount * ss.newNodeTokens())
+		if estimate < expected {
+			return fmt.Sprintf(
+				"high transaction count for the fleet: %d new transaction node(s) start with about %.0f RBT, below the ~%.3f RBT expected for %d transaction(s); expect insufficient-balance failures unless test tokens are generated (POST /nodes/check-tokens) or autoRefill is set",
+				nodeCount, estimate, expected, transactionCount), nil
+		}
+		return "", nil
+	}
+
+	if balance < minimum {
+		return "", fmt.Errorf(
+			"insufficient fleet balance: %d transaction node(s) hold %.2f RBT but %d transaction(s) need at least %.3f RBT; generate test tokens (POST /nodes/check-tokens) or request fewer transactions",
+			nodes, balance, transactionCount, minimum)
+	}
+	if balance < expected {
+		return fmt.Sprintf(
+			"low fleet balance: %d transaction node(s) hold %.2f RBT, below the ~%.3f RBT expected for %d transaction(s); expect insufficient-balance failures unless test tokens are generated (POST /nodes/check-tokens)",
+			nodes, balance, expected, transactionCount), nil
+	}
+	return "", nil
+}
+
+// transactionNodeCount returns how many transaction nodes the managed fleet currently has
+// selected, or 0 for an external fleet or one that hasn't been started yet
+func (ss *SimulationService) transactionNodeCount() int {
+	if ss.nodeManager.IsExternal() {
+		return 0
+	}
+	count := 0
+	for _, node := range ss.nodeManager.GetNodes() {
+		if !node.IsQuorum {
+			count++
+		}
+	}
+	return count
+}
+
+// IsSimulationRunning reports whether any simulation is currently running
+func (ss *SimulationService) IsSimulationRunning() bool {
+	ss.simMu.Lock()
+	defer ss.simMu.Unlock()
+	return ss.runningSimulations > 0
+}
+
+// fleetReady lets other simulations start once the preparing one holds its nodes
+func (ss *SimulationService) fleetReady() {
+	ss.simMu.Lock()
+	ss.preparingFleet = false
+	ss.simMu.Unlock()
+}
+
+// releaseRun undoes StartSimulation's bookkeeping for a run that finished or failed to
+// start: it frees the run's nodes and, if the run was still preparing the fleet, lets
+// other simulations start. Token monitoring resumes once no simulation is left.
+func (ss *SimulationService) releaseRun(dryRun, preparing bool, nodes []*models.Node) {
+	if len(nodes) > 0 {
+		ss.nodeManager.MarkNodesAsAvailable(nodes)
+	}
+
+	ss.simMu.Lock()
+	ss.runningSimulations--
+	if !dryRun {
+		ss.nodeSimulations--
+	}
+	if preparing {
+		ss.preparingFleet = false
+	}
+	idle := ss.runningSimulations == 0
+	metrics.SetRunningSimulations(ss.runningSimulations)
+	ss.simMu.Unlock()
+
+	if idle {
+		ss.nodeManager.SetSimulationActive(false)
+	}
+}
+
+func (ss *SimulationService) StartSimulation(req models.SimulationRequest) (string, error) {
+	nodeCount, transactionCount := req.Nodes, req.Transactions
+	// Read before taking simMu: it waits on the rubix manager, which a fleet start holds
+	// for minutes
+	quorumCount := ss.nodeManager.QuorumCount()
+
 	ss.simMu.Lock()
-	if ss.isSimulationRunning {
+	if ss.ctx.Err() != nil {
+		ss.simMu.Unlock()
+		return "", fmt.Errorf("server is shutting down")
+	}
+	// A run that starts or resizes the fleet must hold its nodes before another can pick
+	// from what is left; dry runs don't touch the fleet
+	if ss.preparingFleet && !req.DryRun {
 		ss.simMu.Unlock()
 		return "", fmt.Errorf("All servers are busy, please try again after some time.")
 	}
+	// Alongside another simulation the fleet is used as it is, and the new run takes
+	// transaction nodes the others aren't using
+	concurrent := !req.DryRun && ss.nodeSimulations > 0
+	// A run bringing its own external fleet switches to it only once it is accepted, and
+	// never while another simulation is using the current nodes
+	var externalNodes []*models.Node
+	if len(req.ExternalNodes) > 0 {
+		if ss.runningSimulations > 0 {
+			ss.simMu.Unlock()
+			return "", fmt.Errorf("All servers are busy, please try again after some time.")
+		}
+		var err error
+		if externalNodes, err = externalFleet(req.ExternalNodes); err != nil {
+			ss.simMu.Unlock()
+			return "", err
+		}
+	}
+	external := externalNodes != nil || ss.nodeManager.IsExternal()
 	// Validate parameters before marking simulation as running
-	// nodeCount represents additional non-quorum nodes beyond the 7 quorum nodes
+	// nodeCount represents additional non-quorum nodes beyond the quorum nodes
 	// Minimum 2 non-quorum nodes required for transactions
-	if nodeCount < 2 || nodeCount > 20 {
+	totalNodes := nodeCount + quorumCount // Total nodes (quorum + additional)
+	externalCount := ss.nodeManager.ExternalNodeCount()
+	if externalNodes != nil {
+		externalCount = len(externalNodes)
+	}
+	if externalCount > 0 {
+		// External fleets bring their own quorum; use every node unless told otherwise
+		if nodeCount == 0 {
+			nodeCount = externalCount
+		}
+		if nodeCount < 2 || nodeCount > externalCount {
+			ss.simMu.Unlock()
+			return "", fmt.Errorf("node count must be between 2 and %d for the externally-managed fleet", externalCount)
+		}
+		totalNodes = nodeCount
+	} else if nodeCount < 2 || nodeCount > 20 {
 		ss.simMu.Unlock()
 		return "", fmt.Errorf("non-quorum node count must be between 2 and 20 (need at least 2 for sender/receiver)")
 	}
+
+	// Starting the run selects exactly nodeCount transaction nodes, dropping or adding
+	// nodes on a fleet of a different size, so that has to be asked for explicitly
+	var fleetWarning string
+	if concurrent && req.Adjust {
+		ss.simMu.Unlock()
+		return "", fmt.Errorf("the fleet cannot be resized while another simulation is running")
+	}
+	if running := ss.transactionNodeCount(); !external && !req.DryRun && !concurrent && running > 0 && running != nodeCount {
+		if !req.Adjust {
+			ss.simMu.Unlock()
+			return "", fmt.Errorf("%d transaction nodes are running but %d were requested; request %d nodes or set \"adjust\": true to resize the fleet",
+				running, nodeCount, running)
+		}
+		fleetWarning = fmt.Sprintf("Fleet resized from %d to %d transaction nodes", running, nodeCount)
+	}
 	
-	if transactionCount < 1 || transactionCount > 500 {
+	if maxTransactions := ss.maxTransactions(); transactionCount < 1 || transactionCount > maxTransactions {
+		ss.simMu.Unlock()
+		return "", fmt.Errorf("transaction count must be between 1 and %d (MAX_TRANSACTIONS)", maxTransactions)
+	}
+
+	minAmount, maxAmount, err := transferAmountRange(req)
+	if err != nil {
+		ss.simMu.Unlock()
+		return "", err
+	}
+
+	if (req.SenderNodeID == "") != (req.ReceiverNodeID == "") {
+		ss.simMu.Unlock()
+		return "", fmt.Errorf("senderNodeId and receiverNodeId must be set together")
+	}
+	if req.SenderNodeID != "" && req.SenderNodeID == req.ReceiverNodeID {
+		ss.simMu.Unlock()
+		return "", fmt.Errorf("senderNodeId and receiverNodeId must be different nodes")
+	}
+	if req.DryRunSuccessRate < 0 || req.DryRunSuccessRate > 1 {
+		ss.simMu.Unlock()
+		return "", fmt.Errorf("dryRunSuccessRate must be between 0 and 1")
+	}
+	transferMode := req.TransferMode
+	if transferMode == "" {
+		transferMode = TransferModePaired
+	}
+	if transferMode != TransferModePaired && transferMode != TransferModeBatch {
+		ss.simMu.Unlock()
+		return "", fmt.Errorf("transferMode must be %q or %q", TransferModePaired, TransferModeBatch)
+	}
+	distributionMode := req.DistributionMode
+	if distributionMode == "" {
+		distributionMode = DistributionRandom
+	}
+	if distributionMode != DistributionRandom && distributionMode != DistributionRoundRobin {
+		ss.simMu.Unlock()
+		return "", fmt.Errorf("distributionMode must be %q or %q", DistributionRandom, DistributionRoundRobin)
+	}
+	if req.WarmupTransactions < 0 || req.WarmupTransactions > maxWarmupTransactions {
+		ss.simMu.Unlock()
+		return "", fmt.Errorf("warmupTransactions must be between 0 and %d", maxWarmupTransactions)
+	}
+	interRoundDelayMs := defaultInterRoundDelayMs
+	if req.InterRoundDelayMs != nil {
+		interRoundDelayMs = *req.InterRoundDelayMs
+	}
+	if interRoundDelayMs < 0 || interRoundDelayMs > maxInterRoundDelayMs {
+		ss.simMu.Unlock()
+		return "", fmt.Errorf("interRoundDelayMs must be between 0 and %d", maxInterRoundDelayMs)
+	}
+	if req.AutoRefill && !req.DryRun && transferMode == TransferModeBatch {
+		ss.simMu.Unlock()
+		return "", fmt.Errorf("autoRefill cannot be combined with transferMode %q", TransferModeBatch)
+	}
+	if req.AutoRefill && !req.DryRun && external {
+		ss.simMu.Unlock()
+		return "", fmt.Errorf("autoRefill requires simulator-managed nodes")
+	}
+	if err := validateCommentTemplate(req.CommentTemplate); err != nil {
+		ss.simMu.Unlock()
+		return "", err
+	}
+	if req.CallbackURL != "" {
+		if err := validateCallbackURL(req.CallbackURL); err != nil {
+			ss.simMu.Unlock()
+			return "", err
+		}
+	}
+	if distributionMode == DistributionRoundRobin && req.SenderNodeID != "" {
+		ss.simMu.Unlock()
+		return "", fmt.Errorf("distributionMode %q cannot be combined with senderNodeId/receiverNodeId", DistributionRoundRobin)
+	}
+	if err := validateNodeAffinity(req.SenderNodeIDs, req.ReceiverNodeIDs, nodeCount); err != nil {
+		ss.simMu.Unlock()
+		return "", err
+	}
+	if len(req.SenderNodeIDs) > 0 && req.SenderNodeID != "" {
 		ss.simMu.Unlock()
-		return "", fmt.Errorf("transaction count must be between 1 and 500")
+		return "", fmt.Errorf("senderNodeIds/receiverNodeIds cannot be combined with senderNodeId/receiverNodeId")
+	}
+	if len(req.SenderNodeIDs) > 0 && distributionMode == DistributionRoundRobin {
+		ss.simMu.Unlock()
+		return "", fmt.Errorf("distributionMode %q cannot be combined with senderNodeIds/receiverNodeIds", DistributionRoundRobin)
+	}
+	opts := TransactionOptions{
+		MinAmount:         minAmount,
+		MaxAmount:         maxAmount,
+		SenderNodeID:      req.SenderNodeID,
+		ReceiverNodeID:    req.ReceiverNodeID,
+		SenderNodeIDs:     req.SenderNodeIDs,
+		ReceiverNodeIDs:   req.ReceiverNodeIDs,
+		DryRun:            req.DryRun,
+		DryRunSuccessRate: req.DryRunSuccessRate,
+		Batch:             transferMode == TransferModeBatch,
+		RoundRobin:        distributionMode == DistributionRoundRobin,
+		WarmupTransactions: req.WarmupTransactions,
+		InterRoundDelay:    time.Duration(interRoundDelayMs) * time.Millisecond,
+		CommentTemplate:    req.CommentTemplate,
+	}
+	if opts.DryRun && opts.DryRunSuccessRate == 0 {
+		opts.DryRunSuccessRate = defaultDryRunSuccessRate
+	}
+	// Nodes that are already up can be checked now; otherwise the pair or sets are checked
+	// once they start
+	currentNodes := ss.nodeManager.GetNodes()
+	if externalNodes != nil {
+		currentNodes = externalNodes
+	}
+	if !opts.DryRun && len(currentNodes) > 0 {
+		if opts.FixedPair() {
+			if _, _, err := resolveFixedPair(currentNodes, opts); err != nil {
+				ss.simMu.Unlock()
+				return "", err
+			}
+		}
+		if opts.NodeAffinity() {
+			if _, _, err := resolveNodeAffinity(currentNodes, opts); err != nil {
+				ss.simMu.Unlock()
+				return "", err
+			}
+		}
 	}
 
-	ss.isSimulationRunning = true
+	// Claim the nodes now so two concurrent requests can't both take the same ones
+	var reserved []*models.Node
+	if concurrent {
+		var err error
+		if reserved, err = ss.nodeManager.ReserveNodes(nodeCount, opts.requiredNodeIDs()...); err != nil {
+			ss.simMu.Unlock()
+			return "", fmt.Errorf("%v; another simulation is running, request fewer nodes or try again after it finishes", err)
+		}
+	}
+	preparing := !req.DryRun && !concurrent
+	ss.preparingFleet = ss.preparingFleet || preparing
+	ss.runningSimulations++
+	if !req.DryRun {
+		ss.nodeSimulations++
+	}
+	// A dry run is fully checked by now; a real run still holds the fleet until its balance
+	// check passes
+	if externalNodes != nil && req.DryRun {
+		ss.nodeManager.useExternalFleet(externalNodes)
+	}
+	metrics.SetRunningSimulations(ss.runningSimulations)
 	ss.simMu.Unlock()
 
+	// A fixed pair runs its transactions one at a time, node affinity pairs within its sets
+	// and batch mode doesn't use rounds, so round-shape warnings don't apply
+	var warnings []string
+	if !opts.FixedPair() && !opts.NodeAffinity() && !opts.Batch {
+		warnings = validateTransactionPlan(nodeCount, transactionCount)
+	}
+	var balanceWarning string
+	if !opts.DryRun {
+		balanceWarning, err = ss.checkFleetBalance(nodeCount, transactionCount, minAmount, maxAmount, externalNodes)
+	}
+	if err != nil {
+		ss.releaseRun(req.DryRun, preparing, reserved)
+		return "", err
+	}
+	if externalNodes != nil && !req.DryRun {
+		ss.nodeManager.useExternalFleet(externalNodes)
+	}
+	if balanceWarning != "" {
+		warnings = append(warnings, balanceWarning)
+	}
+	if fleetWarning != "" {
+		warnings = append(warnings, fleetWarning)
+	}
+
 	// Pause token monitoring during simulation
 	ss.nodeManager.SetSimulationActive(true)
 
@@ -79,50 +508,106 @@ func (ss *SimulationService) StartSimulation(nodeCount, transactionCount int) (s
 		SimulationID: simulationID,
 		Config: models.SimulationConfig{
 			ID:           simulationID,
-			Nodes:        nodeCount + 7, // Total nodes (7 quorum + additional)
+			Nodes:        totalNodes,
 			Transactions: transactionCount,
+			GeneratePDF:  req.ShouldGeneratePDF(),
+			MinTokenAmount: minAmount,
+			MaxTokenAmount: maxAmount,
+			SenderNodeID:   req.SenderNodeID,
+			ReceiverNodeID: req.ReceiverNodeID,
+			SenderNodeIDs:   req.SenderNodeIDs,
+			ReceiverNodeIDs: req.ReceiverNodeIDs,
+			DryRun:         req.DryRun,
+			TransferMode:   transferMode,
+			DistributionMode: distributionMode,
+			WarmupTransactions: req.WarmupTransactions,
+			InterRoundDelayMs:  interRoundDelayMs,
+			AutoRefill:         req.AutoRefill && !req.DryRun,
+			ThroughputWindowSeconds: ss.config.ThroughputWindowSeconds,
+			CallbackURL:        req.CallbackURL,
+			CommentTemplate:    req.CommentTemplate,
 			StartedAt:    time.Now(),
 		},
 		TotalTransactions: transactionCount,
+		Warnings:          warnings,
 		IsFinished:        false,
+		LastHeartbeat:     time.Now(),
 		CreatedAt:         time.Now(),
 	}
 	
+	for _, warning := range report.Warnings {
+		logging.Warnf("Warning: simulation %s: %s", simulationID, warning)
+	}
+
+	// Derived from the service context so a shutdown still stops every run
+	simCtx, cancel := context.WithCancel(ss.ctx)
+	opts.Pause = newPauseGate()
+	if req.AutoRefill && !opts.DryRun {
+		opts.Refill = ss.refillFunc(simulationID)
+	}
+
 	ss.mu.Lock()
 	ss.simulations[simulationID] = report
+	ss.cancels[simulationID] = cancel
+	ss.pauses[simulationID] = opts.Pause
 	ss.mu.Unlock()
 
 	// Run simulation in background
-	go ss.runSimulation(simulationID, nodeCount, transactionCount)
+	ss.runs.Add(1)
+	go ss.runSimulation(simCtx, simulationID, nodeCount, transactionCount, opts, req.ShouldGeneratePDF(), reserved)
 	
 	return simulationID, nil
 }
 
-func (ss *SimulationService) runSimulation(simulationID string, nodeCount, transactionCount int) {
+// runSimulation runs a started simulation. A concurrent run is given the nodes reserved
+// for it; otherwise (reserved is nil) the run brings the fleet up and reserves its own.
+func (ss *SimulationService) runSimulation(ctx context.Context, simulationID string, nodeCount, transactionCount int, opts TransactionOptions, generatePDF bool, reserved []*models.Node) {
+	defer ss.runs.Done()
+	nodes := reserved
+	preparing := !opts.DryRun && reserved == nil
 	defer func() {
 		// Handle any panic to ensure simulation state is cleaned up
 		if r := recover(); r != nil {
-			log.Printf("ERROR: Simulation %s panicked: %v", simulationID, r)
+			logging.Errorf("ERROR: Simulation %s panicked: %v", simulationID, r)
 			ss.updateReport(simulationID, func(report *models.SimulationReport) {
 				report.IsFinished = true
 				report.Error = fmt.Sprintf("Simulation panicked: %v", r)
 			})
 		}
 		
-		ss.simMu.Lock()
-		ss.isSimulationRunning = false
-		ss.simMu.Unlock()
-		
-		// Resume token monitoring after simulation completes (even if it panicked)
-		ss.nodeManager.SetSimulationActive(false)
+		ss.mu.Lock()
+		if cancel, ok := ss.cancels[simulationID]; ok {
+			cancel()
+			delete(ss.cancels, simulationID)
+		}
+		delete(ss.pauses, simulationID)
+		ss.mu.Unlock()
+
+		// Free the nodes and resume token monitoring once no simulation is left (even if
+		// this one panicked)
+		var held []*models.Node
+		if !opts.DryRun {
+			held = nodes
+		}
+		ss.releaseRun(opts.DryRun, preparing, held)
+
+		// Last, so the receiver sees the final report and can start the next run right away.
+		// Bound to the service context: shutdown waits on this run, so it must not wait out
+		// the retries too.
+		ss.deliverCallback(ss.ctx, simulationID)
 	}()
 
+	// Beat for as long as the run is alive, so a long node startup, consensus wait or settle
+	// phase between progress updates isn't taken for a stall
+	stopHeartbeat := ss.startHeartbeat(simulationID)
+	defer stopHeartbeat()
+
 	// Safely truncate ID for logging
 	simID := simulationID
 	if len(simID) > 8 {
 		simID = simID[:8]
 	}
-	log.Printf("Starting simulation %s with %d non-quorum nodes and %d transactions", 
+	logging.Infof("Starting simulation %s with %d non-quorum nodes and %d transactions", 
 		simID, nodeCount, transactionCount)
 	
 	startTime := time.Now()
@@ -131,34 +616,24 @@ func (ss *SimulationService) runSimulation(simulationID string, nodeCount, trans
 		report.Config.StartedAt = startTime
 	})
 
-	// Ensure nodes are running
-	if _, err := ss.nodeManager.StartNodes(nodeCount); err != nil {
-		log.Printf("ERROR: Failed to start nodes: %v", err)
-		ss.updateReport(simulationID, func(report *models.SimulationReport) {
-			report.IsFinished = true
-			report.Error = fmt.Sprintf("Failed to start nodes: %v", err)
-		})
-		return
-	}
-
-	// Get available nodes from the node manager
-	nodes, err := ss.nodeManager.GetAvailableNodes(nodeCount)
-    if err != nil {
-		log.Printf("ERROR: Failed to get available nodes: %v", err)
-		ss.updateReport(simulationID, func(report *models.SimulationReport) {
-			report.IsFinished = true
-			report.Error = fmt.Sprintf("Failed to get available nodes: %v", err)
-		})
-		return
+	if opts.DryRun {
+		nodes = dryRunNodes(nodeCount)
+		logging.Infof("Dry run: using %d synthetic nodes, no transfers reach the network", len(nodes))
+	} else if preparing {
+		started, ok := ss.startNodes(simulationID, nodeCount, opts)
+		nodes = started
+		preparing = false
+		ss.fleetReady()
+		if !ok {
+			return
+		}
+	} else {
+		logging.Infof("Running alongside other simulations on reserved nodes %s", nodeIDs(nodes))
 	}
-
-	// Mark nodes as busy
-	ss.nodeManager.MarkNodesAsBusy(nodes)
-	defer ss.nodeManager.MarkNodesAsAvailable(nodes)
 	
 	// Verify we have nodes
 	if len(nodes) == 0 {
-		log.Printf("ERROR: No nodes were started")
+		logging.Errorf("ERROR: No nodes were started")
 		ss.updateReport(simulationID, func(report *models.SimulationReport) {
 			report.IsFinished = true
 			report.Error = "No Rubix nodes could be started. Check rubixgoplatform installation."
@@ -175,7 +650,7 @@ func (ss *SimulationService) runSimulation(simulationID string, nodeCount, trans
 	}
 	
 	if transactionNodeCount < 2 {
-		log.Printf("ERROR: Only %d transaction nodes available, need at least 2", transactionNodeCount)
+		logging.Errorf("ERROR: Only %d transaction nodes available, need at least 2", transactionNodeCount)
 		ss.updateReport(simulationID, func(report *models.SimulationReport) {
 			report.IsFinished = true
 			report.Error = fmt.Sprintf("Insufficient transaction nodes: %d (need minimum 2)", transactionNodeCount)
@@ -183,6 +658,21 @@ func (ss *SimulationService) runSimulation(simulationID string, nodeCount, trans
 		return
 	}
 	
+	var pairingErr error
+	if opts.FixedPair() {
+		_, _, pairingErr = resolveFixedPair(nodes, opts)
+	} else if opts.NodeAffinity() {
+		_, _, pairingErr = resolveNodeAffinity(nodes, opts)
+	}
+	if pairingErr != nil {
+		logging.Errorf("ERROR: %v", pairingErr)
+		ss.updateReport(simulationID, func(report *models.SimulationReport) {
+			report.IsFinished = true
+			report.Error = pairingErr.Error()
+		})
+		return
+	}
+
 	// Update report with node information
 	ss.updateReport(simulationID, func(report *models.SimulationReport) {
 		nodeList := make([]models.Node, len(nodes))
@@ -192,7 +682,18 @@ func (ss *SimulationService) runSimulation(simulationID string, nodeCount, trans
 		report.Nodes = nodeList
 	})
 
-	log.Printf("Executing %d real transactions on %d transaction nodes...", transactionCount, transactionNodeCount)
+	// Warm the nodes up before anything is measured or snapshotted
+	if !opts.DryRun {
+		ss.transactionExecutor.RunWarmup(ctx, nodes, opts)
+	}
+
+	// Snapshot balances up front so the settle phase can reconcile them afterwards
+	var initialBalances map[string]float64
+	if ss.config.SettleSeconds > 0 && !opts.DryRun {
+		initialBalances = ss.transactionExecutor.SnapshotBalances(nodes)
+	}
+
+	logging.Infof("Executing %d real transactions on %d transaction nodes...", transactionCount, transactionNodeCount)
 	
 	// Execute real transactions on real nodes with progress reporting
 	progressCallback := func(executorCompleted int, transactions []models.Transaction) {
@@ -223,7 +724,7 @@ func (ss *SimulationService) runSimulation(simulationID string, nodeCount, trans
 		computedCompleted := successCount + failureCount
 
 		// Update report with recomputed progress and metrics
-		ss.updateReport(simulationID, func(report *models.SimulationReport) {
+		ss.updateProgress(simulationID, func(report *models.SimulationReport) {
 			report.TransactionsCompleted = computedCompleted
 			report.SuccessCount = successCount
 			report.FailureCount = failureCount
@@ -235,13 +736,31 @@ func (ss *SimulationService) runSimulation(simulationID string, nodeCount, trans
 			report.Transactions = completedTxs
 		})
 
-		log.Printf("Progress: executor=%d, computed=%d/%d (success=%d, failed=%d)", executorCompleted, computedCompleted, transactionCount, successCount, failureCount)
+		logging.Infof("Progress: executor=%d, computed=%d/%d (success=%d, failed=%d)", executorCompleted, computedCompleted, transactionCount, successCount, failureCount)
 	}
 	
-	transactions := ss.transactionExecutor.ExecuteTransactionsWithProgress(nodes, transactionCount, progressCallback)
+	transactions := ss.transactionExecutor.ExecuteTransactionsWithContext(ctx, nodes, transactionCount, opts, progressCallback)
 	
+	// Cancelled by the user or the server is shutting down: checkpoint what we have and skip settle/PDF
+	if ctx.Err() != nil && len(transactions) < transactionCount {
+		reason := "cancelled by user"
+		if ss.ctx.Err() != nil {
+			reason = fmt.Sprintf("Interrupted by server shutdown after %d/%d transactions", len(transactions), transactionCount)
+		}
+		endTime := time.Now()
+		ss.updateReport(simulationID, func(r *models.SimulationReport) {
+			aggregateTransactions(r, transactions)
+			r.Config.EndedAt = &endTime
+			r.TotalTime = endTime.Sub(startTime)
+			r.IsFinished = true
+			r.Error = reason
+		})
+		logging.Infof("Simulation %s stopped after %d/%d transactions: %s", simID, len(transactions), transactionCount, reason)
+		return
+	}
+
 	if len(transactions) == 0 {
-		log.Printf("ERROR: No transactions were executed")
+		logging.Errorf("ERROR: No transactions were executed")
 		ss.updateReport(simulationID, func(report *models.SimulationReport) {
 			report.IsFinished = true
 			report.Error = "Failed to execute transactions. Check if nodes are running with valid DIDs."
@@ -250,51 +769,188 @@ func (ss *SimulationService) runSimulation(simulationID string, nodeCount, trans
 	}
 	
 	// Process final transaction results
-	report := ss.processTransactions(simulationID, transactions)
-	
 	endTime := time.Now()
 	totalTime := endTime.Sub(startTime)
 	
 	ss.updateReport(simulationID, func(r *models.SimulationReport) {
+		aggregateTransactions(r, transactions)
 		r.Config.EndedAt = &endTime
 		r.TotalTime = totalTime
+	})
+
+	// Optionally wait for async consensus to settle and verify the reported results landed.
+	// Cancelling during the wait skips the verification and the account snapshot.
+	var settlement *models.SettlementReconciliation
+	var accountBalances []models.NodeAccountBalance
+	var skipped string
+	if ss.config.SettleSeconds > 0 && !opts.DryRun {
+		settleDuration := time.Duration(ss.config.SettleSeconds) * time.Second
+		logging.Infof("Waiting %v for balances to settle before verification...", settleDuration)
+		select {
+		case <-time.After(settleDuration):
+			settlement = ss.transactionExecutor.VerifySettlement(nodes, transactions, initialBalances)
+			settlement.SettleDuration = settleDuration
+			logging.Infof("Settlement verification: %d/%d reported transfers confirmed (%d unconfirmed, %d unverifiable)",
+				settlement.Confirmed, settlement.ReportedSuccess, len(settlement.Unconfirmed), settlement.Unverifiable)
+		case <-ctx.Done():
+			skipped = "settlement verification and account balances skipped: simulation stopped during the settle wait"
+			logging.Infof("Simulation %s: %s", simID, skipped)
+		}
+	}
+
+	// Record where the tokens ended up, so pledged or locked RBT isn't mistaken for spent
+	if !opts.DryRun && ctx.Err() == nil {
+		accountBalances = ss.transactionExecutor.SnapshotAccountInfo(nodes)
+	}
+
+	// Finish only now, so a finished report always carries its settlement and balances
+	ss.updateReport(simulationID, func(r *models.SimulationReport) {
+		r.Settlement = settlement
+		r.AccountBalances = accountBalances
+		if skipped != "" {
+			r.Warnings = append(r.Warnings, skipped)
+		}
 		r.IsFinished = true
-		*r = *report
+		if !opts.DryRun {
+			metrics.SetLastRunAverageLatency(r.AverageTransactionTime)
+		}
 	})
 
-	// Generate PDF report
-	pdfFilename, err := ss.reportGenerator.GeneratePDF(report)
-	if err != nil {
-		log.Printf("Failed to generate PDF report: %v", err)
+	// Generate PDF report unless the caller only consumes the JSON report
+	if generatePDF {
+		report, err := ss.GetReport(simulationID)
+		if err != nil {
+			logging.Errorf("Failed to generate PDF report: %v", err)
+			return
+		}
+		pdfFilename, err := ss.reportGenerator.GeneratePDF(report)
+		if err != nil {
+			logging.Errorf("Failed to generate PDF report: %v", err)
+		} else {
+			logging.Infof("PDF report generated: %s", pdfFilename)
+		}
 	} else {
-		log.Printf("PDF report generated: %s", pdfFilename)
+		logging.Infof("Skipping PDF report generation (disabled for this simulation)")
 	}
 	
 	// NOTE: Nodes are NOT stopped after simulation - they remain running for subsequent simulations
 	// Users can manually stop nodes using the shutdown button in the UI
-	log.Printf("Nodes remain running for next simulation. Use shutdown button to stop them.")
+	logging.Infof("Nodes remain running for next simulation. Use shutdown button to stop them.")
 	
 	// Reuse simID from earlier for logging
-	log.Printf("Simulation %s completed in %v", simID, totalTime)
+	logging.Infof("Simulation %s completed in %v", simID, totalTime)
+}
+
+// startNodes makes sure the fleet is running with a quorum majority and reserves the nodes
+// for a simulation. On failure the report is finished with the error and ok is false.
+func (ss *SimulationService) startNodes(simulationID string, nodeCount int, opts TransactionOptions) ([]*models.Node, bool) {
+	// Ensure nodes are running
+	if _, err := ss.nodeManager.StartNodes(nodeCount); err != nil {
+		logging.Errorf("ERROR: Failed to start nodes: %v", err)
+		ss.updateReport(simulationID, func(report *models.SimulationReport) {
+			report.IsFinished = true
+			report.Error = fmt.Sprintf("Failed to start nodes: %v", err)
+		})
+		return nil, false
+	}
+
+	// Without a quorum majority every transfer would hang or fail, so fail fast instead
+	if err := ss.nodeManager.CheckQuorumMajority(); err != nil {
+		logging.Errorf("ERROR: %v", err)
+		ss.updateReport(simulationID, func(report *models.SimulationReport) {
+			report.IsFinished = true
+			report.Error = err.Error()
+		})
+		return nil, false
+	}
+
+	// Reserve the simulation's nodes, including a requested fixed pair or sender and receiver sets
+	nodes, err := ss.nodeManager.ReserveNodes(nodeCount, opts.requiredNodeIDs()...)
+	if err != nil {
+		logging.Errorf("ERROR: Failed to get available nodes: %v", err)
+		ss.updateReport(simulationID, func(report *models.SimulationReport) {
+			report.IsFinished = true
+			report.Error = fmt.Sprintf("Failed to get available nodes: %v", err)
+		})
+		return nil, false
+	}
+
+	return nodes, true
 }
 
-func (ss *SimulationService) processTransactions(simulationID string, transactions []models.Transaction) *models.SimulationReport {
-	ss.mu.RLock()
-	report := ss.simulations[simulationID]
-	ss.mu.RUnlock()
+// nodeIDs lists the IDs of nodes, comma-separated, for logging
+func nodeIDs(nodes []*models.Node) string {
+	ids := make([]string, len(nodes))
+	for i, node := range nodes {
+		ids[i] = node.ID
+	}
+	return strings.Join(ids, ", ")
+}
 
+// cloneReport returns a copy of a report that shares no mutable state with the original,
+// so it can be read or encoded after ss.mu is released. Caller must hold ss.mu.
+func cloneReport(report *models.SimulationReport) *models.SimulationReport {
+	clone := *report
+	clone.Nodes = append([]models.Node(nil), report.Nodes...)
+	clone.Transactions = append([]models.Transaction(nil), report.Transactions...)
+	clone.NodeBreakdown = append([]models.NodeStats(nil), report.NodeBreakdown...)
+	clone.FailureBreakdown = append([]models.FailureCategoryCount(nil), report.FailureBreakdown...)
+	clone.Refills = append([]models.RefillEvent(nil), report.Refills...)
+	clone.Warnings = append([]string(nil), report.Warnings...)
+	clone.ThroughputSeries = append([]models.ThroughputBucket(nil), report.ThroughputSeries...)
+	clone.AccountBalances = append([]models.NodeAccountBalance(nil), report.AccountBalances...)
+	if report.Config.EndedAt != nil {
+		endedAt := *report.Config.EndedAt
+		clone.Config.EndedAt = &endedAt
+	}
+	if report.Settlement != nil {
+		settlement := *report.Settlement
+		settlement.Unconfirmed = append([]string(nil), report.Settlement.Unconfirmed...)
+		settlement.NodeBalances = append([]models.NodeBalanceCheck(nil), report.Settlement.NodeBalances...)
+		clone.Settlement = &settlement
+	}
+	if report.Fairness != nil {
+		fairness := *report.Fairness
+		clone.Fairness = &fairness
+	}
+	if report.Filter != nil {
+		filter := *report.Filter
+		filter.NodePairs = append([]models.NodePair(nil), report.Filter.NodePairs...)
+		clone.Filter = &filter
+	}
+	return &clone
+}
+
+// aggregateTransactions recomputes the summary metrics and node breakdown of a report from the given transactions
+func aggregateTransactions(report *models.SimulationReport, transactions []models.Transaction) {
 	successCount := 0
 	failureCount := 0
 	totalLatency := time.Duration(0)
 	minTransactionTime := time.Duration(1<<63 - 1)
 	maxTransactionTime := time.Duration(0)
 	totalTokensTransferred := float64(0)
+	totalTokensRequested := float64(0)
+	adjustedTransactions := 0
+	retriedTransactions := 0
+	var totalSubmitTime, totalConfirmTime time.Duration
+	var successTimes []time.Duration
 	nodeStats := make(map[string]*models.NodeStats)
 
 	for _, tx := range transactions {
+		totalTokensRequested += tx.RequestedAmount
+		if tx.RequestedAmount != tx.TokenAmount {
+			adjustedTransactions++
+		}
+		if tx.Attempts > 1 {
+			retriedTransactions++
+		}
+
 		if tx.Status == "success" {
 			successCount++
 			totalTokensTransferred += tx.TokenAmount
+			successTimes = append(successTimes, tx.TimeTaken)
+			totalSubmitTime += tx.SubmitTime
+			totalConfirmTime += tx.ConfirmTime
 		} else {
 			failureCount++
 		}
@@ -314,7 +970,7 @@ func (ss *SimulationService) processTransactions(simulationID string, transactio
 				TransactionsHandled:    0,
 				SuccessfulTransactions: 0,
 				FailedTransactions:     0,
-				AverageTransactionTime:         0,
+				AverageTransactionTime: 0,
 				TotalTokensTransferred: float64(0),
 			}
 		}
@@ -331,12 +987,32 @@ func (ss *SimulationService) processTransactions(simulationID string, transactio
 		stats.AverageTransactionTime += tx.TimeTaken
 	}
 
+	if len(transactions) == 0 {
+		minTransactionTime = 0
+	}
+
 	// Calculate averages
 	avgLatency := float64(0)
 	if len(transactions) > 0 {
 		avgLatency = float64(totalLatency.Milliseconds()) / float64(len(transactions))
 	}
 
+	// Attribute received transactions to nodes by DID
+	nodeIDByDID := make(map[string]string)
+	for _, node := range report.Nodes {
+		nodeIDByDID[node.DID] = node.ID
+	}
+	for _, tx := range transactions {
+		receiverID, known := nodeIDByDID[tx.Receiver]
+		if !known {
+			receiverID = tx.Receiver
+		}
+		if _, exists := nodeStats[receiverID]; !exists {
+			nodeStats[receiverID] = &models.NodeStats{NodeID: receiverID}
+		}
+		nodeStats[receiverID].TransactionsReceived++
+	}
+
 	// Convert map to slice and calculate average latency for each node
 	nodeBreakdown := make([]models.NodeStats, 0, len(nodeStats))
 	for _, stats := range nodeStats {
@@ -353,22 +1029,293 @@ func (ss *SimulationService) processTransactions(simulationID string, transactio
 	report.AverageTransactionTime = avgLatency
 	report.MinTransactionTime = minTransactionTime
 	report.MaxTransactionTime = maxTransactionTime
+	report.AverageSubmitTime = 0
+	report.AverageConfirmTime = 0
+	if successCount > 0 {
+		report.AverageSubmitTime = float64(totalSubmitTime.Milliseconds()) / float64(successCount)
+		report.AverageConfirmTime = float64(totalConfirmTime.Milliseconds()) / float64(successCount)
+	}
+	sort.Slice(successTimes, func(i, j int) bool { return successTimes[i] < successTimes[j] })
+	report.P50TransactionTime = percentile(successTimes, 50)
+	report.P95TransactionTime = percentile(successTimes, 95)
+	report.P99TransactionTime = percentile(successTimes, 99)
 	report.TotalTokensTransferred = totalTokensTransferred
+	report.TotalTokensRequested = totalTokensRequested
+	report.AdjustedTransactions = adjustedTransactions
+	report.RetriedTransactions = retriedTransactions
 	report.NodeBreakdown = nodeBreakdown
+	report.FailureBreakdown = failureBreakdown(transactions)
+	report.Fairness = computeFairness(report.Nodes, nodeStats)
 
-	return report
+	window := time.Duration(report.Config.ThroughputWindowSeconds) * time.Second
+	if window <= 0 {
+		window = defaultThroughputWindow
+	}
+	report.ThroughputSeries = throughputSeries(transactions, window)
 }
 
-func (ss *SimulationService) GetReport(simulationID string) (*models.SimulationReport, error) {
-	ss.mu.RLock()
-	defer ss.mu.RUnlock()
+// percentile returns the nearest-rank p-th percentile of durations sorted ascending, or 0
+// when there are none
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// refillFunc returns the TransactionOptions.Refill hook of a simulation: it tops up the
+// sender and records the attempt, successful or not, in the report
+func (ss *SimulationService) refillFunc(simulationID string) func(node *models.Node, balance float64) error {
+	return func(node *models.Node, balance float64) error {
+		start := time.Now()
+		logging.Infof("Refilling %s mid-simulation (balance %.3f RBT)", node.ID, balance)
+		tokens, err := ss.nodeManager.TopUpNode(node.ID)
+		event := models.RefillEvent{
+			NodeID:    node.ID,
+			Tokens:    tokens,
+			Balance:   balance,
+			Timestamp: start,
+			Duration:  time.Since(start),
+		}
+		if err != nil {
+			event.Error = err.Error()
+		}
+		ss.updateReport(simulationID, func(report *models.SimulationReport) {
+			report.Refills = append(report.Refills, event)
+		})
+		return err
+	}
+}
+
+// failureBreakdown counts failed transactions per failure category, most frequent first,
+// or returns nil when nothing failed
+func failureBreakdown(transactions []models.Transaction) []models.FailureCategoryCount {
+	counts := make(map[string]int)
+	for _, tx := range transactions {
+		if tx.Status == "failed" {
+			counts[categorizeFailure(tx.Error)]++
+		}
+	}
+	if len(counts) == 0 {
+		return nil
+	}
+
+	breakdown := make([]models.FailureCategoryCount, 0, len(counts))
+	for category, count := range counts {
+		breakdown = append(breakdown, models.FailureCategoryCount{Category: category, Count: count})
+	}
+	sort.Slice(breakdown, func(i, j int) bool {
+		if breakdown[i].Count != breakdown[j].Count {
+			return breakdown[i].Count > breakdown[j].Count
+		}
+		return breakdown[i].Category < breakdown[j].Category
+	})
+	return breakdown
+}
+
+// defaultThroughputWindow is used for reports that predate the configurable window
+const defaultThroughputWindow = 5 * time.Second
+
+// throughputSeries buckets completed transactions by their start time into fixed windows
+// measured from the first transaction. Empty windows are kept so the series has no gaps.
+func throughputSeries(transactions []models.Transaction, window time.Duration) []models.ThroughputBucket {
+	var first, last time.Time
+	for _, tx := range transactions {
+		if tx.Timestamp.IsZero() {
+			continue
+		}
+		if first.IsZero() || tx.Timestamp.Before(first) {
+			first = tx.Timestamp
+		}
+		if tx.Timestamp.After(last) {
+			last = tx.Timestamp
+		}
+	}
+	if first.IsZero() {
+		return nil
+	}
+
+	series := make([]models.ThroughputBucket, int(last.Sub(first)/window)+1)
+	for i := range series {
+		offset := time.Duration(i) * window
+		series[i].Start = first.Add(offset)
+		series[i].OffsetSeconds = offset.Seconds()
+	}
+	for _, tx := range transactions {
+		if tx.Timestamp.IsZero() {
+			continue
+		}
+		bucket := &series[int(tx.Timestamp.Sub(first)/window)]
+		bucket.Completed++
+		if tx.Status == "success" {
+			bucket.Successful++
+		}
+	}
+	for i := range series {
+		series[i].TPS = float64(series[i].Completed) / window.Seconds()
+	}
+	return series
+}
+
+// computeFairness measures how evenly transactions were initiated and received across
+// the transaction nodes. Nodes that never appeared in a transaction count as zero.
+func computeFairness(nodes []models.Node, nodeStats map[string]*models.NodeStats) *models.FairnessMetrics {
+	nodeIDs := make(map[string]bool)
+	for _, node := range nodes {
+		if !node.IsQuorum {
+			nodeIDs[node.ID] = true
+		}
+	}
+	for nodeID := range nodeStats {
+		nodeIDs[nodeID] = true
+	}
+	if len(nodeIDs) == 0 {
+		return nil
+	}
+
+	initiated := make([]int, 0, len(nodeIDs))
+	received := make([]int, 0, len(nodeIDs))
+	for nodeID := range nodeIDs {
+		if stats, exists := nodeStats[nodeID]; exists {
+			initiated = append(initiated, stats.TransactionsHandled)
+			received = append(received, stats.TransactionsReceived)
+		} else {
+			initiated = append(initiated, 0)
+			received = append(received, 0)
+		}
+	}
+
+	return &models.FairnessMetrics{
+		Initiated: loadDistribution(initiated),
+		Received:  loadDistribution(received),
+	}
+}
+
+// loadDistribution computes min, max, min/max ratio and the Gini coefficient of per-node counts
+func loadDistribution(counts []int) models.LoadDistribution {
+	sorted := append([]int(nil), counts...)
+	sort.Ints(sorted)
+
+	dist := models.LoadDistribution{
+		Min: sorted[0],
+		Max: sorted[len(sorted)-1],
+	}
+	if dist.Max > 0 {
+		dist.MinMaxRatio = float64(dist.Min) / float64(dist.Max)
+	}
+
+	// G = 2*sum(i*x_i) / (n*sum(x)) - (n+1)/n over ascending values, i from 1
+	n := float64(len(sorted))
+	total, weighted := 0, 0
+	for i, count := range sorted {
+		total += count
+		weighted += (i + 1) * count
+	}
+	if total > 0 {
+		dist.Gini = 2*float64(weighted)/(n*float64(total)) - (n+1)/n
+	}
+
+	return dist
+}
+
+// CancelSimulation stops a running simulation. The in-flight round's transfers are
+// abandoned, no further rounds start, and the report is finished with the transactions
+// executed so far.
+func (ss *SimulationService) CancelSimulation(simulationID string) error {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
 
 	report, exists := ss.simulations[simulationID]
 	if !exists {
-		return nil, fmt.Errorf("simulation %s not found", simulationID)
+		return fmt.Errorf("%w: %s", ErrSimulationNotFound, simulationID)
+	}
+	cancel, running := ss.cancels[simulationID]
+	if report.IsFinished || !running {
+		return fmt.Errorf("simulation %s %w", simulationID, ErrSimulationFinished)
+	}
+
+	logging.Infof("Cancelling simulation %s", simulationID)
+	cancel()
+	return nil
+}
+
+// GetFilteredReport returns a copy of a simulation report scoped to the transactions matching
+// the filter, with summary metrics and node breakdown recomputed over that subset
+func (ss *SimulationService) GetFilteredReport(simulationID string, filter models.ReportFilter) (*models.SimulationReport, error) {
+	filtered, err := ss.GetReport(simulationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve DIDs to node IDs so pair filters can be expressed with readable node IDs
+	nodeByDID := make(map[string]string)
+	for _, node := range filtered.Nodes {
+		nodeByDID[node.DID] = node.ID
+	}
+
+	transactions := []models.Transaction{}
+	for _, tx := range filtered.Transactions {
+		if transactionMatchesFilter(tx, filter, nodeByDID) {
+			transactions = append(transactions, tx)
+		}
 	}
 
-	return report, nil
+	aggregateTransactions(filtered, transactions)
+	filtered.TotalTransactions = len(transactions)
+	filtered.Filter = &filter
+	return filtered, nil
+}
+
+// transactionMatchesFilter reports whether a transaction satisfies every criterion set on the filter
+func transactionMatchesFilter(tx models.Transaction, filter models.ReportFilter, nodeByDID map[string]string) bool {
+	if filter.Status != "" && tx.Status != filter.Status {
+		return false
+	}
+	if filter.MinAmount > 0 && tx.TokenAmount < filter.MinAmount {
+		return false
+	}
+	if filter.MaxAmount > 0 && tx.TokenAmount > filter.MaxAmount {
+		return false
+	}
+	if filter.Node != "" && tx.NodeID != filter.Node && nodeByDID[tx.Receiver] != filter.Node {
+		return false
+	}
+	if len(filter.NodePairs) > 0 {
+		sender, receiver := nodeByDID[tx.Sender], nodeByDID[tx.Receiver]
+		for _, pair := range filter.NodePairs {
+			if pair.Sender == sender && pair.Receiver == receiver {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// GetReport returns a snapshot of a simulation report; the live report is only ever
+// mutated under ss.mu via updateReport/updateProgress
+func (ss *SimulationService) GetReport(simulationID string) (*models.SimulationReport, error) {
+	ss.mu.Lock()
+	report, exists := ss.simulations[simulationID]
+	if exists {
+		ss.markIfStalled(report)
+		report = cloneReport(report)
+	}
+	ss.mu.Unlock()
+	if exists {
+		return report, nil
+	}
+
+	// Finished runs whose state was cleaned up still have their saved JSON report. Read it
+	// without holding mu, so a slow disk doesn't stall running simulations' updates.
+	if saved, err := ss.reportGenerator.LoadReportJSON(simulationID); err == nil {
+		return saved, nil
+	}
+	return nil, fmt.Errorf("simulation %s not found", simulationID)
 }
 
 // GetSimulationReport is an alias for GetReport to match the handler's expectation
@@ -376,14 +1323,159 @@ func (ss *SimulationService) GetSimulationReport(simulationID string) (*models.S
 	return ss.GetReport(simulationID)
 }
 
+// heartbeatInterval is how often a running simulation refreshes its heartbeat between
+// progress updates
+const heartbeatInterval = 30 * time.Second
+
+// startHeartbeat refreshes a simulation's heartbeat every heartbeatInterval until the
+// returned function is called
+func (ss *SimulationService) startHeartbeat(simulationID string) func() {
+	stop := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(heartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				ss.mu.Lock()
+				if report, exists := ss.simulations[simulationID]; exists && !report.IsFinished {
+					report.LastHeartbeat = time.Now()
+				}
+				ss.mu.Unlock()
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return func() { close(stop) }
+}
+
+// updateReport applies a lifecycle update from the simulation goroutine and persists it. A
+// report that is already finished, e.g. marked stalled, keeps its final state.
 func (ss *SimulationService) updateReport(simulationID string, updateFunc func(*models.SimulationReport)) {
 	ss.mu.Lock()
 	defer ss.mu.Unlock()
 	
-	if report, exists := ss.simulations[simulationID]; exists {
+	if report, exists := ss.simulations[simulationID]; exists && !report.IsFinished {
 		updateFunc(report)
+		if report.IsFinished {
+			clearPaused(report)
+		}
+		// Every update from the simulation goroutine doubles as a liveness heartbeat
+		report.LastHeartbeat = time.Now()
 		// Persist the updated report to disk
 		ss.persistSimulationToDisk(report)
+		ss.publishProgress(report)
+		if report.IsFinished {
+			delete(ss.lastSnapshot, simulationID)
+			if err := ss.reportGenerator.SaveReportJSON(report); err != nil {
+				logging.Errorf("ERROR: Failed to save JSON report %s: %v", simulationID, err)
+			}
+		}
+	}
+}
+
+// Shutdown stops running simulations from starting new rounds and waits for them to
+// checkpoint, bounded by ctx. Any simulation still unfinished when ctx expires (e.g.
+// blocked on a slow transfer) is persisted with its partial progress and marked as
+// interrupted so it is not left looking like it is still running after a restart.
+func (ss *SimulationService) Shutdown(ctx context.Context) error {
+	ss.simMu.Lock()
+	ss.cancel()
+	ss.simMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		ss.runs.Wait()
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	for id, report := range ss.simulations {
+		if report.IsFinished {
+			continue
+		}
+		endTime := time.Now()
+		report.Config.EndedAt = &endTime
+		report.IsFinished = true
+		clearPaused(report)
+		report.Error = fmt.Sprintf("Interrupted by server shutdown after %d/%d transactions",
+			report.TransactionsCompleted, report.TotalTransactions)
+		ss.persistSimulationToDisk(report)
+		ss.publishProgress(report)
+		logging.Infof("Checkpointed unfinished simulation %s on shutdown", id)
+	}
+
+	return err
+}
+
+// updateProgress applies a mid-run progress update. Unlike updateReport it only
+// checkpoints the partial report to disk every SnapshotSeconds, so long runs with
+// many rounds don't rewrite the whole report after each one; a crash loses at most
+// that interval. Lifecycle changes still go through updateReport and persist immediately.
+func (ss *SimulationService) updateProgress(simulationID string, updateFunc func(*models.SimulationReport)) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	report, exists := ss.simulations[simulationID]
+	if !exists || report.IsFinished {
+		return
+	}
+	updateFunc(report)
+	report.LastHeartbeat = time.Now()
+	ss.publishProgress(report)
+
+	if ss.config.SnapshotSeconds <= 0 {
+		return
+	}
+	interval := time.Duration(ss.config.SnapshotSeconds) * time.Second
+	if time.Since(ss.lastSnapshot[simulationID]) >= interval {
+		ss.persistSimulationToDisk(report)
+		ss.lastSnapshot[simulationID] = time.Now()
+	}
+}
+
+// markIfStalled fails a running simulation whose goroutine has stopped sending heartbeats,
+// e.g. one orphaned by a server restart, and cancels the run if it is still live so it
+// stops transacting and releases its nodes. Caller must hold ss.mu.
+func (ss *SimulationService) markIfStalled(report *models.SimulationReport) {
+	if report.IsFinished || ss.config.StaleMinutes <= 0 {
+		return
+	}
+	// A paused run sends no heartbeats by design; one orphaned by a restart still goes stale
+	if _, live := ss.pauses[report.SimulationID]; live && report.IsPaused {
+		return
+	}
+
+	lastSeen := report.LastHeartbeat
+	if lastSeen.IsZero() {
+		lastSeen = report.CreatedAt
+	}
+
+	staleAfter := time.Duration(ss.config.StaleMinutes) * time.Minute
+	if time.Since(lastSeen) > staleAfter {
+		logging.Warnf("WARNING: Simulation %s has not sent a heartbeat since %s, marking as stalled",
+			report.SimulationID, lastSeen.Format(time.RFC3339))
+		report.IsFinished = true
+		clearPaused(report)
+		report.Error = "simulation appears stalled"
+		ss.persistSimulationToDisk(report)
+		ss.publishProgress(report)
+		delete(ss.lastSnapshot, report.SimulationID)
+		if err := ss.reportGenerator.SaveReportJSON(report); err != nil {
+			logging.Errorf("ERROR: Failed to save JSON report %s: %v", report.SimulationID, err)
+		}
+		if cancel, running := ss.cancels[report.SimulationID]; running {
+			cancel()
+		}
 	}
 }
 
@@ -393,13 +1485,18 @@ func (ss *SimulationService) persistSimulationToDisk(report *models.SimulationRe
 	
 	data, err := json.MarshalIndent(report, "", "  ")
 	if err != nil {
-		log.Printf("ERROR: Failed to marshal simulation report %s: %v", report.SimulationID, err)
+		logging.Errorf("ERROR: Failed to marshal simulation report %s: %v", report.SimulationID, err)
 		return
 	}
 	
-	err = os.WriteFile(filePath, data, 0644)
-	if err != nil {
-		log.Printf("ERROR: Failed to persist simulation report %s: %v", report.SimulationID, err)
+	// Write to a temp file and rename so a crash mid-write never leaves a truncated report
+	tmpPath := filePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		logging.Errorf("ERROR: Failed to persist simulation report %s: %v", report.SimulationID, err)
+		return
+	}
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		logging.Errorf("ERROR: Failed to persist simulation report %s: %v", report.SimulationID, err)
 	}
 }
 
@@ -407,60 +1504,102 @@ func (ss *SimulationService) persistSimulationToDisk(report *models.SimulationRe
 func (ss *SimulationService) loadSimulationsFromDisk() {
 	files, err := filepath.Glob(filepath.Join(ss.persistenceDir, "*.json"))
 	if err != nil {
-		log.Printf("ERROR: Failed to list simulation files: %v", err)
+		logging.Errorf("ERROR: Failed to list simulation files: %v", err)
 		return
 	}
 	
 	for _, file := range files {
 		data, err := os.ReadFile(file)
 		if err != nil {
-			log.Printf("ERROR: Failed to read simulation file %s: %v", file, err)
+			logging.Errorf("ERROR: Failed to read simulation file %s: %v", file, err)
 			continue
 		}
 		
 		var report models.SimulationReport
 		if err := json.Unmarshal(data, &report); err != nil {
-			log.Printf("ERROR: Failed to unmarshal simulation file %s: %v", file, err)
+			logging.Errorf("ERROR: Failed to unmarshal simulation file %s: %v", file, err)
 			continue
 		}
 		
 		ss.simulations[report.SimulationID] = &report
-		log.Printf("Loaded simulation %s from disk (finished: %v)", report.SimulationID, report.IsFinished)
+		logging.Infof("Loaded simulation %s from disk (finished: %v)", report.SimulationID, report.IsFinished)
 	}
 	
-	log.Printf("Loaded %d simulations from disk", len(ss.simulations))
+	logging.Infof("Loaded %d simulations from disk", len(ss.simulations))
 }
 
-// GetActiveSimulations returns all non-finished simulations
+// GetActiveSimulations returns snapshots of all non-finished simulations
 func (ss *SimulationService) GetActiveSimulations() []*models.SimulationReport {
-	ss.mu.RLock()
-	defer ss.mu.RUnlock()
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
 	
 	var activeSimulations []*models.SimulationReport
 	for _, report := range ss.simulations {
+		ss.markIfStalled(report)
 		if !report.IsFinished {
-			activeSimulations = append(activeSimulations, report)
+			activeSimulations = append(activeSimulations, cloneReport(report))
 		}
 	}
 	
 	return activeSimulations
 }
 
-// CleanupFinishedSimulations removes finished simulations from memory and disk
-func (ss *SimulationService) CleanupFinishedSimulations() {
+// CleanupFinishedSimulations removes simulations that finished more than maxAge ago from
+// memory and disk, along with report files (PDF, JSON, CSV) older than maxAge
+func (ss *SimulationService) CleanupFinishedSimulations(maxAge time.Duration) {
+	cutoff := time.Now().Add(-maxAge)
+
 	ss.mu.Lock()
-	defer ss.mu.Unlock()
-	
+	removed := 0
 	for id, report := range ss.simulations {
-		if report.IsFinished {
-			// Remove from memory
-			delete(ss.simulations, id)
-			
-			// Remove from disk
-			filePath := filepath.Join(ss.persistenceDir, id+".json")
-			if err := os.Remove(filePath); err != nil {
-				log.Printf("WARNING: Failed to remove simulation file %s: %v", filePath, err)
-			}
+		if !report.IsFinished {
+			continue
+		}
+		finishedAt := report.LastHeartbeat
+		if report.Config.EndedAt != nil {
+			finishedAt = *report.Config.EndedAt
+		}
+		if finishedAt.After(cutoff) {
+			continue
+		}
+
+		// Remove from memory
+		delete(ss.simulations, id)
+		removed++
+		
+		// Remove from disk
+		filePath := filepath.Join(ss.persistenceDir, id+".json")
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			logging.Warnf("WARNING: Failed to remove simulation file %s: %v", filePath, err)
 		}
 	}
+	ss.mu.Unlock()
+
+	files, err := ss.reportGenerator.PruneReports(cutoff)
+	if err != nil {
+		logging.Warnf("Warning: failed to prune old reports: %v", err)
+	}
+	if removed > 0 || len(files) > 0 {
+		logging.Infof("Retention: removed %d finished simulation(s) and %d report file(s) older than %v: %v",
+			removed, len(files), maxAge, files)
+	}
+}
+
+// StartReportCleanup removes finished simulations and report files older than maxAge once
+// at startup and then every hour, until the service shuts down
+func (ss *SimulationService) StartReportCleanup(maxAge time.Duration) {
+	logging.Infof("Keeping finished simulations and reports for %v", maxAge)
+	go func() {
+		ticker := time.NewTicker(reportCleanupInterval)
+		defer ticker.Stop()
+
+		for {
+			ss.CleanupFinishedSimulations(maxAge)
+			select {
+			case <-ticker.C:
+			case <-ss.ctx.Done():
+				return
+			}
+		}
+	}()
 }
\ No newline at end of file
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	rubixconfig "github.com/rubix-simulator/backend/config"
+	"github.com/rubix-simulator/backend/internal/config"
+	"github.com/rubix-simulator/backend/internal/models"
+)
+
+// newTestSimulationService returns a service with no nodes, saving reports under a temp dir
+func newTestSimulationService(t *testing.T) *SimulationService {
+	t.Helper()
+	cfg := &config.Config{ReportsPath: t.TempDir()}
+	rg, err := NewReportGenerator(cfg)
+	if err != nil {
+		t.Fatalf("NewReportGenerator: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	return &SimulationService{
+		ctx:             ctx,
+		cancel:          cancel,
+		config:          cfg,
+		reportGenerator: rg,
+		simulations:     make(map[string]*models.SimulationReport),
+		lastSnapshot:    make(map[string]time.Time),
+		cancels:         make(map[string]context.CancelFunc),
+		pauses:          make(map[string]*pauseGate),
+		streams:         make(map[string]map[chan models.ProgressFrame]struct{}),
+		persistenceDir:  t.TempDir(),
+	}
+}
+
+// Run with -race: GetReport must hand out snapshots that later updates don't touch
+func TestGetReportConcurrentWithUpdateProgress(t *testing.T) {
+	ss := newTestSimulationService(t)
+	const id = "sim-race"
+	const updates = 200
+	ss.simulations[id] = &models.SimulationReport{SimulationID: id, TotalTransactions: updates}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < updates; i++ {
+			ss.updateProgress(id, func(report *models.SimulationReport) {
+				report.Transactions = append(report.Transactions, models.Transaction{Status: "success"})
+				report.TransactionsCompleted++
+			})
+		}
+	}()
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < updates; i++ {
+				report, err := ss.GetReport(id)
+				if err != nil {
+					t.Errorf("GetReport: %v", err)
+					return
+				}
+				if len(report.Transactions) != report.TransactionsCompleted {
+					t.Errorf("snapshot has %d transactions but %d completed",
+						len(report.Transactions), report.TransactionsCompleted)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	report, err := ss.GetReport(id)
+	if err != nil {
+		t.Fatalf("GetReport: %v", err)
+	}
+	if report.TransactionsCompleted != updates {
+		t.Errorf("TransactionsCompleted = %d, want %d", report.TransactionsCompleted, updates)
+	}
+}
+
+func TestGetReportFallsBackToSavedReport(t *testing.T) {
+	ss := newTestSimulationService(t)
+	saved := &models.SimulationReport{SimulationID: "sim-saved", IsFinished: true, TransactionsCompleted: 3}
+	if err := ss.reportGenerator.SaveReportJSON(saved); err != nil {
+		t.Fatalf("SaveReportJSON: %v", err)
+	}
+
+	report, err := ss.GetReport("sim-saved")
+	if err != nil {
+		t.Fatalf("GetReport: %v", err)
+	}
+	if !report.IsFinished || report.TransactionsCompleted != 3 {
+		t.Errorf("got %+v, want the saved report", report)
+	}
+	if _, err := ss.GetReport("sim-missing"); err == nil {
+		t.Error("GetReport of an unknown simulation succeeded")
+	}
+}
+
+// Regression: the report once declared averageLatency/minLatency/maxLatency while the
+// aggregation wrote other fields, so API consumers read zeros
+func TestAggregatedReportSerializesTransactionTimes(t *testing.T) {
+	start := time.Now()
+	report := &models.SimulationReport{SimulationID: "sim-times", IsFinished: true}
+	aggregateTransactions(report, []models.Transaction{
+		{NodeID: "node2", Status: "success", TokenAmount: 1, TimeTaken: 2 * time.Second, Timestamp: start},
+		{NodeID: "node3", Status: "success", TokenAmount: 1, TimeTaken: 4 * time.Second, Timestamp: start},
+		{NodeID: "node2", Status: "failed", TimeTaken: 3 * time.Second, Timestamp: start},
+	})
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	want := map[string]float64{
+		"averageTransactionTime": 3000,                     // Milliseconds
+		"minTransactionTime":     float64(2 * time.Second), // Nanoseconds
+		"maxTransactionTime":     float64(4 * time.Second),
+	}
+	for key, value := range want {
+		if got, _ := fields[key].(float64); got != value {
+			t.Errorf("%s = %v, want %v", key, fields[key], value)
+		}
+	}
+	for _, stale := range []string{"averageLatency", "minLatency", "maxLatency"} {
+		if _, ok := fields[stale]; ok {
+			t.Errorf("stale %s key in the report JSON", stale)
+		}
+	}
+}
+
+// A start that is turned down must leave the service on the fleet it had
+func TestRejectedStartKeepsFleet(t *testing.T) {
+	ss := newTestSimulationService(t)
+	ss.config.Rubix = rubixconfig.DefaultRubixConfig()
+	ss.nodeManager = &NodeManager{
+		config:    ss.config,
+		nodes:     make(map[string]*models.Node),
+		busyNodes: make(map[string]bool),
+	}
+	externalNodes := []models.Node{
+		{ID: "bob", Port: newFakeNode(t).port(), DID: "did-bob"},
+		{ID: "alice", Port: newFakeNode(t).port(), DID: "did-alice"},
+	}
+
+	tests := []struct {
+		name string
+		req  models.SimulationRequest
+	}{
+		{"invalid request", models.SimulationRequest{Transactions: 0, ExternalNodes: externalNodes}},
+		{"invalid external nodes", models.SimulationRequest{Transactions: 10, ExternalNodes: externalNodes[:1]}},
+		{"too many nodes", models.SimulationRequest{Nodes: 3, Transactions: 10, ExternalNodes: externalNodes}},
+		// The fake nodes hold 100 RBT each, well short of 100 transfers of at least 5 RBT
+		{"insufficient balance", models.SimulationRequest{Transactions: 100, MinTokenAmount: 5, MaxTokenAmount: 10, ExternalNodes: externalNodes}},
+	}
+	for _, tt := range tests {
+		if _, err := ss.StartSimulation(tt.req); err == nil {
+			t.Errorf("%s: StartSimulation succeeded, want an error", tt.name)
+		}
+		if ss.nodeManager.IsExternal() || len(ss.nodeManager.GetNodes()) != 0 {
+			t.Fatalf("%s: rejected start switched the service to the external fleet", tt.name)
+		}
+		if ss.IsSimulationRunning() {
+			t.Fatalf("%s: rejected start is still counted as running", tt.name)
+		}
+	}
+}
+
+func TestExternalFleetHonoursCount(t *testing.T) {
+	nm := &NodeManager{config: &config.Config{}, nodes: make(map[string]*models.Node), busyNodes: make(map[string]bool)}
+	err := nm.UseExternalNodes([]models.Node{
+		{ID: "carol", Port: 20012, DID: "did-carol"},
+		{ID: "alice", Port: 20010, DID: "did-alice"},
+		{ID: "bob", Port: 20011, DID: "did-bob"},
+	})
+	if err != nil {
+		t.Fatalf("UseExternalNodes: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		nodes, err := nm.StartNodes(2)
+		if err != nil {
+			t.Fatalf("StartNodes: %v", err)
+		}
+		if len(nodes) != 2 || nodes[0].ID != "alice" || nodes[1].ID != "bob" {
+			t.Fatalf("StartNodes(2) = %v, want alice and bob", nodes)
+		}
+	}
+	if _, err := nm.StartNodes(4); err == nil {
+		t.Error("StartNodes(4) on a fleet of 3 succeeded, want an error")
+	}
+}
@@ -1,22 +1,257 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"math"
 	"math/rand"
 	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	rubixconfig "github.com/rubix-simulator/backend/config"
 	"github.com/rubix-simulator/backend/internal/config"
+	"github.com/rubix-simulator/backend/internal/logging"
+	"github.com/rubix-simulator/backend/internal/metrics"
 	"github.com/rubix-simulator/backend/internal/models"
 	"github.com/rubix-simulator/backend/internal/rubix"
 )
 
+// Default range of RBT amounts a transaction transfers when the request doesn't set one
+const (
+	defaultMinTransferAmount = 1.0
+	defaultMaxTransferAmount = 10.0
+)
+
+// minTransferPrecision is the smallest amount the Rubix API accepts (3 decimal places)
+const minTransferPrecision = 0.001
+
+// maxTransferAmount is the largest amount a single transaction may transfer. It keeps the
+// range, counted in thousandths, well inside int64.
+const maxTransferAmount = 1_000_000.0
+
+// TransactionOptions shapes the transactions a run generates
+type TransactionOptions struct {
+	MinAmount      float64 // Smallest RBT amount per transaction
+	MaxAmount      float64 // Largest RBT amount per transaction
+	SenderNodeID   string  // With ReceiverNodeID, send every transaction from this node...
+	ReceiverNodeID string  // ...to this one instead of pairing nodes at random
+
+	SenderNodeIDs   []string // With ReceiverNodeIDs, draw senders only from these nodes...
+	ReceiverNodeIDs []string // ...and receivers only from these; the two sets are disjoint
+
+	DryRun            bool    // Synthesize transactions instead of calling the nodes
+	DryRunSuccessRate float64 // Fraction of dry-run transactions that succeed
+
+	Batch bool // Submit each sender's transfers concurrently instead of in paired rounds
+
+	RoundRobin bool // Cycle senders through the nodes in order, each sending to the next node in the ring
+
+	WarmupTransactions int  // Throwaway transfers run before the measured ones
+	Warmup             bool // This execution is the warmup: skip metrics and progress reporting
+
+	InterRoundDelay time.Duration // Sleep between paired rounds; 0 starts the next round at once
+
+	CommentTemplate string // Transaction comment with {index}, {sender}, {receiver} and {amount} placeholders ("" uses the default)
+
+	// Refill tops up a paired-mode sender whose balance is below its next transfer (nil
+	// never refills). It is given the balance that triggered it.
+	Refill func(node *models.Node, balance float64) error
+
+	Pause *pauseGate // Holds the round loop between rounds while the simulation is paused (nil never pauses)
+}
+
+// maxWarmupTransactions caps the warmup phase of a simulation
+const maxWarmupTransactions = 50
+
+// defaultInterRoundDelayMs and maxInterRoundDelayMs bound the pause between paired rounds
+const (
+	defaultInterRoundDelayMs = 500
+	maxInterRoundDelayMs     = 60000
+)
+
+// Transfer modes accepted in a simulation request
+const (
+	TransferModePaired = "paired"
+	TransferModeBatch  = "batch"
+)
+
+// Distribution modes accepted in a simulation request
+const (
+	DistributionRandom     = "random"
+	DistributionRoundRobin = "roundrobin"
+)
+
+// txPlan is one planned transaction between two transaction nodes
+type txPlan struct {
+	index        int
+	senderNode   *models.Node
+	receiverNode *models.Node
+	amount       float64 // Drawn when the plan is scheduled into a round
+}
+
+// minAdjustableBalance is the balance above which a sender that can't cover a transfer
+// sends 80% of its balance instead of failing
+const minAdjustableBalance = 1.0
+
+// canAfford reports whether a sender with the given balance can send amount, possibly
+// adjusted down, rather than failing with insufficient balance
+func canAfford(balance, amount float64) bool {
+	return balance >= amount || balance > minAdjustableBalance
+}
+
+// DefaultTransactionOptions returns random pairing with the default amount range
+func DefaultTransactionOptions() TransactionOptions {
+	return TransactionOptions{MinAmount: defaultMinTransferAmount, MaxAmount: defaultMaxTransferAmount}
+}
+
+// FixedPair reports whether every transaction runs over one fixed sender/receiver pair
+func (o TransactionOptions) FixedPair() bool {
+	return o.SenderNodeID != "" && o.ReceiverNodeID != ""
+}
+
+// NodeAffinity reports whether senders and receivers are drawn from separate node sets
+func (o TransactionOptions) NodeAffinity() bool {
+	return len(o.SenderNodeIDs) > 0 && len(o.ReceiverNodeIDs) > 0
+}
+
+// requiredNodeIDs returns the node IDs a run must include: the fixed pair or the sender
+// and receiver sets, or nil
+func (o TransactionOptions) requiredNodeIDs() []string {
+	if o.FixedPair() {
+		return []string{o.SenderNodeID, o.ReceiverNodeID}
+	}
+	if o.NodeAffinity() {
+		return append(append([]string{}, o.SenderNodeIDs...), o.ReceiverNodeIDs...)
+	}
+	return nil
+}
+
+// defaultDryRunSuccessRate is the fraction of dry-run transactions that succeed when unset
+const defaultDryRunSuccessRate = 0.95
+
+// dryRunNodes creates synthetic transaction nodes for a dry run. They are not registered
+// with the node manager, so a dry run never disturbs the real fleet.
+func dryRunNodes(count int) []*models.Node {
+	nodes := make([]*models.Node, count)
+	for i := range nodes {
+		nodes[i] = &models.Node{
+			ID:      fmt.Sprintf("sim-node-%d", i+1),
+			DID:     fmt.Sprintf("dry-run-did-%d", i+1),
+			Status:  "running",
+			Started: time.Now(),
+		}
+	}
+	return nodes
+}
+
+// resolveFixedPair finds the fixed sender and receiver among the transaction (non-quorum) nodes
+func resolveFixedPair(nodes []*models.Node, opts TransactionOptions) (*models.Node, *models.Node, error) {
+	var sender, receiver *models.Node
+	for _, node := range nodes {
+		if node.IsQuorum {
+			continue
+		}
+		switch node.ID {
+		case opts.SenderNodeID:
+			sender = node
+		case opts.ReceiverNodeID:
+			receiver = node
+		}
+	}
+	if sender == nil {
+		return nil, nil, fmt.Errorf("sender node %s is not one of the simulation's transaction nodes", opts.SenderNodeID)
+	}
+	if receiver == nil {
+		return nil, nil, fmt.Errorf("receiver node %s is not one of the simulation's transaction nodes", opts.ReceiverNodeID)
+	}
+	return sender, receiver, nil
+}
+
+// resolveNodeAffinity finds the sender and receiver sets among the transaction (non-quorum) nodes
+func resolveNodeAffinity(nodes []*models.Node, opts TransactionOptions) ([]*models.Node, []*models.Node, error) {
+	byID := make(map[string]*models.Node)
+	for _, node := range nodes {
+		if !node.IsQuorum {
+			byID[node.ID] = node
+		}
+	}
+	resolve := func(role string, ids []string) ([]*models.Node, error) {
+		resolved := make([]*models.Node, 0, len(ids))
+		for _, id := range ids {
+			node, ok := byID[id]
+			if !ok {
+				return nil, fmt.Errorf("%s node %s is not one of the simulation's transaction nodes", role, id)
+			}
+			resolved = append(resolved, node)
+		}
+		return resolved, nil
+	}
+
+	senders, err := resolve("sender", opts.SenderNodeIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	receivers, err := resolve("receiver", opts.ReceiverNodeIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return senders, receivers, nil
+}
+
+// validateNodeAffinity checks the sender and receiver sets of a request: both or neither
+// are given, they are disjoint, and together they fit in the nodeCount transaction nodes
+// the run uses
+func validateNodeAffinity(senderIDs, receiverIDs []string, nodeCount int) error {
+	if len(senderIDs) == 0 && len(receiverIDs) == 0 {
+		return nil
+	}
+	if len(senderIDs) == 0 || len(receiverIDs) == 0 {
+		return fmt.Errorf("senderNodeIds and receiverNodeIds must both be non-empty")
+	}
+
+	roles := make(map[string]string)
+	sets := []struct {
+		role string
+		ids  []string
+	}{{"senderNodeIds", senderIDs}, {"receiverNodeIds", receiverIDs}}
+	for _, set := range sets {
+		role := set.role
+		for _, id := range set.ids {
+			if id == "" {
+				return fmt.Errorf("%s must not contain empty node IDs", role)
+			}
+			if other, listed := roles[id]; listed {
+				if other == role {
+					return fmt.Errorf("node %s is listed twice in %s", id, role)
+				}
+				return fmt.Errorf("node %s cannot be in both senderNodeIds and receiverNodeIds", id)
+			}
+			roles[id] = role
+		}
+	}
+
+	if len(roles) > nodeCount {
+		return fmt.Errorf("senderNodeIds and receiverNodeIds list %d nodes but the simulation uses %d transaction nodes", len(roles), nodeCount)
+	}
+	return nil
+}
+
+// didReregisterCooldown is the minimum time between peer-discovery re-registrations of one DID
+const didReregisterCooldown = 5 * time.Minute
+
 type TransactionExecutor struct {
 	config     *config.Config
 	httpClient *http.Client
+
+	reregisterMu   sync.Mutex
+	reregisteredAt map[string]time.Time // Last peer-discovery re-registration per DID
 }
 
 func NewTransactionExecutor(cfg *config.Config) *TransactionExecutor {
@@ -25,17 +260,31 @@ func NewTransactionExecutor(cfg *config.Config) *TransactionExecutor {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		reregisteredAt: make(map[string]time.Time),
 	}
 }
 
 // ExecuteTransactions executes real transactions using real Rubix nodes with real DIDs
 // Uses paired transaction model: nodes are paired for each round to prevent conflicts
 func (te *TransactionExecutor) ExecuteTransactions(nodes []*models.Node, count int) []models.Transaction {
-	return te.ExecuteTransactionsWithProgress(nodes, count, nil)
+	return te.ExecuteTransactionsWithProgress(nodes, count, DefaultTransactionOptions(), nil)
+}
+
+// ExecuteTransactionsWithProgress executes transactions shaped by opts and reports progress via callback
+func (te *TransactionExecutor) ExecuteTransactionsWithProgress(nodes []*models.Node, count int, opts TransactionOptions, progressCallback func(completed int, transactions []models.Transaction)) []models.Transaction {
+	return te.ExecuteTransactionsWithContext(context.Background(), nodes, count, opts, progressCallback)
 }
 
-// ExecuteTransactionsWithProgress executes transactions and reports progress via callback
-func (te *TransactionExecutor) ExecuteTransactionsWithProgress(nodes []*models.Node, count int, progressCallback func(completed int, transactions []models.Transaction)) []models.Transaction {
+// ExecuteTransactionsWithContext is ExecuteTransactionsWithProgress with cancellation.
+// Once ctx is done no further rounds are started and the in-flight round's transfers are
+// abandoned, failing with the context's error; only the transactions executed so far are
+// returned.
+func (te *TransactionExecutor) ExecuteTransactionsWithContext(ctx context.Context, nodes []*models.Node, count int, opts TransactionOptions, progressCallback func(completed int, transactions []models.Transaction)) []models.Transaction {
+	// Warmup transfers are thrown away, so they never count towards a report
+	if opts.Warmup {
+		progressCallback = nil
+	}
+
 	// Filter out quorum nodes - only use non-quorum nodes for transactions
 	transactionNodes := make([]*models.Node, 0)
 	for _, node := range nodes {
@@ -45,23 +294,23 @@ func (te *TransactionExecutor) ExecuteTransactionsWithProgress(nodes []*models.N
 	}
 
 	if len(transactionNodes) < 2 {
-		log.Println("ERROR: Need at least 2 transaction nodes for sender and receiver")
+		logging.Errorf("ERROR: Need at least 2 transaction nodes for sender and receiver")
 		return []models.Transaction{}
 	}
 
 	// Verify all transaction nodes have DIDs (created by Python script)
 	for _, node := range transactionNodes {
 		if node.DID == "" {
-			log.Printf("ERROR: Node %s does not have a DID. Ensure rubixgoplatform is running and DIDs are created.", node.ID)
+			logging.Errorf("ERROR: Node %s does not have a DID. Ensure rubixgoplatform is running and DIDs are created.", node.ID)
 			return []models.Transaction{}
 		}
 	}
 
-	log.Printf("Executing %d real transactions using %d transaction nodes (paired model)", count, len(transactionNodes))
+	logging.Infof("Executing %d real transactions using %d transaction nodes (paired model)", count, len(transactionNodes))
 
 	// IMPORTANT: Re-register each node's own DID to ensure peer discovery
 	// This triggers the pub/sub broadcast mechanism for peer discovery
-	// log.Printf("Re-registering DIDs to ensure peer discovery before transactions...")
+	// logging.Infof("Re-registering DIDs to ensure peer discovery before transactions...")
 
 	// // Register each node's own DID (this will broadcast via pub/sub)
 	// for _, node := range nodes {
@@ -81,19 +330,19 @@ func (te *TransactionExecutor) ExecuteTransactionsWithProgress(nodes []*models.N
 	// 		didDisplay = didDisplay[:16] + "..."
 	// 	}
 
-	// 	log.Printf("Registering %s node %s DID: %s", nodeType, node.ID, didDisplay)
+	// 	logging.Infof("Registering %s node %s DID: %s", nodeType, node.ID, didDisplay)
 
 	// 	// Register this node's own DID (will broadcast via pub/sub)
-	// 	err := client.RegisterDID(node.DID, "mypassword") // Using default password
+	// 	err := client.RegisterDID(node.DID, te.privKeyPassword())
 	// 	if err != nil {
 	// 		// If already registered, that's fine - it will still trigger broadcast
 	// 		if err.Error() != "DID already registered" && err.Error() != "already_registered" {
-	// 			log.Printf("  ⚠ Warning: Failed to register DID for %s: %v", node.ID, err)
+	// 			logging.Warnf("  ⚠ Warning: Failed to register DID for %s: %v", node.ID, err)
 	// 		} else {
-	// 			log.Printf("  ✓ DID already registered for %s (broadcast triggered)", node.ID)
+	// 			logging.Infof("  ✓ DID already registered for %s (broadcast triggered)", node.ID)
 	// 		}
 	// 	} else {
-	// 		log.Printf("  ✓ DID registered for %s (broadcast sent)", node.ID)
+	// 		logging.Infof("  ✓ DID registered for %s (broadcast sent)", node.ID)
 	// 	}
 
 	// 	// Small delay to avoid overwhelming the network
@@ -101,20 +350,66 @@ func (te *TransactionExecutor) ExecuteTransactionsWithProgress(nodes []*models.N
 	// }
 
 	// // Wait for pub/sub propagation across the network
-	// log.Printf("Waiting 2 seconds for pub/sub broadcast to complete...")
+	// logging.Infof("Waiting 2 seconds for pub/sub broadcast to complete...")
 	// time.Sleep(2 * time.Second)
 
 	// Pre-generate all transaction plans with random pairs
-	type txPlan struct {
-		index        int
-		senderNode   *models.Node
-		receiverNode *models.Node
+	allPlans := make([]txPlan, 0, count)
+
+	// A fixed pair shares both nodes in every plan, so the rounds below run its
+	// transactions one at a time
+	if opts.FixedPair() {
+		senderNode, receiverNode, err := resolveFixedPair(transactionNodes, opts)
+		if err != nil {
+			logging.Errorf("ERROR: %v", err)
+			return []models.Transaction{}
+		}
+		logging.Infof("Using fixed pair %s -> %s for all transactions", senderNode.ID, receiverNode.ID)
+		for i := 0; i < count; i++ {
+			allPlans = append(allPlans, txPlan{
+				index:        i,
+				senderNode:   senderNode,
+				receiverNode: receiverNode,
+			})
+		}
 	}
 
-	allPlans := make([]txPlan, 0, count)
+	// Round robin gives every node the same share of sends and receives: transaction i
+	// goes from node i to node i+1 around the ring
+	if opts.RoundRobin && !opts.FixedPair() {
+		ring := append([]*models.Node(nil), transactionNodes...)
+		sort.Slice(ring, func(i, j int) bool { return ring[i].ID < ring[j].ID })
+		for i := 0; i < count; i++ {
+			allPlans = append(allPlans, txPlan{
+				index:        i,
+				senderNode:   ring[i%len(ring)],
+				receiverNode: ring[(i+1)%len(ring)],
+			})
+		}
+	}
+
+	// Node affinity pairs a random sender from one set with a random receiver from the
+	// other, so no node both sends and receives
+	senderPool := transactionNodes
+	if opts.NodeAffinity() {
+		senders, receivers, err := resolveNodeAffinity(transactionNodes, opts)
+		if err != nil {
+			logging.Errorf("ERROR: %v", err)
+			return []models.Transaction{}
+		}
+		logging.Infof("Using sender nodes [%s] and receiver nodes [%s]", nodeIDs(senders), nodeIDs(receivers))
+		senderPool = senders
+		for i := 0; i < count; i++ {
+			allPlans = append(allPlans, txPlan{
+				index:        i,
+				senderNode:   senders[rand.Intn(len(senders))],
+				receiverNode: receivers[rand.Intn(len(receivers))],
+			})
+		}
+	}
 
 	// Generate random transaction plans
-	for i := 0; i < count; i++ {
+	for i := len(allPlans); i < count; i++ {
 		// Select random sender node
 		senderIdx := rand.Intn(len(transactionNodes))
 		senderNode := transactionNodes[senderIdx]
@@ -133,15 +428,44 @@ func (te *TransactionExecutor) ExecuteTransactionsWithProgress(nodes []*models.N
 		})
 	}
 
+	if opts.Batch && !opts.DryRun {
+		return te.executeBatchTransactions(ctx, allPlans, count, opts, progressCallback)
+	}
+
 	transactions := make([]models.Transaction, count)
 	transactionIndex := 0
 	roundNumber := 1
 
+	// With random pairing, senders that can no longer afford a transfer are swapped for a
+	// funded node instead of failing (from the sender set under node affinity); fixed pairs
+	// and round robin keep their senders. Auto refill keeps senders funded itself.
+	avoidDrainedSenders := !opts.DryRun && !opts.FixedPair() && !opts.RoundRobin && opts.Refill == nil
+
 	// Process transactions in rounds with pairing
 	for transactionIndex < len(allPlans) {
+		opts.Pause.wait(ctx)
+
+		if err := ctx.Err(); err != nil {
+			logging.Infof("Stopping after %d round(s): %v", roundNumber-1, err)
+			return executedTransactions(transactions)
+		}
+
+		// Balances are fetched once per round and decremented locally as transfers are
+		// planned; nodes that don't answer are absent and treated as funded
+		var balances map[string]float64
+		if avoidDrainedSenders {
+			balances = te.SnapshotBalances(transactionNodes)
+		}
+		funded := func(node *models.Node, amount float64) bool {
+			balance, known := balances[node.ID]
+			return !known || canAfford(balance, amount)
+		}
+
 		// Track which nodes are busy in this round
 		busyNodes := make(map[string]bool)
 		roundPlans := make([]txPlan, 0)
+		var deferred *txPlan
+		reassigned := 0
 
 		// Select transactions for this round (ensuring no node is used twice)
 		for i := transactionIndex; i < len(allPlans); i++ {
@@ -154,6 +478,31 @@ func (te *TransactionExecutor) ExecuteTransactionsWithProgress(nodes []*models.N
 
 			// Check if either node is already busy in this round
 			if !busyNodes[plan.senderNode.ID] && !busyNodes[plan.receiverNode.ID] {
+				plan.amount = randomTransferAmount(opts.MinAmount, opts.MaxAmount)
+
+				if avoidDrainedSenders && !funded(plan.senderNode, plan.amount) {
+					var candidates []*models.Node
+					for _, node := range senderPool {
+						if !busyNodes[node.ID] && node.ID != plan.receiverNode.ID && funded(node, plan.amount) {
+							candidates = append(candidates, node)
+						}
+					}
+					if len(candidates) == 0 {
+						// Wait for a funded node to free up in a later round
+						if deferred == nil {
+							pending := plan
+							deferred = &pending
+						}
+						continue
+					}
+					plan.senderNode = candidates[rand.Intn(len(candidates))]
+					allPlans[i].senderNode = plan.senderNode
+					reassigned++
+				}
+				if balance, known := balances[plan.senderNode.ID]; known {
+					balances[plan.senderNode.ID] = balance - plan.amount
+				}
+
 				// Mark both nodes as busy
 				busyNodes[plan.senderNode.ID] = true
 				busyNodes[plan.receiverNode.ID] = true
@@ -169,14 +518,23 @@ func (te *TransactionExecutor) ExecuteTransactionsWithProgress(nodes []*models.N
 			}
 		}
 
+		if len(roundPlans) == 0 && deferred != nil {
+			// No funded sender is free even in an empty round; run the transfer as planned
+			// so it fails with insufficient balance rather than waiting forever
+			roundPlans = append(roundPlans, *deferred)
+		}
+		if reassigned > 0 {
+			logging.Infof("Round %d: Reassigned %d transaction(s) from drained senders", roundNumber, reassigned)
+		}
+
 		if len(roundPlans) == 0 {
 			// This shouldn't happen, but handle it gracefully
-			log.Printf("Warning: No valid pairs found in round %d, moving to next transaction", roundNumber)
+			logging.Warnf("Warning: No valid pairs found in round %d, moving to next transaction", roundNumber)
 			transactionIndex++
 			continue
 		}
 
-		log.Printf("Round %d: Executing %d parallel transaction(s)", roundNumber, len(roundPlans))
+		logging.Infof("Round %d: Executing %d parallel transaction(s)", roundNumber, len(roundPlans))
 
 		// Execute this round's transactions in parallel
 		var wg sync.WaitGroup
@@ -189,18 +547,35 @@ func (te *TransactionExecutor) ExecuteTransactionsWithProgress(nodes []*models.N
 				senderDID := p.senderNode.DID
 				receiverDID := p.receiverNode.DID
 
-				log.Printf("  Round %d: Executing transaction %d: %s -> %s",
+				sampledf(te.logSampled(p.index), "  Round %d: Executing transaction %d: %s -> %s",
 					roundNumber, p.index, p.senderNode.ID, p.receiverNode.ID)
 
 				// Execute the transaction
-				transaction := te.executeRealTransaction(
-					p.senderNode,
-					senderDID,
-					p.receiverNode,
-					receiverDID,
-					p.index,
-				)
+				amount := p.amount
+				var transaction models.Transaction
+				if opts.DryRun {
+					transaction = executeDryRunTransaction(p.senderNode, p.receiverNode, p.index, amount, opts.DryRunSuccessRate, opts.CommentTemplate)
+				} else {
+					transaction = te.executeRealTransaction(
+						ctx,
+						p.senderNode,
+						senderDID,
+						p.receiverNode,
+						receiverDID,
+						p.index,
+						amount,
+						opts.Refill,
+						opts.CommentTemplate,
+					)
+				}
+				if transaction.Status == "" {
+					// Abandoned when the simulation was cancelled
+					return
+				}
 				transactions[p.index] = transaction
+				if !opts.DryRun && !opts.Warmup {
+					metrics.RecordTransaction(transaction.Status == "success")
+				}
 
 				// Mark this plan as processed (set both to nil to avoid partial state)
 				for j := range allPlans {
@@ -224,7 +599,7 @@ func (te *TransactionExecutor) ExecuteTransactionsWithProgress(nodes []*models.N
 					completedCount++
 				}
 			}
-			log.Printf("Progress update: %d/%d transactions completed", completedCount, count)
+			logging.Infof("Progress update: %d/%d transactions completed", completedCount, count)
 			progressCallback(completedCount, transactions)
 		}
 
@@ -233,79 +608,505 @@ func (te *TransactionExecutor) ExecuteTransactionsWithProgress(nodes []*models.N
 			transactionIndex++
 		}
 
-		// Small delay between rounds to ensure blockchain state is updated
-		if transactionIndex < len(allPlans) {
-			time.Sleep(500 * time.Millisecond)
+		// Give the nodes time to settle the round before the next one. Each round's amounts
+		// are checked against balances queried at its start, so with a short or zero delay
+		// those balances can still reflect tokens locked by the previous round, and
+		// transfers fail or get adjusted that a longer delay would let through.
+		if transactionIndex < len(allPlans) && opts.InterRoundDelay > 0 {
+			select {
+			case <-time.After(opts.InterRoundDelay):
+			case <-ctx.Done():
+			}
 		}
 
 		roundNumber++
 	}
 
-	log.Printf("Completed %d transactions in %d rounds", count, roundNumber-1)
-	return transactions
+	logging.Infof("Completed %d transactions in %d rounds", count, roundNumber-1)
+	return executedTransactions(transactions)
+}
+
+// executedTransactions returns the transactions that were run, leaving out those never
+// started or abandoned when the simulation was cancelled
+func executedTransactions(transactions []models.Transaction) []models.Transaction {
+	executed := make([]models.Transaction, 0, len(transactions))
+	for _, tx := range transactions {
+		if tx.Status != "" {
+			executed = append(executed, tx)
+		}
+	}
+	return executed
+}
+
+// RunWarmup executes opts.WarmupTransactions throwaway transfers so peer discovery and
+// pledging settle before measuring. Their results are only logged.
+func (te *TransactionExecutor) RunWarmup(ctx context.Context, nodes []*models.Node, opts TransactionOptions) {
+	if opts.WarmupTransactions <= 0 {
+		return
+	}
+
+	logging.Infof("Warmup: running %d unmeasured transaction(s)...", opts.WarmupTransactions)
+	start := time.Now()
+	warmupOpts := opts
+	warmupOpts.Batch = false
+	warmupOpts.Warmup = true
+	transactions := te.ExecuteTransactionsWithContext(ctx, nodes, opts.WarmupTransactions, warmupOpts, nil)
+
+	succeeded := 0
+	for _, tx := range transactions {
+		if tx.Status == "success" {
+			succeeded++
+		}
+	}
+	logging.Infof("Warmup finished in %v: %d/%d succeeded (excluded from the report)", time.Since(start), succeeded, len(transactions))
+}
+
+// executeBatchTransactions submits every sender's planned transfers in batches, with all
+// senders running in parallel. Transfers don't wait for earlier consensus rounds, so the
+// balance pre-check and amount adjustment of the paired path are skipped. Each sender
+// submits batchSize transfers at a time, and before each batch waits while the simulation
+// is paused and stops once ctx is done, abandoning the transfers in flight. Progress is
+// reported as each batch finishes; only the transactions executed are returned.
+func (te *TransactionExecutor) executeBatchTransactions(ctx context.Context, plans []txPlan, count int, opts TransactionOptions, progressCallback func(completed int, transactions []models.Transaction)) []models.Transaction {
+	bySender := make(map[string][]txPlan)
+	for _, plan := range plans {
+		bySender[plan.senderNode.ID] = append(bySender[plan.senderNode.ID], plan)
+	}
+	batchSize := te.batchSize()
+	logging.Infof("Batch mode: submitting %d transactions from %d sender node(s), %d at a time per sender", count, len(bySender), batchSize)
+
+	transactions := make([]models.Transaction, count)
+	completed := 0
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, senderPlans := range bySender {
+		wg.Add(1)
+		go func(senderPlans []txPlan) {
+			defer wg.Done()
+
+			senderNode := senderPlans[0].senderNode
+			client := rubix.NewClientWithConfig(senderNode.Port, te.config.Rubix)
+			client.SetVerbose(false)
+
+			for len(senderPlans) > 0 {
+				opts.Pause.wait(ctx)
+				if ctx.Err() != nil {
+					logging.Infof("Batch from %s stopped with %d transfer(s) not submitted: %v", senderNode.ID, len(senderPlans), ctx.Err())
+					return
+				}
+
+				batch := senderPlans
+				if len(batch) > batchSize {
+					batch = batch[:batchSize]
+				}
+				senderPlans = senderPlans[len(batch):]
+				executed := te.executeBatch(ctx, client, senderNode, batch, opts)
+
+				mu.Lock()
+				for i, p := range batch {
+					if executed[i].Status != "" {
+						transactions[p.index] = executed[i]
+						completed++
+					}
+				}
+				logging.Infof("Batch from %s finished: %d/%d transactions completed", senderNode.ID, completed, count)
+				if progressCallback != nil {
+					progressCallback(completed, transactions)
+				}
+				mu.Unlock()
+			}
+		}(senderPlans)
+	}
+	wg.Wait()
+
+	return executedTransactions(transactions)
+}
+
+// executeBatch submits one batch of a sender's planned transfers and returns the
+// resulting transactions in batch order. Transfers abandoned because ctx is done are
+// returned with no status.
+func (te *TransactionExecutor) executeBatch(ctx context.Context, client *rubix.Client, senderNode *models.Node, batch []txPlan, opts TransactionOptions) []models.Transaction {
+	pending := make([]models.Transaction, len(batch))
+	requests := make([]rubix.RBTTransferRequest, len(batch))
+	for i, p := range batch {
+		amount := randomTransferAmount(opts.MinAmount, opts.MaxAmount)
+		pending[i] = models.Transaction{
+			ID:              uuid.New().String(),
+			Sender:          senderNode.DID,
+			Receiver:        p.receiverNode.DID,
+			TokenAmount:     amount,
+			RequestedAmount: amount,
+			Comment:         transactionComment(opts.CommentTemplate, p.index, senderNode, p.receiverNode, amount),
+			NodeID:          senderNode.ID,
+			Attempts:        1,
+		}
+		requests[i] = rubix.RBTTransferRequest{
+			Sender:     senderNode.DID,
+			Receiver:   p.receiverNode.DID,
+			TokenCount: amount,
+			Comment:    pending[i].Comment,
+			Type:       2, // Type 2 for RBT transfer
+		}
+	}
+
+	results, err := client.InitiateBatchTransferContext(ctx, requests, te.privKeyPassword())
+
+	for i := range pending {
+		transaction := &pending[i]
+		if ctx.Err() != nil && (err != nil || !results[i].Success) {
+			*transaction = models.Transaction{}
+			continue
+		}
+		if err != nil {
+			transaction.Timestamp = time.Now()
+			transaction.Status = "failed"
+			transaction.Error = fmt.Sprintf("Failed to execute transfer: %v", err)
+		} else {
+			result := results[i]
+			transaction.Timestamp = result.StartedAt
+			transaction.TimeTaken = result.TimeTaken
+			transaction.SubmitTime = result.SubmitTime
+			transaction.ConfirmTime = result.TimeTaken - result.SubmitTime
+			if result.Success {
+				transaction.Status = "success"
+				if result.TransactionID != "" {
+					transaction.ID = result.TransactionID
+				}
+				transaction.ExplorerURL = explorerLink(te.config.ExplorerBaseURL, transaction.ID)
+			} else {
+				transaction.Status = "failed"
+				transaction.Error = fmt.Sprintf("Failed to execute transfer: %s", result.Message)
+			}
+		}
+		if !opts.Warmup {
+			metrics.RecordTransaction(transaction.Status == "success")
+		}
+	}
+	return pending
+}
+
+// batchSize returns how many of a sender's transfers batch mode submits at a time: the
+// client's in-flight limit, so a batch keeps the node as busy as one unbounded submission
+func (te *TransactionExecutor) batchSize() int {
+	if te.config.Rubix != nil && te.config.Rubix.BatchTransferConcurrency > 0 {
+		return te.config.Rubix.BatchTransferConcurrency
+	}
+	return rubixconfig.DefaultRubixConfig().BatchTransferConcurrency
+}
+
+// reregisterDID re-broadcasts a node's DID after a peer discovery failure so later
+// transactions can find it. Registration is normally done once when the fleet is set
+// up; this repairs it at most once per cooldown per DID.
+func (te *TransactionExecutor) reregisterDID(node *models.Node, did string) {
+	te.reregisterMu.Lock()
+	if last, ok := te.reregisteredAt[did]; ok && time.Since(last) < didReregisterCooldown {
+		te.reregisterMu.Unlock()
+		return
+	}
+	te.reregisteredAt[did] = time.Now()
+	te.reregisterMu.Unlock()
+
+	logging.Warnf("Peer discovery failed, re-registering DID for %s", node.ID)
+	client := rubix.NewClientWithConfig(node.Port, te.config.Rubix)
+	if err := client.RegisterDID(did, te.privKeyPassword()); err != nil {
+		logging.Warnf("  ⚠ Warning: Failed to re-register DID for %s: %v", node.ID, err)
+	} else {
+		logging.Infof("  ✓ DID re-registered for %s", node.ID)
+	}
+}
+
+// transactionRetryBackoff is the wait before the first retry of a failed transfer; it
+// doubles with each further attempt up to maxTransactionRetryBackoff
+const (
+	transactionRetryBackoff    = 2 * time.Second
+	maxTransactionRetryBackoff = 30 * time.Second
+)
+
+// retryBackoff returns the wait before retrying a transfer that has failed attempts times
+func retryBackoff(attempts int) time.Duration {
+	backoff := transactionRetryBackoff
+	for i := 1; i < attempts && backoff < maxTransactionRetryBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxTransactionRetryBackoff)
+}
+
+// maxTransactionRetries returns how many times a failed transfer is re-attempted
+func (te *TransactionExecutor) maxTransactionRetries() int {
+	if te.config.Rubix != nil && te.config.Rubix.MaxTransactionRetries > 0 {
+		return te.config.Rubix.MaxTransactionRetries
+	}
+	return 0
+}
+
+// isRetryableTransferError reports whether a failed transfer can be sent again. A transfer
+// is not idempotent, so only failures where the node never took it are retried: the
+// initiate request not reaching the node, and the node not finding its counterparty.
+// Consensus timeouts are handled by confirmTimedOutTransfer.
+func isRetryableTransferError(err error) bool {
+	if errors.Is(err, rubix.ErrPeerNotFound) {
+		return true
+	}
+	return errors.Is(err, rubix.ErrNodeUnreachable) && rubix.SubmittedTransferID(err) == ""
+}
+
+// confirmTimedOutTransfer looks up a transfer whose consensus timed out, since the node may
+// still have finalised it. It returns the transfer's ID if the node has a record of it,
+// and whether it is safe to send again: only when the node confirms it has none.
+func confirmTimedOutTransfer(ctx context.Context, client *rubix.Client, err error) (string, bool) {
+	requestID := rubix.SubmittedTransferID(err)
+	if requestID == "" || !errors.Is(err, rubix.ErrConsensusTimeout) {
+		return "", false
+	}
+	found, lookupErr := client.GetTransactionByIDContext(ctx, requestID)
+	if lookupErr != nil {
+		logging.Warnf("Could not confirm timed-out transfer %s, not retrying it: %v", requestID, lookupErr)
+		return "", false
+	}
+	if found {
+		return requestID, false
+	}
+	return "", true
+}
+
+// privKeyPassword returns the configured private key password the nodes' DIDs were
+// created with (DefaultPrivKeyPassword)
+func (te *TransactionExecutor) privKeyPassword() string {
+	if te.config.Rubix != nil {
+		return te.config.Rubix.DefaultPrivKeyPassword
+	}
+	return rubixconfig.DefaultRubixConfig().DefaultPrivKeyPassword
+}
+
+// logSampled reports whether the transaction at index should log full detail at info
+// level. Only every Nth transaction (LogSampleEvery) does, so large runs don't flood the
+// log; the others log it at debug level, and failures are logged regardless.
+func (te *TransactionExecutor) logSampled(index int) bool {
+	every := 1
+	if te.config.Rubix != nil && te.config.Rubix.LogSampleEvery > 1 {
+		every = te.config.Rubix.LogSampleEvery
+	}
+	return index%every == 0
+}
+
+// sampledf logs transaction detail at info level for sampled transactions, and at debug
+// level otherwise so the full detail is still available
+func sampledf(sampled bool, format string, args ...interface{}) {
+	if sampled {
+		logging.Infof(format, args...)
+	} else {
+		logging.Debugf(format, args...)
+	}
+}
+
+// defaultCommentTemplate is the transaction comment used when the request doesn't set one
+const defaultCommentTemplate = "Transaction {index} from {sender} to {receiver}"
+
+// maxCommentTemplateLength caps a request's comment template
+const maxCommentTemplateLength = 200
+
+// commentPlaceholderPattern matches the {name} placeholders of a comment template
+var commentPlaceholderPattern = regexp.MustCompile(`\{[^{}]*\}`)
+
+// validateCommentTemplate rejects templates that are too long or use unknown placeholders,
+// which would otherwise end up in every transaction comment verbatim
+func validateCommentTemplate(template string) error {
+	if len(template) > maxCommentTemplateLength {
+		return fmt.Errorf("commentTemplate must be at most %d characters", maxCommentTemplateLength)
+	}
+	for _, placeholder := range commentPlaceholderPattern.FindAllString(template, -1) {
+		switch placeholder {
+		case "{index}", "{sender}", "{receiver}", "{amount}":
+		default:
+			return fmt.Errorf("commentTemplate has unknown placeholder %s (use {index}, {sender}, {receiver} or {amount})", placeholder)
+		}
+	}
+	return nil
+}
+
+// transactionComment expands a comment template for transaction index, using
+// defaultCommentTemplate when template is empty
+func transactionComment(template string, index int, senderNode, receiverNode *models.Node, amount float64) string {
+	if template == "" {
+		template = defaultCommentTemplate
+	}
+	return strings.NewReplacer(
+		"{index}", strconv.Itoa(index),
+		"{sender}", senderNode.DisplayName(),
+		"{receiver}", receiverNode.DisplayName(),
+		"{amount}", strconv.FormatFloat(amount, 'f', -1, 64),
+	).Replace(template)
+}
+
+// randomTransferAmount draws a transfer amount uniformly from [minAmount, maxAmount], bounds
+// that transferAmountRange has validated. Whole number bounds give whole amounts; otherwise
+// the amount has up to the 3 decimal places the Rubix API accepts.
+func randomTransferAmount(minAmount, maxAmount float64) float64 {
+	if minAmount == math.Trunc(minAmount) && maxAmount == math.Trunc(maxAmount) {
+		low, high := int64(minAmount), int64(maxAmount)
+		return float64(low + rand.Int63n(high-low+1))
+	}
+	low, high := int64(math.Round(minAmount*1000)), int64(math.Round(maxAmount*1000))
+	return float64(low+rand.Int63n(high-low+1)) / 1000
+}
+
+// hasTransferPrecision reports whether amount has at most the 3 decimal places the Rubix
+// API accepts
+func hasTransferPrecision(amount float64) bool {
+	thousandths := amount * 1000
+	return math.Abs(thousandths-math.Round(thousandths)) < 1e-6
+}
+
+// executeDryRunTransaction synthesizes a transaction with a random latency (0.2-2s, slept
+// so timestamps and throughput look like a real run) that succeeds with the given probability
+func executeDryRunTransaction(senderNode, receiverNode *models.Node, index int, tokenAmount, successRate float64, commentTemplate string) models.Transaction {
+	latency := 200*time.Millisecond + time.Duration(rand.Int63n(int64(1800*time.Millisecond)))
+	time.Sleep(latency)
+
+	transaction := models.Transaction{
+		ID:              uuid.New().String(),
+		Sender:          senderNode.DID,
+		Receiver:        receiverNode.DID,
+		TokenAmount:     tokenAmount,
+		RequestedAmount: tokenAmount,
+		Comment:         transactionComment(commentTemplate, index, senderNode, receiverNode, tokenAmount) + " (dry run)",
+		NodeID:          senderNode.ID,
+		Timestamp:       time.Now().Add(-latency),
+		TimeTaken:       latency,
+		SubmitTime:      latency / 10,
+		ConfirmTime:     latency - latency/10,
+		Status:          "success",
+		Attempts:        1,
+	}
+	if rand.Float64() >= successRate {
+		transaction.Status = "failed"
+		transaction.Error = "Failed to execute transfer: simulated failure (dry run)"
+	}
+	return transaction
 }
 
-func (te *TransactionExecutor) executeRealTransaction(senderNode *models.Node, senderDID string, receiverNode *models.Node, receiverDID string, index int) models.Transaction {
-	tokenAmount := float64(rand.Intn(10) + 1)
+// executeRealTransaction transfers tokenAmount from the sender to the receiver node. Once ctx
+// is done the transfer's requests are abandoned and it is not retried; a transfer that
+// failed that way is returned with no status, as it was cancelled rather than failed.
+func (te *TransactionExecutor) executeRealTransaction(ctx context.Context, senderNode *models.Node, senderDID string, receiverNode *models.Node, receiverDID string, index int, tokenAmount float64, refill func(*models.Node, float64) error, commentTemplate string) models.Transaction {
 
 	transaction := models.Transaction{
-		ID:          uuid.New().String(),
-		Sender:      senderDID,
-		Receiver:    receiverDID,
-		TokenAmount: tokenAmount,
-		Comment:     fmt.Sprintf("Transaction %d from %s to %s", index, senderNode.ID, receiverNode.ID),
-		NodeID:      senderNode.ID, // Transaction initiated from sender node
-		Timestamp:   time.Now(),
-		Status:      "pending",
+		ID:              uuid.New().String(),
+		Sender:          senderDID,
+		Receiver:        receiverDID,
+		TokenAmount:     tokenAmount,
+		RequestedAmount: tokenAmount,
+		Comment:         transactionComment(commentTemplate, index, senderNode, receiverNode, tokenAmount),
+		NodeID:          senderNode.ID, // Transaction initiated from sender node
+		Timestamp:       time.Now(),
+		Status:          "pending",
 	}
 
 	startTime := time.Now()
 
 	// Check sender's balance before attempting transaction
-	client := rubix.NewClient(senderNode.Port)
+	client := rubix.NewClientWithConfig(senderNode.Port, te.config.Rubix)
+	verbose := te.logSampled(index)
+	client.SetVerbose(verbose)
 
-	balance, err := client.GetAccountBalance(senderDID)
+	balance, err := client.GetAccountBalanceContext(ctx, senderDID)
+	if err != nil && ctx.Err() != nil {
+		return models.Transaction{}
+	}
 	if err != nil {
 		transaction.Status = "failed"
 		transaction.Error = fmt.Sprintf("Failed to check balance: %v", err)
 		transaction.TimeTaken = time.Since(startTime)
-		log.Printf("Failed to check balance for %s: %v", senderNode.ID, err)
+		logging.Errorf("Failed to check balance for %s: %v", senderNode.ID, err)
 		return transaction
 	}
 
-	log.Printf("Node %s balance: %.3f RBT, attempting to send: %.3f RBT", senderNode.ID, balance, tokenAmount)
+	sampledf(verbose, "Node %s balance: %.3f RBT, attempting to send: %.3f RBT", senderNode.ID, balance, tokenAmount)
+
+	if balance < tokenAmount && refill != nil {
+		if err := refill(senderNode, balance); err != nil {
+			logging.Warnf("Warning: could not refill %s before transaction %d: %v", senderNode.ID, index, err)
+		} else if refreshed, err := client.GetAccountBalanceContext(ctx, senderDID); err == nil {
+			balance = refreshed
+		}
+		// The refill is recorded on its own; the transaction's time starts after it
+		startTime = time.Now()
+		transaction.Timestamp = startTime
+	}
 
 	// Check if sender has sufficient balance
 	if balance < tokenAmount {
 		// Try with a smaller amount that the sender can afford
-		if balance > 1.0 {
+		if balance > minAdjustableBalance {
 			// Use 80% of available balance to leave some for fees
 			tokenAmount = balance * 0.8
 			// Round to 3 decimal places as required by Rubix API
 			tokenAmount = float64(int(tokenAmount*1000)) / 1000.0
 			transaction.TokenAmount = tokenAmount
-			log.Printf("Adjusted transaction amount to %.3f RBT (80%% of available %.3f RBT)", tokenAmount, balance)
+			transaction.Comment = transactionComment(commentTemplate, index, senderNode, receiverNode, tokenAmount)
+			logging.Infof("Adjusted transaction amount to %.3f RBT (80%% of available %.3f RBT)", tokenAmount, balance)
 		} else {
 			transaction.Status = "failed"
 			transaction.Error = fmt.Sprintf("Insufficient balance: have %.2f RBT, need %.2f RBT", balance, tokenAmount)
 			transaction.TimeTaken = time.Since(startTime)
-			log.Printf("Insufficient balance for %s: have %.2f, need %.2f", senderNode.ID, balance, tokenAmount)
+			logging.Infof("Insufficient balance for %s: have %.2f, need %.2f", senderNode.ID, balance, tokenAmount)
 			return transaction
 		}
 	}
 
-	// Use the new InitiateRBTTransfer function with signature handling
-	// Using hardcoded password for test environment
-	transactionID, err := client.InitiateRBTTransfer(
-		transaction.Sender,
-		transaction.Receiver,
-		transaction.TokenAmount,
-		transaction.Comment,
-		"mypassword", // Default password for test environment
-	)
+	// Use the new InitiateRBTTransfer function with signature handling, retrying
+	// transient failures with a doubling backoff
+	maxRetries := te.maxTransactionRetries()
+	var transactionID string
+	for {
+		transaction.Attempts++
+		attemptStart := time.Now()
+		transactionID, transaction.SubmitTime, err = client.InitiateRBTTransferTimedContext(
+			ctx,
+			transaction.Sender,
+			transaction.Receiver,
+			transaction.TokenAmount,
+			transaction.Comment,
+			te.privKeyPassword(),
+		)
+		transaction.ConfirmTime = time.Since(attemptStart) - transaction.SubmitTime
+		if err == nil || ctx.Err() != nil {
+			break
+		}
+		retryable := isRetryableTransferError(err)
+		if errors.Is(err, rubix.ErrConsensusTimeout) {
+			var confirmedID string
+			if confirmedID, retryable = confirmTimedOutTransfer(ctx, client, err); confirmedID != "" {
+				logging.Infof("Transaction %d timed out waiting for consensus but was finalised as %s", index, confirmedID)
+				transactionID, err = confirmedID, nil
+				break
+			}
+		}
+		if transaction.Attempts > maxRetries || !retryable {
+			break
+		}
+
+		backoff := retryBackoff(transaction.Attempts)
+		logging.Warnf("Transaction %d attempt %d/%d failed, retrying in %v: %v", index, transaction.Attempts, maxRetries+1, backoff, err)
+		if errors.Is(err, rubix.ErrPeerNotFound) {
+			te.reregisterDID(senderNode, senderDID)
+			te.reregisterDID(receiverNode, receiverDID)
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
 
 	transaction.TimeTaken = time.Since(startTime)
 
+	if err != nil && ctx.Err() != nil {
+		logging.Infof("Transaction %d abandoned: %v", index, ctx.Err())
+		return models.Transaction{}
+	}
 	if err != nil {
 		transaction.Status = "failed"
 		transaction.Error = fmt.Sprintf("Failed to execute transfer: %v", err)
@@ -314,7 +1115,11 @@ func (te *TransactionExecutor) executeRealTransaction(senderNode *models.Node, s
 		if len(txID) > 8 {
 			txID = txID[:8]
 		}
-		log.Printf("Transaction %s failed: %v", txID, err)
+		logging.Warnf("Transaction %s failed: %v", txID, err)
+		if errors.Is(err, rubix.ErrPeerNotFound) {
+			te.reregisterDID(senderNode, senderDID)
+			te.reregisterDID(receiverNode, receiverDID)
+		}
 		return transaction
 	}
 
@@ -324,12 +1129,164 @@ func (te *TransactionExecutor) executeRealTransaction(senderNode *models.Node, s
 	}
 
 	transaction.Status = "success"
+	transaction.ExplorerURL = explorerLink(te.config.ExplorerBaseURL, transaction.ID)
 	// Safely truncate ID for logging
 	txID := transaction.ID
 	if len(txID) > 8 {
 		txID = txID[:8]
 	}
-	log.Printf("Transaction %s completed successfully in %v", txID, transaction.TimeTaken)
+	sampledf(verbose, "Transaction %s completed successfully in %v", txID, transaction.TimeTaken)
 
 	return transaction
 }
+
+// snapshotWorkers bounds how many nodes SnapshotBalances queries at once
+const snapshotWorkers = 8
+
+// SnapshotBalances returns the current RBT balance of each transaction node keyed by node
+// ID, querying up to snapshotWorkers nodes in parallel. Nodes that don't answer are left out.
+func (te *TransactionExecutor) SnapshotBalances(nodes []*models.Node) map[string]float64 {
+	type balanceResult struct {
+		node    *models.Node
+		balance float64
+		err     error
+	}
+
+	jobs := make(chan *models.Node)
+	results := make(chan balanceResult)
+	var wg sync.WaitGroup
+	for i := 0; i < snapshotWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for node := range jobs {
+				client := rubix.NewClientWithConfig(node.Port, te.config.Rubix)
+				balance, err := client.GetAccountBalance(node.DID)
+				results <- balanceResult{node: node, balance: balance, err: err}
+			}
+		}()
+	}
+	go func() {
+		for _, node := range nodes {
+			if !node.IsQuorum && node.DID != "" {
+				jobs <- node
+			}
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+
+	balances := make(map[string]float64, len(nodes))
+	for result := range results {
+		if result.err != nil {
+			logging.Errorf("Failed to snapshot balance for %s: %v", result.node.ID, result.err)
+			continue
+		}
+		balances[result.node.ID] = result.balance
+	}
+	return balances
+}
+
+// SnapshotAccountInfo queries the available, pledged, locked and pinned RBT of each
+// transaction node, ordered by node ID. Nodes that don't answer are left out.
+func (te *TransactionExecutor) SnapshotAccountInfo(nodes []*models.Node) []models.NodeAccountBalance {
+	var balances []models.NodeAccountBalance
+	for _, node := range nodes {
+		if node.IsQuorum || node.DID == "" {
+			continue
+		}
+
+		client := rubix.NewClientWithConfig(node.Port, te.config.Rubix)
+		info, err := client.GetDIDAccountInfo(node.DID)
+		if err != nil {
+			logging.Warnf("Warning: could not read account info for %s: %v", node.ID, err)
+			continue
+		}
+		balances = append(balances, models.NodeAccountBalance{
+			NodeID:    node.ID,
+			DID:       node.DID,
+			Available: info.RBTAmount,
+			Pledged:   info.PledgedRBT,
+			Locked:    info.LockedRBT,
+			Pinned:    info.PinnedRBT,
+		})
+	}
+	sort.Slice(balances, func(i, j int) bool { return balances[i].NodeID < balances[j].NodeID })
+	return balances
+}
+
+// VerifySettlement re-queries balances and transaction records after the settle period and
+// reconciles them against the statuses reported by the transfer API.
+// Balance deltas are only indicative: pledging during consensus can temporarily move tokens
+// out of the available balance even when every transfer landed.
+func (te *TransactionExecutor) VerifySettlement(nodes []*models.Node, transactions []models.Transaction, initialBalances map[string]float64) *models.SettlementReconciliation {
+	reconciliation := &models.SettlementReconciliation{
+		Unconfirmed: []string{},
+	}
+
+	nodesByID := make(map[string]*models.Node)
+	nodesByDID := make(map[string]*models.Node)
+	for _, node := range nodes {
+		nodesByID[node.ID] = node
+		nodesByDID[node.DID] = node
+	}
+
+	expectedDeltas := make(map[string]float64)
+	for _, tx := range transactions {
+		if tx.Status != "success" {
+			continue
+		}
+		reconciliation.ReportedSuccess++
+
+		if sender, ok := nodesByDID[tx.Sender]; ok {
+			expectedDeltas[sender.ID] -= tx.TokenAmount
+		}
+		if receiver, ok := nodesByDID[tx.Receiver]; ok {
+			expectedDeltas[receiver.ID] += tx.TokenAmount
+		}
+
+		node, ok := nodesByID[tx.NodeID]
+		if !ok {
+			reconciliation.Unverifiable++
+			continue
+		}
+
+		client := rubix.NewClientWithConfig(node.Port, te.config.Rubix)
+		found, err := client.GetTransactionByID(tx.ID)
+		if err != nil {
+			logging.Infof("Could not verify transaction %s on %s: %v", tx.ID, node.ID, err)
+			reconciliation.Unverifiable++
+			continue
+		}
+
+		if found {
+			reconciliation.Confirmed++
+		} else {
+			logging.Infof("Transaction %s was reported successful but is not recorded on %s", tx.ID, node.ID)
+			reconciliation.Unconfirmed = append(reconciliation.Unconfirmed, tx.ID)
+		}
+	}
+
+	finalBalances := te.SnapshotBalances(nodes)
+	for _, node := range nodes {
+		initial, hasInitial := initialBalances[node.ID]
+		final, hasFinal := finalBalances[node.ID]
+		if !hasInitial || !hasFinal {
+			continue
+		}
+
+		expected := expectedDeltas[node.ID]
+		actual := final - initial
+		reconciliation.NodeBalances = append(reconciliation.NodeBalances, models.NodeBalanceCheck{
+			NodeID:         node.ID,
+			InitialBalance: initial,
+			FinalBalance:   final,
+			ExpectedDelta:  expected,
+			ActualDelta:    actual,
+			Matches:        math.Abs(expected-actual) < 0.001,
+		})
+	}
+
+	return reconciliation
+}
@@ -0,0 +1,347 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	rubixconfig "github.com/rubix-simulator/backend/config"
+	"github.com/rubix-simulator/backend/internal/config"
+	"github.com/rubix-simulator/backend/internal/models"
+	"github.com/rubix-simulator/backend/internal/rubix"
+)
+
+const testTransactionID = "08765414814e03e9ffb71f3cedda61c7246f40cf1a48b2d5f6cdfdfc359b13e3"
+
+// fakeNode answers the node endpoints a transfer and a DID registration use, and records
+// the password of every signature response
+type fakeNode struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	passwords map[string][]string // Signature response passwords by the request that asked for them
+}
+
+func newFakeNode(t *testing.T) *fakeNode {
+	t.Helper()
+	node := &fakeNode{passwords: make(map[string][]string)}
+	reply := func(w http.ResponseWriter, body interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(body)
+	}
+	passwordNeeded := func(id string) map[string]interface{} {
+		return map[string]interface{}{
+			"status":  true,
+			"message": "Password needed",
+			"result":  map[string]interface{}{"id": id, "mode": 4},
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/get-account-info", func(w http.ResponseWriter, r *http.Request) {
+		reply(w, map[string]interface{}{
+			"status":       true,
+			"account_info": []map[string]interface{}{{"did": r.URL.Query().Get("did"), "rbt_amount": 100}},
+		})
+	})
+	mux.HandleFunc("/api/initiate-rbt-transfer", func(w http.ResponseWriter, r *http.Request) {
+		reply(w, passwordNeeded("transfer"))
+	})
+	mux.HandleFunc("/api/register-did", func(w http.ResponseWriter, r *http.Request) {
+		reply(w, passwordNeeded("register"))
+	})
+	mux.HandleFunc("/api/signature-response", func(w http.ResponseWriter, r *http.Request) {
+		var signature struct {
+			ID       string `json:"id"`
+			Password string `json:"password"`
+		}
+		json.NewDecoder(r.Body).Decode(&signature)
+		node.mu.Lock()
+		node.passwords[signature.ID] = append(node.passwords[signature.ID], signature.Password)
+		node.mu.Unlock()
+		reply(w, map[string]interface{}{
+			"status":  true,
+			"message": "Transfer finished successfully in 1.5s with trnxid " + testTransactionID,
+		})
+	})
+	node.Server = httptest.NewServer(mux)
+	t.Cleanup(node.Close)
+	return node
+}
+
+func (n *fakeNode) port() int {
+	u, _ := url.Parse(n.URL)
+	port, _ := strconv.Atoi(u.Port())
+	return port
+}
+
+func (n *fakeNode) signedWith(requestID string) []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]string(nil), n.passwords[requestID]...)
+}
+
+func TestConfiguredPasswordSignsTransfersAndRegistrations(t *testing.T) {
+	const password = "custom-secret"
+	node := newFakeNode(t)
+
+	rubixCfg := rubixconfig.DefaultRubixConfig()
+	rubixCfg.DefaultPrivKeyPassword = password
+	te := NewTransactionExecutor(&config.Config{Rubix: rubixCfg})
+
+	sender := &models.Node{ID: "node2", Port: node.port(), DID: "sender-did"}
+	receiver := &models.Node{ID: "node3", DID: "receiver-did"}
+	tx := te.executeRealTransaction(context.Background(), sender, sender.DID, receiver, receiver.DID, 0, 1, nil, "")
+	if tx.Status != "success" {
+		t.Fatalf("transaction status = %q (%s), want success", tx.Status, tx.Error)
+	}
+	if got := node.signedWith("transfer"); len(got) != 1 || got[0] != password {
+		t.Errorf("transfer signed with %q, want [%q]", got, password)
+	}
+
+	te.reregisterDID(sender, sender.DID)
+	if got := node.signedWith("register"); len(got) != 1 || got[0] != password {
+		t.Errorf("DID registration signed with %q, want [%q]", got, password)
+	}
+}
+
+func TestSnapshotBalances(t *testing.T) {
+	te := NewTransactionExecutor(&config.Config{Rubix: rubixconfig.DefaultRubixConfig()})
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": false, "message": "DID not found"})
+	}))
+	t.Cleanup(failing.Close)
+	failingURL, _ := url.Parse(failing.URL)
+	failingPort, _ := strconv.Atoi(failingURL.Port())
+
+	nodes := []*models.Node{
+		{ID: "node0", Port: newFakeNode(t).port(), DID: "quorum-did", IsQuorum: true},
+		{ID: "node1", Port: newFakeNode(t).port()},
+		{ID: "node99", Port: failingPort, DID: "unknown-did"},
+	}
+	for i := 2; i < 2+2*snapshotWorkers; i++ {
+		nodes = append(nodes, &models.Node{ID: "node" + strconv.Itoa(i), Port: newFakeNode(t).port(), DID: "did-" + strconv.Itoa(i)})
+	}
+
+	balances := te.SnapshotBalances(nodes)
+	if len(balances) != 2*snapshotWorkers {
+		t.Errorf("got %d balances, want one per answering transaction node with a DID (%d): %v",
+			len(balances), 2*snapshotWorkers, balances)
+	}
+	for _, skipped := range []string{"node0", "node1", "node99"} {
+		if _, ok := balances[skipped]; ok {
+			t.Errorf("%s should have no balance, got %v", skipped, balances[skipped])
+		}
+	}
+	if balances["node2"] != 100 {
+		t.Errorf("node2 balance = %v, want 100", balances["node2"])
+	}
+}
+
+// TestTransferRetries checks that only failures where the node never took the transfer
+// are sent again
+func TestTransferRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		signature    func(w http.ResponseWriter) // Answers /api/signature-response
+		recorded     bool                        // Whether /api/get-by-txnId finds the transfer
+		wantStatus   string
+		wantID       string
+		wantAttempts int
+	}{
+		{
+			name: "peer not found",
+			signature: func(w http.ResponseWriter) {
+				json.NewEncoder(w).Encode(map[string]interface{}{"status": false, "message": "failed to get peer: not found"})
+			},
+			wantStatus:   "failed",
+			wantAttempts: 2,
+		},
+		{
+			name: "consensus failed",
+			signature: func(w http.ResponseWriter) {
+				json.NewEncoder(w).Encode(map[string]interface{}{"status": false, "message": "consensus failed"})
+			},
+			wantStatus:   "failed",
+			wantAttempts: 1,
+		},
+		{
+			name: "invalid response",
+			signature: func(w http.ResponseWriter) {
+				w.Write([]byte("not json"))
+			},
+			wantStatus:   "failed",
+			wantAttempts: 1,
+		},
+		{
+			name:         "timeout finalised on chain",
+			signature:    func(w http.ResponseWriter) { time.Sleep(1500 * time.Millisecond) },
+			recorded:     true,
+			wantStatus:   "success",
+			wantID:       "transfer",
+			wantAttempts: 1,
+		},
+		{
+			name:         "timeout with no record",
+			signature:    func(w http.ResponseWriter) { time.Sleep(1500 * time.Millisecond) },
+			wantStatus:   "failed",
+			wantAttempts: 2,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var mu sync.Mutex
+			initiated := 0
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/get-account-info", func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"status":       true,
+					"account_info": []map[string]interface{}{{"rbt_amount": 100}},
+				})
+			})
+			mux.HandleFunc("/api/initiate-rbt-transfer", func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				initiated++
+				mu.Unlock()
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"status":  true,
+					"message": "Password needed",
+					"result":  map[string]interface{}{"id": "transfer", "mode": 4},
+				})
+			})
+			mux.HandleFunc("/api/signature-response", func(w http.ResponseWriter, r *http.Request) {
+				tt.signature(w)
+			})
+			mux.HandleFunc("/api/get-by-txnId", func(w http.ResponseWriter, r *http.Request) {
+				details := []map[string]interface{}{}
+				if tt.recorded {
+					details = append(details, map[string]interface{}{"TransactionID": r.URL.Query().Get("txnID")})
+				}
+				json.NewEncoder(w).Encode(map[string]interface{}{"status": tt.recorded, "TxnDetails": details})
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+			serverURL, _ := url.Parse(server.URL)
+			port, _ := strconv.Atoi(serverURL.Port())
+
+			rubixCfg := rubixconfig.DefaultRubixConfig()
+			rubixCfg.MaxTransactionRetries = 1
+			rubixCfg.SignatureTimeout = 1
+			te := NewTransactionExecutor(&config.Config{Rubix: rubixCfg})
+			sender := &models.Node{ID: "node2", Port: port, DID: "sender-did"}
+			receiver := &models.Node{ID: "node3", DID: "receiver-did"}
+
+			tx := te.executeRealTransaction(context.Background(), sender, sender.DID, receiver, receiver.DID, 0, 1, nil, "")
+			if tx.Status != tt.wantStatus {
+				t.Errorf("status = %q (%s), want %q", tx.Status, tx.Error, tt.wantStatus)
+			}
+			if tt.wantID != "" && tx.ID != tt.wantID {
+				t.Errorf("transaction ID = %q, want %q", tx.ID, tt.wantID)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if initiated != tt.wantAttempts || tx.Attempts != tt.wantAttempts {
+				t.Errorf("initiated %d time(s), %d attempt(s) recorded, want %d", initiated, tx.Attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestTransferAmountRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max float64
+		wantErr  bool
+	}{
+		{name: "defaults"},
+		{name: "whole bounds", min: 2, max: 20},
+		{name: "fractional bounds", min: 0.001, max: 0.5},
+		{name: "largest allowed", min: 1, max: maxTransferAmount},
+		{name: "overflowing max", min: 1, max: 1e19, wantErr: true},
+		{name: "min below precision", min: 0.0005, max: 1, wantErr: true},
+		{name: "min with 4 decimals", min: 0.0015, max: 1, wantErr: true},
+		{name: "max with 4 decimals", min: 1, max: 2.0001, wantErr: true},
+		{name: "max below min", min: 5, max: 2, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := transferAmountRange(models.SimulationRequest{MinTokenAmount: tt.min, MaxTokenAmount: tt.max})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRandomTransferAmount(t *testing.T) {
+	ranges := [][2]float64{{1, 10}, {0.001, 0.5}, {1, maxTransferAmount}, {2.5, 2.5}}
+	for _, r := range ranges {
+		for i := 0; i < 1000; i++ {
+			amount := randomTransferAmount(r[0], r[1])
+			if amount < r[0] || amount > r[1] || !hasTransferPrecision(amount) {
+				t.Fatalf("randomTransferAmount(%v, %v) = %v", r[0], r[1], amount)
+			}
+			if r[0] == 1 && r[1] == 10 && amount != math.Trunc(amount) {
+				t.Fatalf("whole bounds gave %v", amount)
+			}
+		}
+	}
+}
+
+// Transfers abandoned by a cancelled simulation are dropped rather than recorded as failed
+func TestCancelledTransfersAreDropped(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/get-account-info", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":       true,
+			"account_info": []map[string]interface{}{{"rbt_amount": 100}},
+		})
+	})
+	mux.HandleFunc("/api/initiate-rbt-transfer", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":  true,
+			"message": "Password needed",
+			"result":  map[string]interface{}{"id": "transfer", "mode": 4},
+		})
+	})
+	// Consensus never finishes; the transfer only ends when it is cancelled
+	done := make(chan struct{})
+	mux.HandleFunc("/api/signature-response", func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	defer close(done)
+	serverURL, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverURL.Port())
+
+	te := NewTransactionExecutor(&config.Config{Rubix: rubixconfig.DefaultRubixConfig()})
+	sender := &models.Node{ID: "node2", Port: port, DID: "sender-did"}
+	receiver := &models.Node{ID: "node3", DID: "receiver-did"}
+	cancelSoon := func() context.Context {
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		t.Cleanup(cancel)
+		return ctx
+	}
+
+	if tx := te.executeRealTransaction(cancelSoon(), sender, sender.DID, receiver, receiver.DID, 0, 1, nil, ""); tx.Status != "" {
+		t.Errorf("cancelled transfer recorded as %q (%s), want it dropped", tx.Status, tx.Error)
+	}
+
+	client := rubix.NewClientWithConfig(port, te.config.Rubix)
+	batch := []txPlan{{index: 0, senderNode: sender, receiverNode: receiver}, {index: 1, senderNode: sender, receiverNode: receiver}}
+	for i, tx := range te.executeBatch(cancelSoon(), client, sender, batch, TransactionOptions{MinAmount: 1, MaxAmount: 1}) {
+		if tx.Status != "" {
+			t.Errorf("cancelled batch transfer %d recorded as %q (%s), want it dropped", i, tx.Status, tx.Error)
+		}
+	}
+}
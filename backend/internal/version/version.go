@@ -0,0 +1,34 @@
+// Package version holds the simulator build information. The variables are set at
+// build time via -ldflags, e.g.
+//
+//	go build -ldflags "-X github.com/rubix-simulator/backend/internal/version.Commit=$(git rev-parse --short HEAD)" ./cmd/server
+package version
+
+import "runtime"
+
+var (
+	// Version is the simulator release version
+	Version = "1.0.0"
+	// Commit is the git commit the binary was built from
+	Commit = "unknown"
+	// BuildTime is the UTC time the binary was built
+	BuildTime = "unknown"
+)
+
+// Info describes the running simulator build
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"buildTime"`
+	GoVersion string `json:"goVersion"`
+}
+
+// Get returns the build information of the running binary
+func Get() Info {
+	return Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+}
@@ -5,7 +5,11 @@ echo.
 REM Build backend
 echo Building backend...
 cd backend
-go build -o rubix-simulator.exe cmd/server/main.go
+set VERSION_PKG=github.com/rubix-simulator/backend/internal/version
+set COMMIT=unknown
+for /f %%i in ('git rev-parse --short HEAD 2^>nul') do set COMMIT=%%i
+for /f %%i in ('powershell -NoProfile -Command "(Get-Date).ToUniversalTime().ToString('yyyy-MM-ddTHH:mm:ssZ')"') do set BUILD_TIME=%%i
+go build -ldflags "-X %VERSION_PKG%.Commit=%COMMIT% -X %VERSION_PKG%.BuildTime=%BUILD_TIME%" -o rubix-simulator.exe cmd/server/main.go
 if %errorlevel% neq 0 (
     echo Backend build failed!
     pause
@@ -11,7 +11,13 @@ NC='\033[0m'
 # Build backend
 echo "Building backend..."
 cd backend
-go build -o rubix-simulator cmd/server/main.go
+VERSION_PKG=github.com/rubix-simulator/backend/internal/version
+LDFLAGS="-X $VERSION_PKG.Commit=$(git rev-parse --short HEAD 2>/dev/null || echo unknown)"
+LDFLAGS="$LDFLAGS -X $VERSION_PKG.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+if VERSION=$(git describe --tags --abbrev=0 2>/dev/null); then
+    LDFLAGS="$LDFLAGS -X $VERSION_PKG.Version=$VERSION"
+fi
+go build -ldflags "$LDFLAGS" -o rubix-simulator cmd/server/main.go
 if [ $? -ne 0 ]; then
     echo -e "${RED}Backend build failed!${NC}"
     exit 1
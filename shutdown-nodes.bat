@@ -4,9 +4,18 @@ setlocal enabledelayedexpansion
 echo Shutting down Rubix nodes...
 echo.
 
+REM Match the backend's RUBIX_INSTANCE_OFFSET: each instance shifts its ports by 100 and
+REM keeps its data in rubix-data-<offset>
+set "instance_offset=0"
+if defined RUBIX_INSTANCE_OFFSET set "instance_offset=%RUBIX_INSTANCE_OFFSET%"
 set "base_port=20000"
-set "nodes_dir=backend\rubix-data\nodes"
-set "rubix_exe=backend\rubix-data\rubixgoplatform\windows\rubixgoplatform.exe"
+set "data_dir=backend\rubix-data"
+if %instance_offset% GTR 0 (
+    set /a base_port=20000 + %instance_offset% * 100
+    set "data_dir=backend\rubix-data-%instance_offset%"
+)
+set "nodes_dir=%data_dir%\nodes"
+set "rubix_exe=%data_dir%\rubixgoplatform\windows\rubixgoplatform.exe"
 
 REM Check if nodes directory exists
 if not exist "%nodes_dir%" (
@@ -3,14 +3,22 @@
 echo "Shutting down Rubix nodes..."
 echo
 
+# Match the backend's RUBIX_INSTANCE_OFFSET: each instance shifts its ports by 100 and
+# keeps its data in rubix-data-<offset>
+INSTANCE_OFFSET=${RUBIX_INSTANCE_OFFSET:-0}
 BASE_PORT=20000
-NODES_DIR="backend/rubix-data/nodes"
+DATA_DIR="backend/rubix-data"
+if [ "$INSTANCE_OFFSET" -gt 0 ]; then
+    BASE_PORT=$((BASE_PORT + INSTANCE_OFFSET * 100))
+    DATA_DIR="${DATA_DIR}-${INSTANCE_OFFSET}"
+fi
+NODES_DIR="$DATA_DIR/nodes"
 
 # Detect OS and set rubix executable path
 if [[ "$OSTYPE" == "linux-gnu"* ]]; then
-    RUBIX_EXE="backend/rubix-data/rubixgoplatform/linux/rubixgoplatform"
+    RUBIX_EXE="$DATA_DIR/rubixgoplatform/linux/rubixgoplatform"
 elif [[ "$OSTYPE" == "darwin"* ]]; then
-    RUBIX_EXE="backend/rubix-data/rubixgoplatform/mac/rubixgoplatform"
+    RUBIX_EXE="$DATA_DIR/rubixgoplatform/mac/rubixgoplatform"
 else
     echo "Unsupported OS: $OSTYPE"
     exit 1